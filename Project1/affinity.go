@@ -0,0 +1,51 @@
+package main
+
+import (
+	"math"
+	"slices"
+)
+
+// WithCacheAffinity is a teaching model of cache warmth, not a physically accurate one. A process
+// dispatched again with nobody else having run since its last slice finds its cache warm, and its
+// remaining burst shrinks by warmFactor (0 to just under 1). A process dispatched right after a
+// different one first spends coldPenalty ticks refilling its cache. Preemption decisions still
+// go by the true remaining burst, and a round-robin slice always makes at least a tick of
+// progress, so switching can't livelock. It applies to SJF and round-robin. Every completed
+// process' burst in the result is the CPU time it actually used.
+func WithCacheAffinity(warmFactor float64, coldPenalty int64) ScheduleOption {
+	return func(cfg *scheduleConfig) {
+		cfg.warmFactor = math.Min(max(warmFactor, 0), 0.99)
+		cfg.coldPenalty = max(coldPenalty, 0)
+	}
+}
+
+// dispatch is the remaining burst of pid as it's dispatched after the gantt so far, and the cold
+// ticks it must spend refilling its cache before it makes progress on that burst.
+func (cfg scheduleConfig) dispatch(pid string, gantt []TimeSlice, remaining int64) (int64, int64) {
+	if len(gantt) == 0 {
+		return remaining, 0
+	}
+	if gantt[len(gantt)-1].PID == pid {
+		return max(int64(math.Ceil(float64(remaining)*(1-cfg.warmFactor))), 1), 0
+	}
+	return remaining, cfg.coldPenalty
+}
+
+// resultProcesses is processes with the burst of every finished one replaced by the CPU time it
+// used, when cache affinity changed how much work it needed.
+func (cfg scheduleConfig) resultProcesses(processes []Process, gantt []TimeSlice, remaining map[string]int64) []Process {
+	if cfg.warmFactor == 0 && cfg.coldPenalty == 0 {
+		return processes
+	}
+	ran := make(map[string]int64, len(processes))
+	for _, slice := range gantt {
+		ran[slice.PID] += slice.Stop - slice.Start
+	}
+	used := slices.Clone(processes)
+	for i, p := range used {
+		if remaining[p.ProcessID] == 0 {
+			used[i].BurstDuration = ran[p.ProcessID]
+		}
+	}
+	return used
+}
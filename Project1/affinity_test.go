@@ -0,0 +1,54 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithCacheAffinity(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: "B", ArrivalTime: 0, BurstDuration: 8},
+	}
+
+	if diff := cmp.Diff(RRQuantumSchedule(io.Discard, "rr", processes, 1),
+		RRQuantumScheduleWith(io.Discard, "rr", processes, 1, WithCacheAffinity(0, 0))); diff != "" {
+		t.Errorf("no affinity changed the schedule: %s", diff)
+	}
+
+	// a long quantum keeps each process resident; a quantum of 2 switches every other tick.
+	resident := RRQuantumScheduleWith(io.Discard, "resident", processes, 100, WithCacheAffinity(0, 1))
+	switched := RRQuantumScheduleWith(io.Discard, "switched", processes, 2, WithCacheAffinity(0, 1))
+	if resident.Makespan != 17 {
+		t.Errorf("resident makespan = %d, want 17 (one cold switch)", resident.Makespan)
+	}
+	if switched.Makespan <= resident.Makespan {
+		t.Errorf("switched makespan = %d, want more than resident %d", switched.Makespan, resident.Makespan)
+	}
+	for _, r := range []ScheduleResult{resident, switched} {
+		for _, p := range r.Processes {
+			if p.Remaining != 0 || p.Wait < 0 {
+				t.Errorf("%s: %s = %+v, want completed", r.Title, p.ProcessID, p)
+			}
+		}
+	}
+
+	// alone on the CPU, every re-dispatch after a quantum is warm: 8 -> 6 -> 5 -> 3 -> 3 -> 1 -> 1.
+	warm := RRQuantumScheduleWith(io.Discard, "warm", processes[:1], 2, WithCacheAffinity(0.25, 1))
+	if warm.Makespan != 7 || warm.Processes[0].Remaining != 0 {
+		t.Errorf("warm makespan = %d, remaining = %d, want 7 and 0", warm.Makespan, warm.Processes[0].Remaining)
+	}
+
+	sjfSwitched := SJFScheduleWith(io.Discard, "sjf", []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 8},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 6},
+	}, WithCacheAffinity(0, 2))
+	// C preempts B one tick into its refill, then C, B and A each run after a full refill.
+	if sjfSwitched.Makespan != 24+1+3*2 {
+		t.Errorf("sjf makespan = %d, want %d", sjfSwitched.Makespan, 24+1+3*2)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"io"
+)
+
+// PriorityAgingSchedule is preemptive priority scheduling with aging: a ready process gains one
+// priority level (its number drops by one) for every `every` ticks it has waited since it last ran,
+// so a low priority process can't be starved by a stream of more important ones. A process is back
+// at its own Priority once it runs. An arrival or an aged process preempts the running one only
+// when strictly more important; other ties go to the earliest queued. every of 0 or less disables
+// aging, which is PreemptivePrioritySchedule. Aging stops at the ceiling of bounds, so a process
+// can't age into the levels above it, and one already past it doesn't age. It writes the longest
+// wait next to the one without aging, to show the starvation aging avoids, and with a ceiling how
+// often a waiting process aged up to it.
+func PriorityAgingSchedule(w io.Writer, title string, processes []Process, every int64, bounds PriorityBounds) ScheduleResult {
+	var (
+		running string
+		// ranUntil is when a process last ran, its wait counting from then or from its arrival.
+		ranUntil = make(map[string]int64, len(processes))
+		// atCeiling holds the processes at the ceiling since they last ran, each counted in hits once.
+		atCeiling = make(map[string]bool)
+		hits      int
+	)
+	effective := func(p Process, now int64) int64 {
+		if every <= 0 || bounds.moreImportant(p.Priority, bounds.Ceiling) {
+			return p.Priority
+		}
+		waited := now - p.ArrivalTime
+		if t, ok := ranUntil[p.ProcessID]; ok {
+			waited = now - t
+		}
+		if waited < every {
+			return p.Priority
+		}
+		aged, ceiling := bounds.Boost(p.Priority, waited/every)
+		if ceiling && !atCeiling[p.ProcessID] {
+			atCeiling[p.ProcessID] = true
+			hits++
+		}
+		return aged
+	}
+	pick := func(ready []Process, now int64) int {
+		// the most important, earliest queued on a tie, unless it's no more important than the running process.
+		next := 0
+		for i, p := range ready {
+			if effective(p, now) < effective(ready[next], now) {
+				next = i
+			}
+		}
+		if i := readyIndex(ready, running); i >= 0 && effective(ready[next], now) >= effective(ready[i], now) {
+			next = i
+		}
+		running = ready[next].ProcessID
+		return next
+	}
+	result, _ := Simulate(processes, pick, SimOptions{
+		Title:      title,
+		Preemptive: true,
+		KeepPlace:  true,
+		Ran: func(p Process, _, stop int64) {
+			ranUntil[p.ProcessID] = stop
+			delete(atCeiling, p.ProcessID)
+		},
+	})
+	if every > 0 {
+		plain := PreemptivePrioritySchedule(io.Discard, title, processes)
+		result.Notes = noteLines(func(w io.Writer) {
+			outputStarvation(w, result, plain)
+			if bounds.Ceiling != UnboundedPriorities.Ceiling {
+				_, _ = fmt.Fprintf(w, "Ceiling hits: %d, processes aged up to priority %d\n", hits, bounds.Ceiling)
+			}
+		})
+	}
+	outputResult(w, result)
+
+	return result
+}
+
+// outputStarvation compares the longest wait of an aging schedule with that of plain priority.
+func outputStarvation(w io.Writer, aging, plain ScheduleResult) {
+	agingCritical, ok := CriticalProcess(aging)
+	if !ok {
+		return
+	}
+	plainCritical, _ := CriticalProcess(plain)
+	_, _ = fmt.Fprintf(w, "Longest wait: %s waited %d, %s waited %d without aging\n",
+		agingCritical.ProcessID, agingCritical.Wait, plainCritical.ProcessID, plainCritical.Wait)
+}
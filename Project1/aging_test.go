@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPriorityAgingSchedule(t *testing.T) {
+	t.Parallel()
+	// a stream of important processes keeps L off the CPU until 20 without aging.
+	processes := []Process{
+		{ProcessID: "L", ArrivalTime: 0, BurstDuration: 1, Priority: 5},
+		{ProcessID: "H1", ArrivalTime: 0, BurstDuration: 5, Priority: 1},
+		{ProcessID: "H2", ArrivalTime: 5, BurstDuration: 5, Priority: 1},
+		{ProcessID: "H3", ArrivalTime: 10, BurstDuration: 5, Priority: 1},
+		{ProcessID: "H4", ArrivalTime: 15, BurstDuration: 5, Priority: 1},
+	}
+	var w bytes.Buffer
+	result := PriorityAgingSchedule(&w, "aging", processes, 2, UnboundedPriorities)
+	// at 8, L has aged 4 levels to tie the running H2, and at 10 to 0, ahead of H3.
+	want := []TimeSlice{
+		{PID: "H1", Start: 0, Stop: 5},
+		{PID: "H2", Start: 5, Stop: 10},
+		{PID: "L", Start: 10, Stop: 11},
+		{PID: "H3", Start: 11, Stop: 16},
+		{PID: "H4", Start: 16, Stop: 21},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if !strings.Contains(w.String(), "Longest wait: L waited 10, L waited 20 without aging\n") {
+		t.Errorf("output is missing the starvation comparison:\n%s", w.String())
+	}
+}
+
+func TestPriorityAgingSchedule_noAging(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4, Priority: 2},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 2, Priority: 1},
+	}
+	var w bytes.Buffer
+	PriorityAgingSchedule(&w, "priority", processes, 0, UnboundedPriorities)
+	if strings.Contains(w.String(), "without aging") {
+		t.Errorf("output compares with itself:\n%s", w.String())
+	}
+}
+
+func TestPriorityAgingSchedule_ceiling(t *testing.T) {
+	t.Parallel()
+	// L ages no further than 2, so the stream of processes at 1 starves it as without aging.
+	processes := []Process{
+		{ProcessID: "L", ArrivalTime: 0, BurstDuration: 1, Priority: 5},
+		{ProcessID: "H1", ArrivalTime: 0, BurstDuration: 5, Priority: 1},
+		{ProcessID: "H2", ArrivalTime: 5, BurstDuration: 5, Priority: 1},
+		{ProcessID: "H3", ArrivalTime: 10, BurstDuration: 5, Priority: 1},
+		{ProcessID: "H4", ArrivalTime: 15, BurstDuration: 5, Priority: 1},
+	}
+	var w bytes.Buffer
+	bounds := PriorityBounds{Floor: 10, Ceiling: 2, Order: LowerIsHigher}
+	result := PriorityAgingSchedule(&w, "aging", processes, 2, bounds)
+	want := []TimeSlice{
+		{PID: "H1", Start: 0, Stop: 5},
+		{PID: "H2", Start: 5, Stop: 10},
+		{PID: "H3", Start: 10, Stop: 15},
+		{PID: "H4", Start: 15, Stop: 20},
+		{PID: "L", Start: 20, Stop: 21},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	for _, line := range []string{
+		"Longest wait: L waited 20, L waited 20 without aging\n",
+		"Ceiling hits: 1, processes aged up to priority 2\n",
+	} {
+		if !strings.Contains(w.String(), line) {
+			t.Errorf("output is missing %q:\n%s", line, w.String())
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// scheduleFunc is an Algorithm made of a name and a function.
+type scheduleFunc struct {
+	name     string
+	schedule func(workload Workload) (ScheduleResult, error)
+}
+
+func (f scheduleFunc) Name() string { return f.name }
+
+func (f scheduleFunc) Schedule(workload Workload) (ScheduleResult, error) {
+	return f.schedule(workload)
+}
+
+// oneCPU is the Schedule of a single-CPU scheduler: it rejects processes kept off CPU 0 before
+// running schedule over them.
+func oneCPU(schedule func(processes []Process, opts runOptions) ScheduleResult) func(Workload) (ScheduleResult, error) {
+	return func(workload Workload) (ScheduleResult, error) {
+		if err := checkOneCPU(workload.Processes); err != nil {
+			return ScheduleResult{}, err
+		}
+		return schedule(workload.Processes, workload.Options), nil
+	}
+}
+
+// plain is the Schedule of a single-CPU scheduler without tuning options, run with title.
+func plain(title string, schedule func(w io.Writer, title string, processes []Process) ScheduleResult) func(Workload) (ScheduleResult, error) {
+	return oneCPU(func(processes []Process, _ runOptions) ScheduleResult {
+		return schedule(io.Discard, title, processes)
+	})
+}
+
+// multicoreTitle is the title of a multicore run of policy: "Multicore round-robin (2 CPUs,
+// quantum 4)" for example.
+func multicoreTitle(kind string, policy Scheduler, cpus int, quantum int64) string {
+	var name string
+	switch policy {
+	case fcfs:
+		name = "first-come, first-serve"
+	case sjf:
+		name = "shortest-job-first"
+	default:
+		name = "round-robin"
+	}
+	title := fmt.Sprintf("%s %s (%d CPUs", kind, name, cpus)
+	if policy == rr {
+		title += fmt.Sprintf(", quantum %d", quantum)
+	}
+	return title + ")"
+}
+
+// The options of the multicore algorithms.
+var (
+	multicoreOptions = []string{"cpus", "policy", "quantum", "percentile"}
+	quantumOptions   = []string{"quantum", "percentile"}
+)
+
+// init registers the built-in schedulers beyond fcfs, sjf, sjfp and rr, so every one can be picked
+// on the command line and is run by -optimal, scenarios and profiles. Rate-monotonic scheduling
+// isn't among them, as it schedules periodic tasks rather than processes.
+func init() {
+	for _, a := range []struct {
+		name, usage string
+		options     []string
+		schedule    func(Workload) (ScheduleResult, error)
+	}{
+		{name: "srtf", usage: "Shortest-remaining-time-first scheduling", schedule: plain("Shortest-remaining-time-first", SRTFSchedule)},
+		{name: "sjf-np", usage: "Non-preemptive shortest-job-first scheduling", schedule: plain("Non-preemptive shortest-job-first", SJFNonPreemptiveSchedule)},
+		{
+			name: "sjf-cooldown", usage: "Shortest-job-first scheduling with a minimum run before preemption", options: []string{"min-run"},
+			schedule: oneCPU(func(processes []Process, opts runOptions) ScheduleResult {
+				return SJFCooldownSchedule(io.Discard, fmt.Sprintf("Shortest-job-first (runs at least %d)", opts.minRun), processes, opts.minRun)
+			}),
+		},
+		{
+			name: "sjf-bounded", usage: "Shortest-job-first scheduling with a bound on waiting", options: []string{"wait-bound"},
+			schedule: oneCPU(func(processes []Process, opts runOptions) ScheduleResult {
+				return SJFBoundedWaitSchedule(io.Discard, fmt.Sprintf("Shortest-job-first (waits at most %d)", opts.waitBound), processes, opts.waitBound)
+			}),
+		},
+		{
+			name: "srtf-quantum", usage: "Shortest-remaining-time-first re-evaluated every quantum", options: quantumOptions,
+			schedule: oneCPU(func(processes []Process, opts runOptions) ScheduleResult {
+				quantum := opts.resolveQuantum(processes)
+				return SRTFQuantumSchedule(io.Discard, fmt.Sprintf("Shortest-remaining-time-first (quantum %d)", quantum), processes, quantum)
+			}),
+		},
+		{name: "setf", usage: "Shortest-elapsed-time-first scheduling", schedule: plain("Shortest-elapsed-time-first", SETFSchedule)},
+		{name: "lrtf", usage: "Longest-remaining-time-first scheduling", schedule: plain("Longest-remaining-time-first", LRTFSchedule)},
+		{name: "hrrn", usage: "Highest-response-ratio-next scheduling", schedule: plain("Highest-response-ratio-next", HRRNSchedule)},
+		{name: "edf", usage: "Earliest-deadline-first scheduling", schedule: plain("Earliest-deadline-first", EDFSchedule)},
+		{name: "llf", usage: "Least-laxity-first scheduling", schedule: plain("Least-laxity-first", LLFSchedule)},
+		{name: "priority-np", usage: "Non-preemptive priority scheduling", schedule: plain("Non-preemptive priority", NonPreemptivePrioritySchedule)},
+		{name: "priority-p", usage: "Preemptive priority scheduling", schedule: plain("Preemptive priority", PreemptivePrioritySchedule)},
+		{name: "priority-threshold", usage: "Preemptive priority scheduling with preemption thresholds", schedule: plain("Preemption-threshold priority", PreemptionThresholdSchedule)},
+		{
+			name: "aging", usage: "Preemptive priority scheduling with aging", options: []string{"age", "priority-ceiling"},
+			schedule: oneCPU(func(processes []Process, opts runOptions) ScheduleResult {
+				return PriorityAgingSchedule(io.Discard, fmt.Sprintf("Priority with aging (a level every %d)", opts.age), processes, opts.age, opts.priorityBounds())
+			}),
+		},
+		{name: "wrr", usage: "Weighted round-robin scheduling", schedule: plain("Weighted round-robin", WRRSchedule)},
+		{
+			name: "rr-boost", usage: "Round-robin scheduling with starvation boosts", options: []string{"boost"},
+			schedule: oneCPU(func(processes []Process, opts runOptions) ScheduleResult {
+				return RRBoostSchedule(io.Discard, fmt.Sprintf("Round-robin (boost after %d)", opts.boost), processes, opts.boost)
+			}),
+		},
+		{
+			name: "srr", usage: "Selfish round-robin scheduling", options: []string{"srr-a", "srr-b"},
+			schedule: oneCPU(func(processes []Process, opts runOptions) ScheduleResult {
+				return SRRSchedule(io.Discard, fmt.Sprintf("Selfish round-robin (a %d, b %d)", opts.srrA, opts.srrB), processes, opts.srrA, opts.srrB)
+			}),
+		},
+		{name: "vrr", usage: "Virtual round-robin scheduling", schedule: plain("Virtual round-robin", VRRSchedule)},
+		{
+			name: "dynamic-rr", usage: "Round-robin scheduling with a quantum fitted to the bursts every round", options: []string{"quantum-formula"},
+			schedule: func(workload Workload) (ScheduleResult, error) {
+				if err := checkOneCPU(workload.Processes); err != nil {
+					return ScheduleResult{}, err
+				}
+				formula := workload.Options.formula
+				return DynamicRRSchedule(io.Discard, fmt.Sprintf("Dynamic round-robin (%s quantum)", formula), workload.Processes, formula)
+			},
+		},
+		{name: "priority-rr", usage: "Round-robin scheduling within priority levels", schedule: plain("Priority round-robin", PriorityRRSchedule)},
+		{
+			name: "mlq", usage: "Multilevel queue scheduling by class", options: []string{"levels"},
+			schedule: func(workload Workload) (ScheduleResult, error) {
+				if err := checkOneCPU(workload.Processes); err != nil {
+					return ScheduleResult{}, err
+				}
+				levels := workload.Options.levels
+				if len(levels) == 0 {
+					return ScheduleResult{}, fmt.Errorf("%w: a multilevel queue without levels", ErrInvalidArgs)
+				}
+				return MultilevelQueueSchedule(io.Discard, fmt.Sprintf("Multilevel queue (%s)", levels.String()), workload.Processes, levels), nil
+			},
+		},
+		{
+			name: "two-queue", usage: "Foreground/background multilevel queue scheduling", options: []string{"foreground"},
+			schedule: oneCPU(func(processes []Process, opts runOptions) ScheduleResult {
+				return TwoQueueSchedule(io.Discard, fmt.Sprintf("Foreground/background (%d%% foreground)", opts.foreground), processes, opts.foreground)
+			}),
+		},
+		{name: "fb", usage: "Multilevel feedback queue scheduling", schedule: plain("Multilevel feedback", FBSchedule)},
+		{
+			name: "auto-classify", usage: "Multilevel queue scheduling by the classes the bursts suggest", options: []string{"classify-threshold", "classify-verbose"},
+			schedule: oneCPU(func(processes []Process, opts runOptions) ScheduleResult {
+				return AutoClassifySchedule(io.Discard, "Auto-classified multilevel queue", processes, opts.classify, opts.classifyVerbose)
+			}),
+		},
+		{name: "o1", usage: "Linux O(1) scheduling", schedule: plain("O(1)", O1Schedule)},
+		{name: "posix", usage: "POSIX real-time and normal scheduling", schedule: plain("POSIX", POSIXSchedule)},
+		{name: "bandwidth", usage: "Round-robin scheduling throttled at each process' quota", schedule: plain("Bandwidth-controlled round-robin", BandwidthSchedule)},
+		{name: "cfs", usage: "Completely Fair Scheduler", schedule: plain("Completely Fair Scheduler", CFSSchedule)},
+		{name: "eevdf", usage: "Earliest eligible virtual deadline first scheduling", schedule: plain("Earliest eligible virtual deadline first", EEVDFSchedule)},
+		{name: "bfs", usage: "Brain Fuck Scheduler", schedule: plain("Brain Fuck Scheduler", BFSSchedule)},
+		{name: "stride", usage: "Stride scheduling", schedule: plain("Stride", StrideSchedule)},
+		{
+			name: "lottery", usage: "Lottery scheduling", options: []string{"seed"},
+			schedule: oneCPU(func(processes []Process, opts runOptions) ScheduleResult {
+				return LotterySchedule(io.Discard, fmt.Sprintf("Lottery (seed %d)", opts.seed), processes, opts.seed)
+			}),
+		},
+		{
+			name: "random", usage: "Random scheduling, a baseline", options: []string{"seed"},
+			schedule: oneCPU(func(processes []Process, opts runOptions) ScheduleResult {
+				return RandomSchedule(io.Discard, fmt.Sprintf("Random (seed %d)", opts.seed), processes, opts.seed)
+			}),
+		},
+		{name: "fair-share", usage: "Fair-share scheduling between users", schedule: plain("Fair-share", FairShareSchedule)},
+		{name: "guaranteed", usage: "Guaranteed scheduling", schedule: plain("Guaranteed", GuaranteedSchedule)},
+		{name: "coschedule", usage: "Round-robin scheduling running each group back to back", schedule: plain("Co-scheduling", CoScheduleSchedule)},
+		{
+			name: "multicore", usage: "Scheduling on several CPUs from one global queue", options: multicoreOptions,
+			schedule: func(workload Workload) (ScheduleResult, error) {
+				opts, quantum := workload.Options, workload.Options.resolveQuantum(workload.Processes)
+				title := multicoreTitle("Multicore", Scheduler(opts.policy), opts.cpus, quantum)
+				return MulticoreSchedule(io.Discard, title, workload.Processes, opts.cpus, Scheduler(opts.policy), quantum)
+			},
+		},
+		{
+			name: "partitioned", usage: "Scheduling on several CPUs with a runqueue each and work stealing", options: append([]string{"steal"}, multicoreOptions...),
+			schedule: func(workload Workload) (ScheduleResult, error) {
+				opts, quantum := workload.Options, workload.Options.resolveQuantum(workload.Processes)
+				title := multicoreTitle("Partitioned", Scheduler(opts.policy), opts.cpus, quantum)
+				return PartitionedSchedule(io.Discard, title, workload.Processes, opts.cpus, Scheduler(opts.policy), quantum, opts.steal)
+			},
+		},
+		{
+			name: "packed", usage: "Scheduling on several CPUs with the processes bin-packed onto them", options: append([]string{"pack"}, multicoreOptions...),
+			schedule: func(workload Workload) (ScheduleResult, error) {
+				opts, quantum := workload.Options, workload.Options.resolveQuantum(workload.Processes)
+				title := multicoreTitle("Packed "+opts.pack, Scheduler(opts.policy), opts.cpus, quantum)
+				return PackedSchedule(io.Discard, title, workload.Processes, opts.cpus, Scheduler(opts.policy), quantum, opts.pack)
+			},
+		},
+		{
+			name: "gang", usage: "Gang scheduling each group across several CPUs", options: []string{"cpus", "quantum", "percentile"},
+			schedule: func(workload Workload) (ScheduleResult, error) {
+				opts, quantum := workload.Options, workload.Options.resolveQuantum(workload.Processes)
+				title := fmt.Sprintf("Gang (%d CPUs, quantum %d)", opts.cpus, quantum)
+				return GangSchedule(io.Discard, title, workload.Processes, opts.cpus, quantum)
+			},
+		},
+		{
+			name: "heterogeneous", usage: "Scheduling on CPUs of different speeds", options: []string{"speeds", "policy", "quantum", "percentile"},
+			schedule: func(workload Workload) (ScheduleResult, error) {
+				opts, quantum := workload.Options, workload.Options.resolveQuantum(workload.Processes)
+				title := multicoreTitle("Heterogeneous", Scheduler(opts.policy), len(opts.speeds), quantum)
+				return HeterogeneousSchedule(io.Discard, title, workload.Processes, opts.speeds, Scheduler(opts.policy), quantum)
+			},
+		},
+	} {
+		RegisterAlgorithm(scheduleFunc{name: a.name, schedule: a.schedule}, a.usage, a.options...)
+	}
+}
+
+// levelsFlag is the -levels of a multilevel queue, written "interactive:4,batch:0".
+type levelsFlag []QueueLevel
+
+func (l *levelsFlag) String() string {
+	fields := make([]string, len(*l))
+	for i, level := range *l {
+		fields[i] = fmt.Sprintf("%s:%d", level.Class, level.Quantum)
+	}
+	return strings.Join(fields, ",")
+}
+
+func (l *levelsFlag) Set(s string) error {
+	var levels levelsFlag
+	for _, field := range strings.Split(s, ",") {
+		class, quantum, ok := strings.Cut(strings.TrimSpace(field), ":")
+		ticks, err := strconv.ParseInt(quantum, 10, 64)
+		if !ok || err != nil || ticks < 0 {
+			return fmt.Errorf("%w: level %q is not <class>:<quantum>", ErrInvalidArgs, field)
+		}
+		levels = append(levels, QueueLevel{Class: class, Quantum: ticks})
+	}
+	*l = levels
+
+	return nil
+}
+
+// speedsFlag is the -speeds of the CPUs of a heterogeneous scheduler, written "2,1".
+type speedsFlag []int64
+
+func (s *speedsFlag) String() string {
+	fields := make([]string, len(*s))
+	for i, speed := range *s {
+		fields[i] = strconv.FormatInt(speed, 10)
+	}
+	return strings.Join(fields, ",")
+}
+
+func (s *speedsFlag) Set(value string) error {
+	var speeds speedsFlag
+	for _, field := range strings.Split(value, ",") {
+		speed, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+		if err != nil || speed < 1 {
+			return fmt.Errorf("%w: CPU speed %q is not a positive number", ErrInvalidArgs, field)
+		}
+		speeds = append(speeds, speed)
+	}
+	*s = speeds
+
+	return nil
+}
+
+// policyFlag is the -policy each CPU of a multicore scheduler picks by.
+type policyFlag Scheduler
+
+func (p *policyFlag) String() string { return Scheduler(*p).Name() }
+
+func (p *policyFlag) Set(s string) error {
+	switch s {
+	case fcfs.String(), sjf.String(), rr.String():
+		scheduler, _ := parseScheduler(s)
+		*p = policyFlag(scheduler)
+		return nil
+	}
+	return fmt.Errorf("%w: policy %q is not fcfs, sjf or rr", ErrInvalidArgs, s)
+}
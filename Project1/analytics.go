@@ -0,0 +1,178 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+)
+
+// MM1Estimate is the M/M/1 queueing model fitted to a workload.
+type MM1Estimate struct {
+	// Lambda is the arrival rate, from the mean time between arrivals.
+	Lambda float64
+	// Mu is the service rate, from the mean burst.
+	Mu float64
+	// Rho is the utilization Lambda / Mu.
+	Rho float64
+	// ExpectedWait is the mean time in the queue, Rho / (Mu - Lambda). It is only meaningful when Rho < 1.
+	ExpectedWait float64
+	// Caveats explain why the prediction may not apply to this workload.
+	Caveats []string
+}
+
+// exponentialCV is how far the coefficient of variation may stray from 1, the exponential
+// distribution's, before a workload no longer looks exponential.
+const exponentialCV = 0.3
+
+// EstimateMM1 fits an M/M/1 queue to the arrivals and bursts of processes, so the FCFS average
+// wait can be checked against theory. It needs at least two processes with distinct arrivals.
+func EstimateMM1(processes []Process) (MM1Estimate, error) {
+	if len(processes) < 2 {
+		return MM1Estimate{}, fmt.Errorf("%w: M/M/1 needs at least 2 processes", ErrInvalidArgs)
+	}
+	arrivals := make([]float64, len(processes))
+	bursts := make([]float64, len(processes))
+	for i, p := range processes {
+		arrivals[i] = float64(p.ArrivalTime)
+		bursts[i] = float64(p.BurstDuration)
+	}
+	slices.Sort(arrivals)
+	gaps := make([]float64, len(arrivals)-1)
+	for i := range gaps {
+		gaps[i] = arrivals[i+1] - arrivals[i]
+	}
+
+	meanGap, gapCV := meanCV(gaps)
+	meanBurst, burstCV := meanCV(bursts)
+	if meanGap == 0 || meanBurst == 0 {
+		return MM1Estimate{}, fmt.Errorf("%w: M/M/1 needs distinct arrivals and nonzero bursts", ErrInvalidArgs)
+	}
+
+	e := MM1Estimate{Lambda: 1 / meanGap, Mu: 1 / meanBurst}
+	e.Rho = e.Lambda / e.Mu
+	if e.Rho < 1 {
+		e.ExpectedWait = e.Rho / (e.Mu - e.Lambda)
+	} else {
+		e.ExpectedWait = math.Inf(1)
+		e.Caveats = append(e.Caveats, fmt.Sprintf("ρ = %.2f ≥ 1: the queue grows without bound, so there is no steady-state wait", e.Rho))
+	}
+	if math.Abs(gapCV-1) > exponentialCV {
+		e.Caveats = append(e.Caveats, fmt.Sprintf("inter-arrival times don't look exponential (CV %.2f, want about 1)", gapCV))
+	}
+	if math.Abs(burstCV-1) > exponentialCV {
+		e.Caveats = append(e.Caveats, fmt.Sprintf("bursts don't look exponential (CV %.2f, want about 1)", burstCV))
+	}
+
+	return e, nil
+}
+
+// meanCV is the mean and coefficient of variation (standard deviation / mean) of values.
+func meanCV(values []float64) (mean, cv float64) {
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	if mean == 0 {
+		return 0, 0
+	}
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+
+	return mean, math.Sqrt(variance) / mean
+}
+
+// outputMM1 writes the M/M/1 prediction next to the simulated average wait.
+func outputMM1(w io.Writer, e MM1Estimate, simulatedWait float64) {
+	_, _ = fmt.Fprintf(w, "M/M/1 predicted wait: %.2f (λ=%.4f μ=%.4f ρ=%.2f), simulated: %.2f\n",
+		e.ExpectedWait, e.Lambda, e.Mu, e.Rho, simulatedWait)
+	for _, caveat := range e.Caveats {
+		_, _ = fmt.Fprintf(w, "caveat: %s\n", caveat)
+	}
+}
+
+// LittlesLaw is both sides of Little's law, L = λW, measured on a schedule over [0, makespan].
+// The law is an identity for a finished schedule, so the sides only disagree when the reported
+// turnarounds don't match the arrivals and completions they were derived from.
+type LittlesLaw struct {
+	// L is the time-average number of processes that have arrived but not yet completed.
+	L float64
+	// Lambda is the arrival rate of the processes that completed.
+	Lambda float64
+	// W is the average time in the system, the reported average turnaround.
+	W float64
+}
+
+// littlesLawTolerance is the relative discrepancy allowed for floating point noise.
+const littlesLawTolerance = 1e-9
+
+// MeasureLittlesLaw integrates the number of completed processes in the system over the makespan
+// of result and pairs it with the arrival rate and reported average turnaround. Aborted processes
+// never complete, so they are left out of both sides.
+func MeasureLittlesLaw(result ScheduleResult) LittlesLaw {
+	type event struct {
+		t     int64
+		delta int
+	}
+	var events []event
+	for _, p := range result.Processes {
+		if p.Aborted || p.Remaining > 0 {
+			continue
+		}
+		events = append(events, event{p.ArrivalTime, 1}, event{p.Completion, -1})
+	}
+	if len(events) == 0 || result.Makespan == 0 {
+		return LittlesLaw{}
+	}
+	slices.SortFunc(events, func(a, b event) int { return cmp.Compare(a.t, b.t) })
+
+	var (
+		area    int64
+		inside  int
+		horizon = float64(result.Makespan)
+		last    = events[0].t
+	)
+	for _, e := range events {
+		area += int64(inside) * (e.t - last)
+		inside, last = inside+e.delta, e.t
+	}
+
+	return LittlesLaw{
+		L:      float64(area) / horizon,
+		Lambda: float64(len(events)/2) / horizon,
+		W:      result.AverageTurnaround,
+	}
+}
+
+// Discrepancy is the relative difference between L and λW.
+func (l LittlesLaw) Discrepancy() float64 {
+	lw := l.Lambda * l.W
+	if l.L == lw {
+		return 0
+	}
+	return math.Abs(l.L-lw) / math.Max(math.Abs(l.L), math.Abs(lw))
+}
+
+// VerifyLittlesLaw checks that the schedule in result obeys Little's law, catching a scheduler
+// whose turnaround bookkeeping disagrees with its own completions.
+func VerifyLittlesLaw(result ScheduleResult) error {
+	l := MeasureLittlesLaw(result)
+	if d := l.Discrepancy(); d > littlesLawTolerance {
+		return fmt.Errorf("%w: L = %g but λW = %g × %g = %g (discrepancy %.2g)",
+			ErrLittlesLaw, l.L, l.Lambda, l.W, l.Lambda*l.W, d)
+	}
+	return nil
+}
+
+// outputLittlesLaw writes both sides of Little's law for result.
+func outputLittlesLaw(w io.Writer, l LittlesLaw) {
+	if l.Lambda == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Little's law: L = %.2f, λW = %.2f × %.2f = %.2f (discrepancy %.2f%%)\n",
+		l.L, l.Lambda, l.W, l.Lambda*l.W, 100*l.Discrepancy())
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEstimateMM1(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		processes   []Process
+		wantRho     float64
+		wantWait    float64
+		wantCaveats []string
+		wantErr     error
+	}{
+		{
+			name: "stable but deterministic",
+			processes: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 1},
+				{ProcessID: "P1", ArrivalTime: 2, BurstDuration: 1},
+				{ProcessID: "P2", ArrivalTime: 4, BurstDuration: 1},
+				{ProcessID: "P3", ArrivalTime: 6, BurstDuration: 1},
+			},
+			wantRho:     0.5,
+			wantWait:    1,
+			wantCaveats: []string{"inter-arrival", "bursts"},
+		},
+		{
+			name: "overloaded",
+			processes: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 4},
+				{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 4},
+			},
+			wantRho:     4,
+			wantWait:    math.Inf(1),
+			wantCaveats: []string{"ρ = 4.00 ≥ 1", "inter-arrival", "bursts"},
+		},
+		{
+			name:      "too few",
+			processes: []Process{{ProcessID: "P0", BurstDuration: 4}},
+			wantErr:   ErrInvalidArgs,
+		},
+		{
+			name: "simultaneous arrivals",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 4},
+				{ProcessID: "P1", BurstDuration: 4},
+			},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := EstimateMM1(tt.processes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("EstimateMM1() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Rho != tt.wantRho || got.ExpectedWait != tt.wantWait {
+				t.Errorf("ρ = %g, wait = %g, want %g and %g", got.Rho, got.ExpectedWait, tt.wantRho, tt.wantWait)
+			}
+			if len(got.Caveats) != len(tt.wantCaveats) {
+				t.Fatalf("caveats = %q, want %d", got.Caveats, len(tt.wantCaveats))
+			}
+			for i, want := range tt.wantCaveats {
+				if !strings.HasPrefix(got.Caveats[i], want) {
+					t.Errorf("caveat %d = %q, want it to start with %q", i, got.Caveats[i], want)
+				}
+			}
+		})
+	}
+}
+
+// exponentialWorkload draws n processes with exponential inter-arrival and service times.
+func exponentialWorkload(n int, meanGap, meanBurst float64, seed uint64) []Process {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	processes := make([]Process, n)
+	var arrival float64
+	for i := range processes {
+		arrival += rng.ExpFloat64() * meanGap
+		processes[i] = Process{
+			ProcessID:     fmt.Sprintf("P%d", i),
+			ArrivalTime:   int64(math.Round(arrival)),
+			BurstDuration: max(int64(math.Round(rng.ExpFloat64()*meanBurst)), 1),
+		}
+	}
+	return processes
+}
+
+func TestEstimateMM1_simulatedFCFS(t *testing.T) {
+	t.Parallel()
+	processes := exponentialWorkload(20000, 40, 20, 1)
+	estimate, err := EstimateMM1(processes)
+	if err != nil {
+		t.Fatalf("EstimateMM1() error = %v", err)
+	}
+	if len(estimate.Caveats) > 0 {
+		t.Errorf("caveats for an exponential workload: %q", estimate.Caveats)
+	}
+
+	simulated := FCFSSchedule(io.Discard, "fcfs", processes).AverageWait
+	t.Logf("ρ = %.3f, predicted wait %.2f, simulated %.2f", estimate.Rho, estimate.ExpectedWait, simulated)
+	if diff := math.Abs(simulated-estimate.ExpectedWait) / estimate.ExpectedWait; diff > 0.1 {
+		t.Errorf("simulated FCFS wait %.2f is %.0f%% off the predicted %.2f", simulated, 100*diff, estimate.ExpectedWait)
+	}
+}
+
+func TestVerifyLittlesLaw(t *testing.T) {
+	t.Parallel()
+	workloads := map[string][]Process{
+		"exponential": exponentialWorkload(200, 4, 3, 7),
+		"kills and suspensions": {
+			{ProcessID: "A", ArrivalTime: 0, BurstDuration: 7, Priority: 3},
+			{ProcessID: "B", ArrivalTime: 1, BurstDuration: 3, Priority: 1, Suspensions: []Window{{Start: 2, Stop: 5}}},
+			{ProcessID: "C", ArrivalTime: 2, BurstDuration: 5, Priority: 3, KillAt: 9},
+			{ProcessID: "D", ArrivalTime: 20, BurstDuration: 2, Priority: 2},
+		},
+	}
+	fixtures, err := filepath.Glob("*.csv")
+	if err != nil || len(fixtures) == 0 {
+		t.Fatalf("no CSV fixtures found: %v", err)
+	}
+	for _, name := range fixtures {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		processes, err := loadProcesses(f)
+		_ = f.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		workloads[name] = processes
+	}
+
+	for name, processes := range workloads {
+		processes := processes
+		for _, result := range []ScheduleResult{
+			FCFSSchedule(io.Discard, "fcfs", processes),
+			SJFSchedule(io.Discard, "sjf", processes),
+			SJFScheduleWith(io.Discard, "sjf affinity", processes, WithCacheAffinity(0.2, 1)),
+			SJFPrioritySchedule(io.Discard, "sjfp", processes),
+			RRSchedule(io.Discard, "rr", processes),
+			RRQuantumScheduleWith(io.Discard, "rr affinity", processes, 3, WithCacheAffinity(0.2, 1)),
+		} {
+			result := result
+			t.Run(name+"/"+result.Title, func(t *testing.T) {
+				t.Parallel()
+				if err := VerifyLittlesLaw(result); err != nil {
+					t.Error(err)
+				}
+
+				// a turnaround that disagrees with the completions is caught.
+				result.AverageTurnaround += 0.5
+				if err := VerifyLittlesLaw(result); !errors.Is(err, ErrLittlesLaw) {
+					t.Errorf("VerifyLittlesLaw() with a skewed turnaround error = %v, want %v", err, ErrLittlesLaw)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// parseBandwidth reads a CPU bandwidth quota written as "<quota>/<period>", like "2/5" for at most
+// 2 ticks in every 5.
+func parseBandwidth(s string) (quota, period int64, err error) {
+	q, p, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return 0, 0, fmt.Errorf("%w: bandwidth %q is not <quota>/<period>", ErrInvalidArgs, s)
+	}
+	if quota, err = strconv.ParseInt(q, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("%w: bandwidth %q: %v", ErrInvalidArgs, s, err)
+	}
+	if period, err = strconv.ParseInt(p, 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("%w: bandwidth %q: %v", ErrInvalidArgs, s, err)
+	}
+	if quota < 1 || period < quota {
+		return 0, 0, fmt.Errorf("%w: bandwidth %q must be a quota of 1 to the period", ErrInvalidArgs, s)
+	}
+
+	return quota, period, nil
+}
+
+// quotaRun is how long p can run from now, at most limit, before it's throttled, having used used
+// of the Quota of its window window. Using up the quota just as a window ends doesn't throttle it,
+// as the next window's quota starts at once.
+func (p Process) quotaRun(now, window, used, limit int64) int64 {
+	if p.Quota == 0 {
+		return limit
+	}
+	if now/p.Period != window {
+		used = 0
+	}
+	end := (now/p.Period + 1) * p.Period
+	switch {
+	case now+p.Quota-used < end:
+		return min(limit, p.Quota-used)
+	case p.Quota == p.Period:
+		return limit
+	}
+	return min(limit, end-now+p.Quota)
+}
+
+// chargeQuota is the window p is in and how much of its Quota it has used there once it has run
+// from start to stop, having used used of the quota of window before.
+func (p Process) chargeQuota(window, used, start, stop int64) (int64, int64) {
+	if last := (stop - 1) / p.Period; last != window {
+		return last, stop - max(start, last*p.Period)
+	}
+	return window, used + stop - start
+}
+
+// throttledBy reports whether p, having used used of the Quota of its window at t, is held back
+// until the window ends.
+func (p Process) throttledBy(t, used int64) bool {
+	return p.Quota > 0 && used >= p.Quota && t%p.Period != 0
+}
+
+// BandwidthSchedule is RRSchedule written with the throttling every Simulate scheduler applies to
+// a process with a Quota: once it has run Quota ticks of the current Period window it is
+// throttled, leaving the CPU until the next window starts, when it rejoins the back of the ready
+// queue, in the order the processes were throttled. The windows start at 0 and every Period after,
+// as cgroup periods run on a timer rather than from when a process arrives. Throttled time counts
+// as waiting. The result's processes record their throttled windows, and it writes them as
+// segments of their own under the gantt, the per-process timeline, where they show as 't', and how
+// long each process was throttled.
+func BandwidthSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	result, _ := Simulate(processes, pickFirst, SimOptions{Title: title, Quantum: defaultQuantum})
+	result.Notes = noteLines(func(w io.Writer) {
+		outputThrottled(w, result)
+		for _, p := range result.Processes {
+			if len(p.Throttled) > 0 {
+				var total int64
+				for _, t := range p.Throttled {
+					total += t.Stop - t.Start
+				}
+				_, _ = fmt.Fprintf(w, "%s throttled %d times, %d ticks in total\n", p.ProcessID, len(p.Throttled), total)
+			}
+		}
+	})
+	outputResult(w, result)
+	OutputTimeline(w, result)
+
+	return result
+}
+
+// outputThrottled writes the CPU's gantt with a row of throttled segments under it for every
+// process that was throttled, on a shared time axis.
+func outputThrottled(w io.Writer, result ScheduleResult) {
+	labeled := map[string][]TimeSlice{"CPU": result.Gantt}
+	for _, p := range result.Processes {
+		for _, t := range p.Throttled {
+			label := "throttled " + p.ProcessID
+			labeled[label] = append(labeled[label], TimeSlice{PID: p.ProcessID, Start: t.Start, Stop: t.Stop})
+		}
+	}
+	if len(labeled) == 1 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Gantt schedule with throttling")
+	_, _ = fmt.Fprintln(w, StackGantts(labeled))
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseBandwidth(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		s                     string
+		wantQuota, wantPeriod int64
+		wantErr               error
+	}{
+		{s: "2/5", wantQuota: 2, wantPeriod: 5},
+		{s: " 5/5 ", wantQuota: 5, wantPeriod: 5},
+		{s: "5", wantErr: ErrInvalidArgs},
+		{s: "x/5", wantErr: ErrInvalidArgs},
+		{s: "6/5", wantErr: ErrInvalidArgs},
+		{s: "0/5", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		quota, period, err := parseBandwidth(tt.s)
+		if !errors.Is(err, tt.wantErr) || quota != tt.wantQuota || period != tt.wantPeriod {
+			t.Errorf("parseBandwidth(%q) = %d, %d, %v, want %d, %d, %v", tt.s, quota, period, err, tt.wantQuota, tt.wantPeriod, tt.wantErr)
+		}
+	}
+}
+
+func TestBandwidthSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 5, Quota: 2, Period: 5},
+		{ProcessID: "B", BurstDuration: 3},
+	}
+	var w bytes.Buffer
+	result := BandwidthSchedule(&w, "bandwidth", processes)
+	// A runs 2 ticks of every 5; the CPU idles from 7 while A is throttled and B is done.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 2},
+		{PID: "B", Start: 2, Stop: 5},
+		{PID: "A", Start: 5, Stop: 7},
+		{PID: "A", Start: 10, Stop: 11},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if diff := cmp.Diff([]Window{{Start: 2, Stop: 5}, {Start: 7, Stop: 10}}, result.Processes[0].Throttled); diff != "" {
+		t.Errorf(diff)
+	}
+	if err := VerifyStateTimeline(result, StateTimeline(result)); err != nil {
+		t.Error(err)
+	}
+	for _, want := range []string{"throttled A\n|  A==  A== |", "A |##ttt##ttt#|", "A throttled 2 times, 6 ticks in total"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output is missing %q:\n%s", want, w.String())
+		}
+	}
+}
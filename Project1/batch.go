@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// parseScheduler looks up a scheduler by its flag name, e.g. "sjf", registered algorithms
+// included.
+func parseScheduler(name string) (Scheduler, error) {
+	for _, spec := range schedulerSpecs {
+		if spec.scheduler.Name() == name {
+			return spec.scheduler, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, name)
+}
+
+// RunBatch runs every line of a batch script, each naming a scheduler and a process file
+// (e.g. "sjf procs1.csv"), and writes a titled section per line to w.
+// Blank lines and lines starting with '#' are skipped. The first failing line stops the batch.
+func RunBatch(r io.Reader, w io.Writer) error {
+	return runBatch(r, w, &runSummary{})
+}
+
+// runBatch is RunBatch, counting the schedulers run and processes simulated into summary.
+func runBatch(r io.Reader, w io.Writer, summary *runSummary) error {
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return fmt.Errorf("%w: batch line %d: expected \"<scheduler> <file>\", got %q", ErrInvalidArgs, lineNo, line)
+		}
+		processes, err := runBatchLine(w, fields[0], fields[1])
+		if err != nil {
+			return fmt.Errorf("batch line %d: %w", lineNo, err)
+		}
+		summary.schedulers++
+		summary.processes += processes
+	}
+
+	return scanner.Err()
+}
+
+func runBatchFile(w io.Writer, name string, summary *runSummary) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w: error opening batch file", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return runBatch(f, w, summary)
+}
+
+// runBatchLine runs one batch line, returning how many processes it simulated.
+func runBatchLine(w io.Writer, name, file string) (int, error) {
+	plan, err := planBatchLine(name, file)
+	if err != nil {
+		return 0, err
+	}
+	result, err := runScheduler(io.Discard, plan.scheduler, plan.processes, plan.options)
+	if err != nil {
+		return 0, err
+	}
+	// batch lines always run with the default options.
+	defaults := flag.NewFlagSet("batch", flag.ContinueOnError)
+	registerFlags(defaults, &cliConfig{})
+	manifest := NewManifest(file, plan.digest, plan.scheduler, defaults)
+	result.Manifest = &manifest
+
+	_, _ = fmt.Fprintf(w, "== %s %s ==\n", name, file)
+	outputResult(w, result)
+	_, _ = fmt.Fprintln(w)
+
+	return len(plan.processes), nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunBatch(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	procs := filepath.Join(dir, "procs1.csv")
+	if err := os.WriteFile(procs, []byte("ProcessID,Burst Duration,Arrival Time,Priority\nP0,5,0,2\nP1,9,3,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		batch     string
+		wantTitle []string
+		wantErr   error
+	}{
+		{
+			name:  "two lines",
+			batch: "# grading run\nsjf " + procs + "\n\nrr " + procs + "\n",
+			wantTitle: []string{
+				"== sjf " + procs + " ==",
+				"Shortest-job-first",
+				"== rr " + procs + " ==",
+				"Round-robin",
+			},
+		},
+		{
+			name:    "unknown algorithm",
+			batch:   "nonesuch " + procs + "\n",
+			wantErr: ErrUnknownAlgorithm,
+		},
+		{
+			name:    "malformed line",
+			batch:   "sjf\n",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "missing file",
+			batch:   "fcfs " + filepath.Join(dir, "missing.csv") + "\n",
+			wantErr: os.ErrNotExist,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := RunBatch(strings.NewReader(tt.batch), &w)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RunBatch() error = %v, want %v", err, tt.wantErr)
+			}
+			out := w.String()
+			last := -1
+			for _, title := range tt.wantTitle {
+				i := strings.Index(out, title)
+				if i <= last {
+					t.Fatalf("%q missing or out of order in:\n%s", title, out)
+				}
+				last = i
+			}
+		})
+	}
+}
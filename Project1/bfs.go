@@ -0,0 +1,99 @@
+package main
+
+import (
+	"io"
+	"slices"
+)
+
+const (
+	// bfsRRInterval is BFS's rr_interval, the timeslice every process gets, its default of 6ms
+	// with a tick standing for a millisecond.
+	bfsRRInterval int64 = 6
+	// bfsRatioScale is the prio_ratio of nice -20, which virtual deadlines are kept in fractions of.
+	bfsRatioScale int64 = 128
+)
+
+// bfsPrioRatios is BFS's prio_ratios: the prio_ratio of nice -20 through 19, each 10% more than
+// the one before.
+var bfsPrioRatios = [40]int64{
+	128, 140, 154, 169, 185, 203, 223, 245, 269, 295,
+	324, 356, 391, 430, 473, 520, 572, 629, 691, 760,
+	836, 919, 1010, 1111, 1222, 1344, 1478, 1625, 1787, 1965,
+	2161, 2377, 2614, 2875, 3162, 3478, 3825, 4207, 4627, 5089,
+}
+
+// bfsDeadline is the virtual deadline BFS gives p at now: now plus bfsRRInterval scaled by the
+// prio_ratio of p's Priority, taken as a nice value clamped to -20 through 19.
+func bfsDeadline(p Process, now int64) int64 {
+	return now*bfsRatioScale + bfsRRInterval*bfsPrioRatios[min(max(p.Priority, -20), 19)+20]
+}
+
+// BFSSchedule emulates Con Kolivas's Brain Fuck Scheduler, the single-runqueue design MuQSS grew
+// from. Every runnable process sits in one global queue with a virtual deadline, now plus
+// bfsRRInterval scaled by its nice level's prio_ratio (see bfsDeadline), and the earliest deadline
+// runs, the earliest queued on a tie. A process that uses up its bfsRRInterval timeslice gets a
+// fresh one and a fresh deadline, so a nicer process's later deadlines let a less nice one run
+// several slices for each of its own, but never starve it. An arrival with an earlier deadline
+// preempts the running process, which keeps its deadline and the rest of its timeslice. With equal
+// priorities it's RRQuantumSchedule with a quantum of bfsRRInterval.
+func BFSSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	var (
+		// queue is the runnable processes but current, in queue order, with the timeslice and
+		// virtual deadline of every process in the system, the deadline in 1/bfsRatioScale ticks.
+		queue    []string
+		slice    = make(map[string]int64, len(processes))
+		deadline = make(map[string]int64, len(processes))
+		current  string
+		// requeue is set when current used up its timeslice.
+		requeue bool
+	)
+	pick := func(ready []Process, _ int64) int {
+		if requeue {
+			// processes that arrived during the timeslice queue ahead of the preempted one.
+			queue, current, requeue = append(queue, current), "", false
+		}
+		earliest := -1
+		for i, id := range queue {
+			if readyIndex(ready, id) >= 0 && (earliest < 0 || deadline[id] < deadline[queue[earliest]]) {
+				earliest = i
+			}
+		}
+		if current != "" {
+			i := readyIndex(ready, current)
+			if i >= 0 && (earliest < 0 || deadline[queue[earliest]] >= deadline[current]) {
+				return i
+			}
+			// preempted by an earlier deadline, or suspended, it keeps its deadline and timeslice.
+			queue, current = append(queue, current), ""
+		}
+		current = queue[earliest]
+		queue = slices.Delete(queue, earliest, earliest+1)
+		return readyIndex(ready, current)
+	}
+
+	result, _ := Simulate(processes, pick, SimOptions{
+		Title:      title,
+		Preemptive: true,
+		KeepPlace:  true,
+		Arrived: func(p Process, now int64) {
+			queue = append(queue, p.ProcessID)
+			slice[p.ProcessID], deadline[p.ProcessID] = bfsRRInterval, bfsDeadline(p, now)
+		},
+		Ran: func(p Process, start, stop int64) {
+			slice[p.ProcessID] -= stop - start
+			if p.BurstDuration > 0 && slice[p.ProcessID] == 0 {
+				slice[p.ProcessID], deadline[p.ProcessID] = bfsRRInterval, bfsDeadline(p, stop)
+				requeue = true
+			}
+		},
+		Left: func(p Process, _ int64) {
+			queue = slices.DeleteFunc(queue, func(id string) bool { return id == p.ProcessID })
+			if p.ProcessID == current {
+				current, requeue = "", false
+			}
+		},
+	})
+	outputResult(w, result)
+
+	return result
+}
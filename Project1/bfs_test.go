@@ -0,0 +1,31 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBFSSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 30, Priority: 0},
+		{ProcessID: "B", ArrivalTime: 0, BurstDuration: 12, Priority: 5},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 2, Priority: -20},
+	}
+	result := BFSSchedule(io.Discard, "bfs", processes)
+	// C's deadline, 2 + 6 ticks, is ahead of A's 6 x 836/128 and preempts it. A's fresh deadlines
+	// then stay ahead of B's 6 x 1344/128 until 26, and B gets one slice before A completes.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 2},
+		{PID: "C", Start: 2, Stop: 4},
+		{PID: "A", Start: 4, Stop: 26},
+		{PID: "B", Start: 26, Stop: 32},
+		{PID: "A", Start: 32, Stop: 38},
+		{PID: "B", Start: 38, Stop: 44},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
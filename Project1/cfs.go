@@ -0,0 +1,103 @@
+package main
+
+import (
+	"cmp"
+	"io"
+)
+
+const (
+	// cfsTargetLatency is the period CFS tries to run every runnable process within once,
+	// splitting it among them by weight.
+	cfsTargetLatency int64 = 8
+	// cfsMinGranularity is the shortest slice CFS hands out, however many processes are runnable.
+	cfsMinGranularity int64 = 1
+	// cfsNice0Weight is the weight of nice 0; vruntime advances at wall-clock speed at this weight.
+	cfsNice0Weight int64 = 1024
+)
+
+// cfsWeights is Linux's sched_prio_to_weight: the weight of nice -20 through 19. Each nice level
+// is about 10% more or less CPU than the next.
+var cfsWeights = [40]int64{
+	88761, 71755, 56483, 46273, 36291,
+	29154, 23254, 18705, 14949, 11916,
+	9548, 7620, 6100, 4904, 3906,
+	3121, 2501, 1991, 1586, 1277,
+	1024, 820, 655, 526, 423,
+	335, 272, 215, 172, 137,
+	110, 87, 70, 56, 45,
+	36, 29, 23, 18, 15,
+}
+
+// NiceWeight converts p's Priority to the weight the proportional-share schedulers give it: the
+// Priority is taken as a nice value, clamped to -20 through 19, and looked up in cfsWeights.
+func NiceWeight(p Process) int64 {
+	return cfsWeights[min(max(p.Priority, -20), 19)+20]
+}
+
+// CFSSchedule is a simplified Linux Completely Fair Scheduler. Every process has a virtual runtime
+// that advances as it runs, more slowly the higher its weight, and the runnable process with the
+// lowest vruntime runs next. Priority is the nice value: each step down gives about 25% more
+// weight. The running process is preempted once it has run its weight's share of
+// cfsTargetLatency among the runnable processes, but never less than cfsMinGranularity. A new
+// arrival starts at the smallest vruntime of the runnable processes, so it neither starves them
+// nor is starved itself. Vruntime is kept in 1/cfsNice0Weight ticks.
+func CFSSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	var (
+		minVruntime int64
+		totalWeight int64
+		// vruntime is the vruntime of every process in the system, and seq the order they arrived
+		// in, which breaks ties.
+		vruntime = make(map[string]int64, len(processes))
+		seq      = make(map[string]int, len(processes))
+		current  string
+		// startVruntime is current's vruntime when it was dispatched, ranFor how long it has run since.
+		startVruntime, ranFor int64
+	)
+	pick := func(ready []Process, _ int64) int {
+		if i := readyIndex(ready, current); i >= 0 &&
+			ranFor < max(cfsTargetLatency*NiceWeight(ready[i])/totalWeight, cfsMinGranularity) {
+			return i
+		}
+		next := 0
+		for i, p := range ready {
+			q := ready[next]
+			if cmp.Or(cmp.Compare(vruntime[p.ProcessID], vruntime[q.ProcessID]), cmp.Compare(seq[p.ProcessID], seq[q.ProcessID])) < 0 {
+				next = i
+			}
+		}
+		current, startVruntime, ranFor = ready[next].ProcessID, vruntime[ready[next].ProcessID], 0
+		return next
+	}
+	ran := func(p Process, start, stop int64) {
+		ranFor += stop - start
+		vruntime[p.ProcessID] = startVruntime + ranFor*cfsNice0Weight*cfsNice0Weight/NiceWeight(p)
+		lowest := int64(-1)
+		for id, v := range vruntime {
+			if (id != p.ProcessID || p.BurstDuration > 0) && (lowest < 0 || v < lowest) {
+				lowest = v
+			}
+		}
+		minVruntime = max(minVruntime, lowest)
+	}
+
+	result, _ := Simulate(processes, pick, SimOptions{
+		Title:      title,
+		Preemptive: true,
+		KeepPlace:  true,
+		Arrived: func(p Process, _ int64) {
+			vruntime[p.ProcessID], seq[p.ProcessID] = minVruntime, len(seq)
+			totalWeight += NiceWeight(p)
+		},
+		Ran: ran,
+		Left: func(p Process, _ int64) {
+			delete(vruntime, p.ProcessID)
+			totalWeight -= NiceWeight(p)
+			if p.ProcessID == current {
+				current = ""
+			}
+		},
+	})
+	outputResult(w, result)
+
+	return result
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCFSSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []TimeSlice
+	}{
+		{
+			// equal weights split the target latency evenly.
+			name: "equal nice",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 8},
+				{ProcessID: "B", ArrivalTime: 0, BurstDuration: 8},
+			},
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 4},
+				{PID: "B", Start: 4, Stop: 8},
+				{PID: "A", Start: 8, Stop: 12},
+				{PID: "B", Start: 12, Stop: 16},
+			},
+		},
+		{
+			// nice 5 weighs 335 to nice 0's 1024, so B gets about a quarter of the CPU.
+			name: "nice 5 against nice 0",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 20},
+				{ProcessID: "B", ArrivalTime: 0, BurstDuration: 20, Priority: 5},
+			},
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 6},
+				{PID: "B", Start: 6, Stop: 8},
+				{PID: "A", Start: 8, Stop: 14},
+				{PID: "B", Start: 14, Stop: 16},
+				{PID: "A", Start: 16, Stop: 22},
+				{PID: "B", Start: 22, Stop: 24},
+				{PID: "A", Start: 24, Stop: 26},
+				{PID: "B", Start: 26, Stop: 40},
+			},
+		},
+		{
+			// B starts at A's vruntime at 10 instead of 0, so it doesn't run alone until it catches up;
+			// A's slice shrinks to 4 when B arrives, so A is preempted at 12.
+			name: "late arrival",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 20},
+				{ProcessID: "B", ArrivalTime: 10, BurstDuration: 4},
+			},
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 12},
+				{PID: "B", Start: 12, Stop: 16},
+				{PID: "A", Start: 16, Stop: 24},
+			},
+		},
+		{
+			name: "idle gap and out of range nice",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 2, BurstDuration: 3, Priority: -99},
+				{ProcessID: "B", ArrivalTime: 9, BurstDuration: 2, Priority: 99},
+			},
+			want: []TimeSlice{
+				{PID: "A", Start: 2, Stop: 5},
+				{PID: "B", Start: 9, Stop: 11},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.want, CFSSchedule(io.Discard, "cfs", tt.processes).Gantt); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The Classes AutoClassifySchedule gives processes.
+const (
+	ClassInteractive = "interactive"
+	ClassBatch       = "batch"
+)
+
+// ClassifyBurst is the Class a process with p's burst pattern would get from a kernel's
+// interactivity heuristic: ClassInteractive when each of its CPU bursts, the runs between its I/O
+// bursts and suspensions, is at most threshold ticks, giving the CPU up within a quantum, and
+// ClassBatch when one is longer. A process without either is a single CPU burst.
+func ClassifyBurst(p Process, threshold int64) string {
+	if slices.Max(append(p.cpuRuns(), 0)) <= threshold {
+		return ClassInteractive
+	}
+	return ClassBatch
+}
+
+// cpuRuns are the CPU bursts p's burst comes in were it to run alone from its arrival, each ended
+// by one of its I/O bursts or suspensions.
+func (p Process) cpuRuns() []int64 {
+	var runs []int64
+	for now, used := p.ArrivalTime, int64(0); used < p.BurstDuration; {
+		now = p.resumeAt(now)
+		run := p.runnableFor(now, p.cpuBeforeIO(used, p.BurstDuration-used))
+		runs = append(runs, run)
+		used += run
+		now += run + p.ioAfter(used)
+	}
+	return runs
+}
+
+// AutoClassifySchedule classifies every process without a Class by ClassifyBurst and routes it to
+// the matching queue of a MultilevelQueueSchedule: interactive processes take turns round-robin
+// for threshold ticks, the longest CPU burst they were classified by, ahead of the batch ones,
+// which run first-come, first-serve. A process keeps a Class it was given, and queues with the
+// batch ones for a class other than these two. A threshold below 1 is defaultQuantum. With
+// verbose, its Notes also hold the class of every process and why.
+func AutoClassifySchedule(w io.Writer, title string, processes []Process, threshold int64, verbose bool) ScheduleResult {
+	if threshold < 1 {
+		threshold = defaultQuantum
+	}
+	classified := make([]Process, len(processes))
+	for i, p := range processes {
+		classified[i] = p
+		if p.Class == "" {
+			classified[i].Class = ClassifyBurst(p, threshold)
+		}
+	}
+	result := MultilevelQueueSchedule(io.Discard, title, classified, []QueueLevel{
+		{Class: ClassInteractive, Quantum: threshold},
+		{Class: ClassBatch},
+	})
+	if verbose {
+		result.Notes = noteLines(func(w io.Writer) { outputClassification(w, processes, threshold) })
+	}
+	outputResult(w, result)
+
+	return result
+}
+
+// outputClassification writes the Class of every process with the CPU bursts it was decided by,
+// or that it was given.
+func outputClassification(w io.Writer, processes []Process, threshold int64) {
+	_, _ = fmt.Fprintf(w, "Classification (interactive with CPU bursts of up to %d ticks)\n", threshold)
+	for _, p := range processes {
+		if p.Class != "" {
+			_, _ = fmt.Fprintf(w, "%s: %s, given\n", p.ProcessID, p.Class)
+			continue
+		}
+		runs := make([]string, 0, len(p.IOBursts)+1)
+		for _, run := range p.cpuRuns() {
+			runs = append(runs, strconv.FormatInt(run, 10))
+		}
+		_, _ = fmt.Fprintf(w, "%s: %s, CPU bursts %s\n", p.ProcessID, ClassifyBurst(p, threshold), strings.Join(runs, ", "))
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAutoClassifySchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 8, Class: ClassInteractive},
+		{ProcessID: "D", ArrivalTime: 3, BurstDuration: 3},
+	}
+	var w bytes.Buffer
+	result := AutoClassifySchedule(&w, "classified", processes, 0, true)
+	// B and D, within the default quantum, preempt the batch A; C keeps its Class, though long.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 1},
+		{PID: "B", Start: 1, Stop: 3},
+		{PID: "C", Start: 3, Stop: 7},
+		{PID: "D", Start: 7, Stop: 10},
+		{PID: "C", Start: 10, Stop: 14},
+		{PID: "A", Start: 14, Stop: 23},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	for _, line := range []string{
+		"Classification (interactive with CPU bursts of up to 4 ticks)",
+		"A: batch, CPU bursts 10",
+		"B: interactive, CPU bursts 2",
+		"C: interactive, given",
+	} {
+		if !strings.Contains(w.String(), line) {
+			t.Errorf("output is missing %q:\n%s", line, w.String())
+		}
+	}
+
+	w.Reset()
+	AutoClassifySchedule(&w, "quiet", processes, 0, false)
+	if strings.Contains(w.String(), "Classification") {
+		t.Errorf("output without verbose has the classification:\n%s", w.String())
+	}
+}
+
+func TestClassifyBurst(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		process Process
+		want    string
+	}{
+		{
+			name:    "short",
+			process: Process{ProcessID: "A", BurstDuration: 3},
+			want:    ClassInteractive,
+		},
+		{
+			name:    "long",
+			process: Process{ProcessID: "A", BurstDuration: 5},
+			want:    ClassBatch,
+		},
+		{
+			name:    "long, in short CPU bursts between I/O",
+			process: Process{ProcessID: "A", BurstDuration: 9, IOBursts: []IOBurst{{After: 3, Duration: 5}, {After: 6, Duration: 5}}},
+			want:    ClassInteractive,
+		},
+		{
+			name:    "long, in short CPU bursts between suspensions",
+			process: Process{ProcessID: "A", ArrivalTime: 2, BurstDuration: 8, Suspensions: []Window{{Start: 6, Stop: 10}}},
+			want:    ClassInteractive,
+		},
+		{
+			name:    "one long CPU burst between I/O",
+			process: Process{ProcessID: "A", BurstDuration: 9, IOBursts: []IOBurst{{After: 2, Duration: 5}}},
+			want:    ClassBatch,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ClassifyBurst(tt.process, 4); got != tt.want {
+				t.Errorf("ClassifyBurst() = %v, want %v (CPU bursts %v)", got, tt.want, tt.process.cpuRuns())
+			}
+		})
+	}
+}
+
+func TestAutoClassifySchedule_threshold(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: "B", ArrivalTime: 0, BurstDuration: 6},
+	}
+	// within a threshold of 6, both are interactive and take turns for 6 ticks, so run through.
+	result := AutoClassifySchedule(io.Discard, "classified", processes, 6, false)
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 6},
+		{PID: "B", Start: 6, Stop: 12},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+// CoScheduleSchedule is round-robin that runs the processes of a Group back to back, modelling
+// their shared cache or communication: once a grouped process has had its defaultQuantum, the
+// earliest queued member of its group that hasn't run since the group took the CPU runs next, ahead
+// of the rest of the queue. When every queued member has had its turn, the CPU goes back to the
+// head of the queue, so a group can't keep it for more than a round. Ungrouped processes run as in
+// RRSchedule. It writes the CoRunPercent.
+func CoScheduleSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	var (
+		// group is the group running back to back, and ran its members that have run since it
+		// took the CPU.
+		group string
+		ran   map[string]bool
+	)
+	pick := func(ready []Process, _ int64) int {
+		if group != "" {
+			if i := slices.IndexFunc(ready, func(p Process) bool { return p.Group == group && !ran[p.ProcessID] }); i >= 0 {
+				ran[ready[i].ProcessID] = true
+				return i
+			}
+		}
+		group, ran = ready[0].Group, map[string]bool{ready[0].ProcessID: true}
+		return 0
+	}
+	result, _ := Simulate(processes, pick, SimOptions{Title: title, Quantum: defaultQuantum})
+	result.Notes = []string{fmt.Sprintf("Co-run: %.1f%%", CoRunPercent(result))}
+	outputResult(w, result)
+
+	return result
+}
+
+// CoRunPercent is the percentage of the slices of grouped processes in result that ran right
+// before or after a slice of another process of the same Group, 0 when no process is grouped.
+func CoRunPercent(result ScheduleResult) float64 {
+	groups := make(map[string]string, len(result.Processes))
+	for _, p := range result.Processes {
+		groups[p.ProcessID] = p.Group
+	}
+	gantt := result.Gantt
+	// coRun reports whether slice i of gantt directly follows a slice of another process of its group.
+	coRun := func(i int) bool {
+		if i <= 0 || i >= len(gantt) {
+			return false
+		}
+		a, b := gantt[i-1], gantt[i]
+		return a.Stop == b.Start && a.PID != b.PID && groups[a.PID] == groups[b.PID]
+	}
+	var grouped, together int
+	for i, slice := range gantt {
+		if groups[slice.PID] == "" {
+			continue
+		}
+		grouped++
+		if coRun(i) || coRun(i+1) {
+			together++
+		}
+	}
+	if grouped == 0 {
+		return 0
+	}
+	return 100 * float64(together) / float64(grouped)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCoScheduleSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 8, Group: "g"},
+		{ProcessID: "C", BurstDuration: 8},
+		{ProcessID: "B", BurstDuration: 8, Group: "g"},
+		{ProcessID: "D", BurstDuration: 4},
+	}
+	var w bytes.Buffer
+	result := CoScheduleSchedule(&w, "co-schedule", processes)
+	// B follows A ahead of C, after which the group has had its round and C and D run.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 4},
+		{PID: "B", Start: 4, Stop: 8},
+		{PID: "C", Start: 8, Stop: 12},
+		{PID: "D", Start: 12, Stop: 16},
+		{PID: "A", Start: 16, Stop: 20},
+		{PID: "B", Start: 20, Stop: 24},
+		{PID: "C", Start: 24, Stop: 28},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if !strings.Contains(w.String(), "Co-run: 100.0%") {
+		t.Errorf("output is missing the co-run percentage:\n%s", w.String())
+	}
+	// round-robin interleaves A and B with C and D.
+	if got := CoRunPercent(RRSchedule(io.Discard, "rr", processes)); got != 0 {
+		t.Errorf("RRSchedule CoRunPercent() = %.1f, want 0", got)
+	}
+}
+
+func TestCoRunPercent(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 2, Group: "g"},
+		{ProcessID: "B", BurstDuration: 2, Group: "g"},
+		{ProcessID: "C", BurstDuration: 2, Group: "h"},
+	}
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  float64
+	}{
+		{
+			name:  "adjacent",
+			gantt: []TimeSlice{{PID: "A", Start: 0, Stop: 2}, {PID: "B", Start: 2, Stop: 4}, {PID: "C", Start: 4, Stop: 6}},
+			// C has no groupmate.
+			want: 200.0 / 3,
+		},
+		{
+			name:  "idle in between",
+			gantt: []TimeSlice{{PID: "A", Start: 0, Stop: 2}, {PID: "B", Start: 3, Stop: 5}, {PID: "C", Start: 5, Stop: 7}},
+		},
+		{
+			name:  "interleaved",
+			gantt: []TimeSlice{{PID: "A", Start: 0, Stop: 2}, {PID: "C", Start: 2, Stop: 4}, {PID: "B", Start: 4, Stop: 6}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := CoRunPercent(newScheduleResult("co-run", processes, tt.gantt)); got != tt.want {
+				t.Errorf("CoRunPercent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
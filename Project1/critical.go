@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ganttHighlight marks the gantt columns during which the critical process was waiting.
+const ganttHighlight = "^"
+
+// CriticalProcess is the process of result that waited longest, the earliest listed on a tie.
+// Aborted and CPU-limited processes have no wait and are skipped; ok is false when no process completed.
+func CriticalProcess(result ScheduleResult) (critical ProcessResult, ok bool) {
+	for _, p := range result.Processes {
+		if p.Aborted || p.Limited {
+			continue
+		}
+		if !ok || p.Wait > critical.Wait {
+			critical, ok = p, true
+		}
+	}
+	return critical, ok
+}
+
+// CriticalWaits are the intervals the CriticalProcess of result spent ready but not running.
+// Suspended time isn't waiting on the scheduler and is left out.
+func CriticalWaits(result ScheduleResult) (pid string, waits []StateInterval) {
+	critical, ok := CriticalProcess(result)
+	if !ok {
+		return "", nil
+	}
+	for _, interval := range StateTimeline(result)[critical.ProcessID] {
+		if interval.State == StateReady {
+			waits = append(waits, interval)
+		}
+	}
+	return critical.ProcessID, waits
+}
+
+// outputCriticalWaits says which process waited longest and when, below a gantt highlighting it.
+func outputCriticalWaits(w io.Writer, result ScheduleResult, unit TimeUnit) {
+	pid, waits := CriticalWaits(result)
+	if len(waits) == 0 {
+		return
+	}
+	spans := make([]string, len(waits))
+	var waited int64
+	for i, interval := range waits {
+		spans[i] = unit.Format(interval.Start) + "-" + unit.Format(interval.Stop)
+		waited += interval.Stop - interval.Start
+	}
+	_, _ = fmt.Fprintf(w, "Longest wait (%s): %s waited %s, ready at %s\n\n",
+		ganttHighlight, pid, unit.Format(waited), strings.Join(spans, ", "))
+}
+
+// overlapsAny reports whether slice shares any time with one of the intervals.
+func overlapsAny(slice TimeSlice, intervals []StateInterval) bool {
+	for _, interval := range intervals {
+		if interval.Start < slice.Stop && slice.Start < interval.Stop {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCriticalWaits(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: "P2", ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		name      string
+		result    ScheduleResult
+		wantPID   string
+		wantWaits []StateInterval
+	}{
+		{
+			// P2 waits behind all of P1.
+			name:      "fcfs",
+			result:    FCFSSchedule(io.Discard, "fcfs", processes),
+			wantPID:   "P2",
+			wantWaits: []StateInterval{{Start: 6, Stop: 14, State: StateReady}},
+		},
+		{
+			// P1 waits for P0's first quantum, then P0's last tick and P2, then P2 again.
+			name:    "rr",
+			result:  RRSchedule(io.Discard, "rr", processes),
+			wantPID: "P1",
+			wantWaits: []StateInterval{
+				{Start: 3, Stop: 4, State: StateReady},
+				{Start: 8, Stop: 13, State: StateReady},
+				{Start: 17, Stop: 19, State: StateReady},
+			},
+		},
+		{
+			name: "empty",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			pid, waits := CriticalWaits(tt.result)
+			if pid != tt.wantPID {
+				t.Errorf("CriticalWaits() process = %q, want %q", pid, tt.wantPID)
+			}
+			if diff := cmp.Diff(tt.wantWaits, waits); diff != "" {
+				t.Errorf(diff)
+			}
+			// the highlighted intervals add up to the process' wait.
+			critical, _ := CriticalProcess(tt.result)
+			var waited int64
+			for _, interval := range waits {
+				waited += interval.Stop - interval.Start
+			}
+			if waited != critical.Wait {
+				t.Errorf("waits add up to %d, want %s's wait of %d", waited, pid, critical.Wait)
+			}
+		})
+	}
+}
+
+func TestOutputResultWith_critical(t *testing.T) {
+	t.Parallel()
+	result := FCFSSchedule(io.Discard, "fcfs", []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: "P2", ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	})
+	var w bytes.Buffer
+	outputResultWith(&w, result, renderOptions{critical: true})
+	want := `Gantt schedule
+|  P0  |  P1  |  P2  |
+0      5      14     20
+        ^^^^^^
+
+Longest wait (^): P2 waited 8, ready at 6-14
+
+`
+	if !strings.Contains(w.String(), want) {
+		t.Errorf("output is missing the highlighted gantt:\n%s", w.String())
+	}
+}
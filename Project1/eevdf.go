@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+)
+
+// eevdfEpsilon absorbs float rounding when comparing virtual times.
+const eevdfEpsilon = 1e-9
+
+// LagStats is the range of a process' lag over an EEVDFSchedule: the CPU time it was owed (positive)
+// or had received beyond its share (negative), in ticks, at each dispatch while it was runnable.
+type LagStats struct {
+	PID      string
+	Min, Max float64
+}
+
+// eevdfTask is a runnable process of EEVDFSchedule.
+type eevdfTask struct {
+	process Process
+	// vruntime is the CPU time received in ticks at nice 0, deadline the vruntime by which the
+	// current request of defaultQuantum ticks is due.
+	vruntime, deadline float64
+}
+
+// EEVDFSchedule is earliest eligible virtual deadline first, the successor to CFS in Linux. The
+// system's virtual time V is the weight-averaged vruntime of the runnable processes, and a
+// process' lag, what it's owed of its weighted share, is its weight times V - vruntime. Processes
+// with no negative lag are eligible, and of those the one with the earliest virtual deadline runs
+// next: each request of defaultQuantum ticks is due its weight's share of that later, so more
+// important processes get earlier deadlines. Priority is the nice value, weighted as in
+// CFSSchedule. A dispatched process runs its request out; an arrival joins with zero lag. It
+// writes each process' lag range.
+func EEVDFSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	result, lags := eevdfSchedule(title, processes)
+	result.Notes = noteLines(func(w io.Writer) { outputLags(w, lags) })
+	outputResult(w, result)
+
+	return result
+}
+
+func eevdfSchedule(title string, processes []Process) (ScheduleResult, []LagStats) {
+	var (
+		runnable []*eevdfTask
+		current  *eevdfTask
+		ranFor   int64
+		lastV    float64
+		lags     = make([]LagStats, len(processes))
+		index    = make(map[string]int, len(processes))
+	)
+	for i, p := range processes {
+		lags[i] = LagStats{PID: p.ProcessID, Min: math.Inf(1), Max: math.Inf(-1)}
+		index[p.ProcessID] = i
+	}
+	weight := func(task *eevdfTask) float64 { return float64(NiceWeight(task.process)) }
+	request := func(task *eevdfTask) float64 { return float64(defaultQuantum*cfsNice0Weight) / weight(task) }
+	// virtualTime is V, the weighted average vruntime of the runnable processes.
+	virtualTime := func() float64 {
+		if len(runnable) == 0 {
+			return lastV
+		}
+		var sum, total float64
+		for _, task := range runnable {
+			sum += weight(task) * task.vruntime
+			total += weight(task)
+		}
+		lastV = sum / total
+		return lastV
+	}
+	lag := func(task *eevdfTask, v float64) float64 {
+		return weight(task) * (v - task.vruntime) / float64(cfsNice0Weight)
+	}
+	taskOf := func(id string) *eevdfTask {
+		i := slices.IndexFunc(runnable, func(task *eevdfTask) bool { return task.process.ProcessID == id })
+		return runnable[i]
+	}
+
+	pick := func(ready []Process, _ int64) int {
+		if current != nil {
+			if i := readyIndex(ready, current.process.ProcessID); i >= 0 {
+				return i
+			}
+		}
+
+		// the eligible process with the earliest deadline, the earliest queued on a tie. Should every
+		// eligible one be suspended, the earliest deadline of the rest.
+		var fallback *eevdfTask
+		current = nil
+		v := virtualTime()
+		for _, task := range runnable {
+			l := lag(task, v)
+			stats := &lags[index[task.process.ProcessID]]
+			stats.Min, stats.Max = math.Min(stats.Min, l), math.Max(stats.Max, l)
+			if readyIndex(ready, task.process.ProcessID) < 0 {
+				continue
+			}
+			if task.vruntime <= v+eevdfEpsilon && (current == nil || task.deadline < current.deadline-eevdfEpsilon) {
+				current = task
+			}
+			if fallback == nil || task.deadline < fallback.deadline-eevdfEpsilon {
+				fallback = task
+			}
+		}
+		if current == nil {
+			current = fallback
+		}
+		ranFor = 0
+		return readyIndex(ready, current.process.ProcessID)
+	}
+	ran := func(p Process, start, stop int64) {
+		task := taskOf(p.ProcessID)
+		ranFor += stop - start
+		task.vruntime += float64((stop-start)*cfsNice0Weight) / weight(task)
+		if p.BurstDuration > 0 && ranFor == defaultQuantum {
+			task.deadline = task.vruntime + request(task)
+			current = nil
+		}
+	}
+
+	result, _ := Simulate(processes, pick, SimOptions{
+		Title:      title,
+		Preemptive: true,
+		KeepPlace:  true,
+		Arrived: func(p Process, _ int64) {
+			task := &eevdfTask{process: p, vruntime: virtualTime()}
+			task.deadline = task.vruntime + request(task)
+			runnable = append(runnable, task)
+		},
+		Ran: ran,
+		Left: func(p Process, _ int64) {
+			runnable = slices.DeleteFunc(runnable, func(task *eevdfTask) bool { return task.process.ProcessID == p.ProcessID })
+			if current != nil && current.process.ProcessID == p.ProcessID {
+				current = nil
+			}
+		},
+	})
+	return result, lags
+}
+
+// outputLags writes the lag range of every process.
+func outputLags(w io.Writer, lags []LagStats) {
+	_, _ = fmt.Fprintln(w, "Lag (ticks owed)")
+	for _, l := range lags {
+		_, _ = fmt.Fprintf(w, "%s: min %.2f, max %.2f\n", l.PID, l.Min, l.Max)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestEEVDFSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []TimeSlice
+		wantLags  []LagStats
+	}{
+		{
+			// after each request the other process is owed 2 ticks and has the earlier deadline.
+			name: "equal nice",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 8},
+				{ProcessID: "B", ArrivalTime: 0, BurstDuration: 8},
+			},
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 4},
+				{PID: "B", Start: 4, Stop: 8},
+				{PID: "A", Start: 8, Stop: 12},
+				{PID: "B", Start: 12, Stop: 16},
+			},
+			wantLags: []LagStats{{PID: "A", Min: -2, Max: 0}, {PID: "B", Min: 0, Max: 2}},
+		},
+		{
+			// B's nice 5 weighs 335 to A's 1024, so after its request A stays eligible for two more.
+			name: "nice 5 against nice 0",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 12},
+				{ProcessID: "B", ArrivalTime: 0, BurstDuration: 12, Priority: 5},
+			},
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 4},
+				{PID: "B", Start: 4, Stop: 8},
+				{PID: "A", Start: 8, Stop: 16},
+				{PID: "B", Start: 16, Stop: 24},
+			},
+			wantLags: []LagStats{{PID: "A", Min: -0.99, Max: 2.03}, {PID: "B", Min: -2.03, Max: 0.99}},
+		},
+		{
+			// B joins at A's vruntime of 2 with the earlier deadline, and runs once A's request is out.
+			name: "late arrival",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 12},
+				{ProcessID: "B", ArrivalTime: 2, BurstDuration: 4},
+			},
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 4},
+				{PID: "B", Start: 4, Stop: 8},
+				{PID: "A", Start: 8, Stop: 16},
+			},
+			wantLags: []LagStats{{PID: "A", Min: -1, Max: 0}, {PID: "B", Min: 1, Max: 1}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, lags := eevdfSchedule("eevdf", tt.processes)
+			if diff := cmp.Diff(tt.want, result.Gantt); diff != "" {
+				t.Errorf(diff)
+			}
+			if diff := cmp.Diff(tt.wantLags, lags, cmpopts.EquateApprox(0, 0.01)); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestEEVDFSchedule_output(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	EEVDFSchedule(&w, "eevdf", []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 12},
+		{ProcessID: "B", ArrivalTime: 2, BurstDuration: 4},
+	})
+	if want := "Lag (ticks owed)\nA: min -1.00, max 0.00\nB: min 1.00, max 1.00\n"; !strings.Contains(w.String(), want) {
+		t.Errorf("output is missing the lags:\n%s", w.String())
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// TestEquivalences checks that each scheduler that generalizes another makes the same schedule as
+// it on a workload that doesn't use what tells them apart.
+func TestEquivalences(t *testing.T) {
+	t.Parallel()
+	plain := exponentialWorkload(300, 10, 8, 9)
+	prioritized := exponentialWorkload(300, 10, 8, 4)
+	for i := range prioritized {
+		prioritized[i].Priority = int64(i * 7 % 5)
+	}
+	// thresholded raises the threshold of every other process by one, below its priority.
+	thresholded := make([]Process, len(prioritized))
+	for i, p := range prioritized {
+		thresholded[i] = p
+		if i%2 == 0 {
+			thresholded[i].PreemptionThreshold = p.Priority + 1
+		}
+	}
+	// alike are equal processes arriving together.
+	alike := make([]Process, 20)
+	for i := range alike {
+		alike[i] = Process{ProcessID: fmt.Sprint(i), BurstDuration: 7}
+	}
+	type schedule func(w io.Writer, title string, processes []Process) ScheduleResult
+	tests := []struct {
+		name      string
+		processes []Process
+		want, got schedule
+	}{
+		{name: "SRTFSchedule is SJFSchedule", processes: plain, want: SJFSchedule, got: SRTFSchedule},
+		{
+			name:      "SJFBoundedWaitSchedule without a bound is SJFSchedule",
+			processes: plain,
+			want:      SJFSchedule,
+			got: func(w io.Writer, title string, processes []Process) ScheduleResult {
+				return SJFBoundedWaitSchedule(w, title, processes, 0)
+			},
+		},
+		{
+			name:      "PreemptivePrioritySchedule is SJFPrioritySchedule",
+			processes: prioritized,
+			want:      SJFPrioritySchedule,
+			got:       PreemptivePrioritySchedule,
+		},
+		{
+			name:      "PreemptionThresholdSchedule without thresholds is PreemptivePrioritySchedule",
+			processes: prioritized,
+			want:      PreemptivePrioritySchedule,
+			got:       PreemptionThresholdSchedule,
+		},
+		{
+			name:      "PreemptionThresholdSchedule with thresholds below the priorities is PreemptivePrioritySchedule",
+			processes: thresholded,
+			want:      PreemptivePrioritySchedule,
+			got:       PreemptionThresholdSchedule,
+		},
+		{
+			name:      "PriorityAgingSchedule without aging is PreemptivePrioritySchedule",
+			processes: prioritized,
+			want:      PreemptivePrioritySchedule,
+			got: func(w io.Writer, title string, processes []Process) ScheduleResult {
+				return PriorityAgingSchedule(w, title, processes, 0, UnboundedPriorities)
+			},
+		},
+		{
+			name:      "SETFSchedule of equal processes is round-robin with a quantum of 1",
+			processes: alike,
+			want: func(w io.Writer, title string, processes []Process) ScheduleResult {
+				return RRQuantumSchedule(w, title, processes, 1)
+			},
+			got: SETFSchedule,
+		},
+		{
+			name:      "BFSSchedule with equal priorities is round-robin",
+			processes: plain,
+			want: func(w io.Writer, title string, processes []Process) ScheduleResult {
+				return RRQuantumSchedule(w, title, processes, bfsRRInterval)
+			},
+			got: BFSSchedule,
+		},
+		{name: "WRRSchedule with equal weights is RRSchedule", processes: plain, want: RRSchedule, got: WRRSchedule},
+		{
+			name:      "RRBoostSchedule without a threshold is RRSchedule",
+			processes: plain,
+			want:      RRSchedule,
+			got: func(w io.Writer, title string, processes []Process) ScheduleResult {
+				return RRBoostSchedule(w, title, processes, 0)
+			},
+		},
+		{
+			name:      "SRRSchedule with b of 0 is RRSchedule",
+			processes: plain,
+			want:      RRSchedule,
+			got: func(w io.Writer, title string, processes []Process) ScheduleResult {
+				return SRRSchedule(w, title, processes, 3, 0)
+			},
+		},
+		{name: "VRRSchedule without I/O bursts is RRSchedule", processes: plain, want: RRSchedule, got: VRRSchedule},
+		{name: "PriorityRRSchedule with one priority is RRSchedule", processes: plain, want: RRSchedule, got: PriorityRRSchedule},
+		{name: "POSIXSchedule of normal processes is RRSchedule", processes: plain, want: RRSchedule, got: POSIXSchedule},
+		{name: "BandwidthSchedule without quotas is RRSchedule", processes: plain, want: RRSchedule, got: BandwidthSchedule},
+		{name: "CoScheduleSchedule without groups is RRSchedule", processes: plain, want: RRSchedule, got: CoScheduleSchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.want(io.Discard, "equal", tt.processes), tt.got(io.Discard, "equal", tt.processes),
+				// the notes are the statistics of the scheduler run.
+				cmpopts.IgnoreFields(ScheduleResult{}, "Notes")); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// EventKind is a scheduler state transition.
+type EventKind string
+
+const (
+	EventArrive    EventKind = "ARRIVE"
+	EventDispatch  EventKind = "DISPATCH"
+	EventPreempt   EventKind = "PREEMPT"
+	EventComplete  EventKind = "COMPLETE"
+	EventKill      EventKind = "KILL"
+	EventLimit     EventKind = "LIMIT"
+	EventBlock     EventKind = "BLOCK"
+	EventUnblock   EventKind = "UNBLOCK"
+	EventIdleStart EventKind = "IDLE_START"
+	EventIdleEnd   EventKind = "IDLE_END"
+)
+
+// eventOrder breaks ties between events at the same instant: the running process leaves the
+// CPU, arrivals and unblocked processes join the ready queue, idling ends and only then is the
+// next process dispatched. A process blocked or killed off the CPU leaves the ready queue after
+// it arrived.
+var eventOrder = map[EventKind]int{
+	EventPreempt:   0,
+	EventComplete:  0,
+	EventKill:      0,
+	EventLimit:     0,
+	EventIdleStart: 1,
+	EventArrive:    2,
+	EventUnblock:   2,
+	EventIdleEnd:   3,
+	EventDispatch:  4,
+}
+
+// Event is a single state transition. ReadyLen is the ready queue length right after it.
+type Event struct {
+	Time     int64
+	Kind     EventKind
+	PID      string
+	ReadyLen int
+}
+
+// EventHook receives every state transition of a schedule in time order.
+type EventHook func(Event)
+
+// ReplayEvents derives the state transitions of a finished schedule from its processes and
+// gantt, and sends them to hook in time order. A process leaves the CPU by completing, being
+// killed, reaching its CPU limit, blocking or being preempted, and it blocks for its suspensions,
+// I/O and throttling, on the CPU or off it; only preempted and unblocked processes rejoin the
+// ready queue.
+func ReplayEvents(result ScheduleResult, hook EventHook) {
+	makespan := Makespan(result.Gantt)
+	byID := make(map[string]ProcessResult, len(result.Processes))
+	stops := make(map[string]map[int64]bool, len(result.Processes))
+	for _, slice := range result.Gantt {
+		if stops[slice.PID] == nil {
+			stops[slice.PID] = make(map[int64]bool)
+		}
+		stops[slice.PID][slice.Stop] = true
+	}
+
+	events := make([]Event, 0, 2*len(result.Processes)+2*len(result.Gantt))
+	for _, p := range result.Processes {
+		byID[p.ProcessID] = p
+		events = append(events, Event{Time: p.ArrivalTime, Kind: EventArrive, PID: p.ProcessID})
+	}
+	for _, p := range result.Processes {
+		// off the CPU, a process blocks and is killed on its own.
+		start, end := p.lifetime(makespan)
+		for _, w := range p.blockedWindows() {
+			if w.Stop <= start || w.Start >= end {
+				continue
+			}
+			if !stops[p.ProcessID][w.Start] {
+				events = append(events, Event{Time: max(w.Start, start), Kind: EventBlock, PID: p.ProcessID})
+			}
+			if w.Stop < end {
+				events = append(events, Event{Time: w.Stop, Kind: EventUnblock, PID: p.ProcessID})
+			}
+		}
+		if p.Aborted && p.KillAt >= start && !stops[p.ProcessID][p.KillAt] {
+			events = append(events, Event{Time: p.KillAt, Kind: EventKill, PID: p.ProcessID})
+		}
+	}
+
+	var busyUntil int64
+	for i, slice := range result.Gantt {
+		if slice.Start > busyUntil || (i == 0 && slice.Start > 0) {
+			events = append(events,
+				Event{Time: busyUntil, Kind: EventIdleStart},
+				Event{Time: slice.Start, Kind: EventIdleEnd},
+			)
+		}
+		events = append(events,
+			Event{Time: slice.Start, Kind: EventDispatch, PID: slice.PID},
+			Event{Time: slice.Stop, Kind: byID[slice.PID].leaveKind(slice.Stop), PID: slice.PID},
+		)
+		busyUntil = max(busyUntil, slice.Stop)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Time != events[j].Time {
+			return events[i].Time < events[j].Time
+		}
+		return eventOrder[events[i].Kind] < eventOrder[events[j].Kind]
+	})
+
+	ready := make(map[string]bool, len(result.Processes))
+	for _, e := range events {
+		switch e.Kind {
+		case EventArrive, EventPreempt, EventUnblock:
+			ready[e.PID] = true
+		case EventDispatch, EventComplete, EventKill, EventLimit, EventBlock:
+			delete(ready, e.PID)
+		}
+		e.ReadyLen = len(ready)
+		hook(e)
+	}
+}
+
+// leaveKind is why p left the CPU at stop.
+func (p ProcessResult) leaveKind(stop int64) EventKind {
+	switch {
+	case p.Remaining == 0 && stop == p.Completion:
+		return EventComplete
+	case p.Limited && stop == p.Terminated:
+		return EventLimit
+	case p.Aborted && stop == p.KillAt:
+		return EventKill
+	case slices.ContainsFunc(p.blockedWindows(), func(w Window) bool { return w.Start == stop }):
+		return EventBlock
+	}
+	return EventPreempt
+}
+
+// blockedWindows are the windows p is suspended, blocked on I/O or throttled in, sorted and with
+// the overlapping or back-to-back ones merged.
+func (p ProcessResult) blockedWindows() []Window {
+	windows := slices.Concat(p.Suspensions, p.IO, p.Throttled)
+	slices.SortFunc(windows, func(a, b Window) int { return cmp.Compare(a.Start, b.Start) })
+	var merged []Window
+	for _, w := range windows {
+		if n := len(merged); n > 0 && w.Start <= merged[n-1].Stop {
+			merged[n-1].Stop = max(merged[n-1].Stop, w.Stop)
+			continue
+		}
+		merged = append(merged, w)
+	}
+	return merged
+}
+
+// CSVEventLog buffers events as CSV rows with a header. Call Flush when done.
+type CSVEventLog struct {
+	w      *csv.Writer
+	header bool
+}
+
+func NewCSVEventLog(w io.Writer) *CSVEventLog {
+	return &CSVEventLog{w: csv.NewWriter(w)}
+}
+
+// OnEvent is an EventHook writing the event as a CSV row.
+func (l *CSVEventLog) OnEvent(e Event) {
+	if !l.header {
+		_ = l.w.Write([]string{"time", "event", "pid", "ready"})
+		l.header = true
+	}
+	_ = l.w.Write([]string{
+		strconv.FormatInt(e.Time, 10),
+		string(e.Kind),
+		e.PID,
+		strconv.Itoa(e.ReadyLen),
+	})
+}
+
+// Flush writes any buffered rows, returning the first error encountered while writing.
+func (l *CSVEventLog) Flush() error {
+	l.w.Flush()
+	return l.w.Error()
+}
+
+// writeEventsFile writes the schedule's event log to a CSV file, flushing whatever was
+// buffered even if replaying fails part way.
+func writeEventsFile(name string, result ScheduleResult) (err error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("%w: error creating events file", err)
+	}
+	log := NewCSVEventLog(f)
+	defer func() {
+		if flushErr := log.Flush(); err == nil {
+			err = flushErr
+		}
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	ReplayEvents(result, log.OnEvent)
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCSVEventLog(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: "C", ArrivalTime: 10, BurstDuration: 1},
+	}
+	result := RRSchedule(io.Discard, "rr", processes)
+
+	var w bytes.Buffer
+	log := NewCSVEventLog(&w)
+	ReplayEvents(result, log.OnEvent)
+	if err := log.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `time,event,pid,ready
+0,ARRIVE,A,1
+0,DISPATCH,A,0
+1,ARRIVE,B,1
+4,PREEMPT,A,2
+4,DISPATCH,B,1
+7,COMPLETE,B,1
+7,DISPATCH,A,0
+8,COMPLETE,A,0
+8,IDLE_START,,0
+10,ARRIVE,C,1
+10,IDLE_END,,1
+10,DISPATCH,C,0
+11,COMPLETE,C,0
+`
+	if diff := cmp.Diff(want, w.String()); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestReplayEvents(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      string
+	}{
+		{
+			name: "killed while running",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 5, KillAt: 3},
+				{ProcessID: "B", ArrivalTime: 1, BurstDuration: 2},
+			},
+			want: `time,event,pid,ready
+0,ARRIVE,A,1
+0,DISPATCH,A,0
+1,ARRIVE,B,1
+3,KILL,A,1
+3,DISPATCH,B,0
+5,COMPLETE,B,0
+`,
+		},
+		{
+			name: "killed while waiting",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4},
+				{ProcessID: "B", ArrivalTime: 0, BurstDuration: 4, KillAt: 2},
+			},
+			want: `time,event,pid,ready
+0,ARRIVE,A,1
+0,ARRIVE,B,2
+0,DISPATCH,A,1
+2,KILL,B,0
+4,COMPLETE,A,0
+`,
+		},
+		{
+			name: "blocked on I/O",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4, IOBursts: []IOBurst{{After: 2, Duration: 3}}},
+			},
+			want: `time,event,pid,ready
+0,ARRIVE,A,1
+0,DISPATCH,A,0
+2,BLOCK,A,0
+2,IDLE_START,,0
+5,UNBLOCK,A,1
+5,IDLE_END,,1
+5,DISPATCH,A,0
+7,COMPLETE,A,0
+`,
+		},
+		{
+			name: "blocked on I/O while another runs",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4, IOBursts: []IOBurst{{After: 2, Duration: 3}}},
+				{ProcessID: "B", ArrivalTime: 1, BurstDuration: 6},
+			},
+			want: `time,event,pid,ready
+0,ARRIVE,A,1
+0,DISPATCH,A,0
+1,ARRIVE,B,1
+2,BLOCK,A,1
+2,DISPATCH,B,0
+5,UNBLOCK,A,1
+8,COMPLETE,B,1
+8,DISPATCH,A,0
+10,COMPLETE,A,0
+`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := FCFSSchedule(io.Discard, "fcfs", tt.processes)
+			var w bytes.Buffer
+			log := NewCSVEventLog(&w)
+			ReplayEvents(result, log.OnEvent)
+			if err := log.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, w.String()); diff != "" {
+				t.Errorf(diff)
+			}
+			if err := VerifyStateTimeline(result, StateTimeline(result)); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func Test_writeEventsFile(t *testing.T) {
+	t.Parallel()
+	result := FCFSSchedule(io.Discard, "fcfs", []Process{
+		{ProcessID: "A", ArrivalTime: 2, BurstDuration: 1},
+	})
+	name := filepath.Join(t.TempDir(), "events.csv")
+	if err := writeEventsFile(name, result); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `time,event,pid,ready
+0,IDLE_START,,0
+2,ARRIVE,A,1
+2,IDLE_END,,1
+2,DISPATCH,A,0
+3,COMPLETE,A,0
+`
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf(diff)
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"slices"
+)
+
+// UserShare is the CPU a user's processes got in a schedule (see UserShares).
+type UserShare struct {
+	User string
+	CPU  int64
+	// Share is CPU over all the users' CPU in the same time, Entitled the equal share of every user.
+	Share, Entitled float64
+}
+
+// userOf is who p belongs to for fair sharing: its User, or a user of its own without one.
+func userOf(p Process) string {
+	if p.User != "" {
+		return p.User
+	}
+	return p.ProcessID
+}
+
+// FairShareSchedule shares the CPU equally between users rather than processes: each quantum of
+// defaultQuantum goes to the user with ready processes who has had the least CPU so far, the one
+// whose processes arrived first on a tie, and the user's processes take turns round-robin. So a
+// user can't take more of the CPU by running more processes. A process without a User is a user of
+// its own. It writes each user's share of the CPU against the equal share they're entitled to.
+func FairShareSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	var (
+		users []string
+		used  = make(map[string]int64)
+	)
+	result, _ := Simulate(processes, func(ready []Process, _ int64) int {
+		// the user with ready processes who had the least CPU, the first to arrive on a tie.
+		user := ""
+		for _, u := range users {
+			if (user == "" || used[u] < used[user]) && slices.ContainsFunc(ready, func(p Process) bool { return userOf(p) == u }) {
+				user = u
+			}
+		}
+		return slices.IndexFunc(ready, func(p Process) bool { return userOf(p) == user })
+	}, SimOptions{
+		Title:   title,
+		Quantum: defaultQuantum,
+		Arrived: func(p Process, _ int64) {
+			if user := userOf(p); !slices.Contains(users, user) {
+				users = append(users, user)
+			}
+		},
+		Ran: func(p Process, start, stop int64) { used[userOf(p)] += stop - start },
+	})
+	result.Notes = noteLines(func(w io.Writer) { outputUserShares(w, UserShares(result)) })
+	outputResult(w, result)
+
+	return result
+}
+
+// GuaranteedSchedule is guaranteed scheduling: every process present is entitled to an equal
+// share of the CPU from its arrival, 1/n of each tick while n processes are present, and the
+// process that has consumed the least of its entitlement so far runs next. A process that has just
+// arrived is owed its whole share and runs first. It re-decides every tick; the running process
+// keeps the CPU on a tie, and other ties go to the earliest arrived.
+func GuaranteedSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	var (
+		running  string
+		present  []string
+		consumed = make(map[string]int64, len(processes))
+		// entitled is exact, a sum of 1/n for every tick n processes were present.
+		entitled = make(map[string]*big.Rat, len(processes))
+	)
+	// compareRatios compares how much of their entitlement a and b have consumed, cross-multiplied
+	// so no rounding decides a tie; a process entitled to nothing yet has consumed none of it.
+	compareRatios := func(a, b Process) int {
+		ea, eb := entitled[a.ProcessID], entitled[b.ProcessID]
+		ca, cb := consumed[a.ProcessID], consumed[b.ProcessID]
+		switch {
+		case ea.Sign() == 0 && eb.Sign() == 0:
+			return 0
+		case ea.Sign() == 0:
+			return -cmp.Compare(cb, 0)
+		case eb.Sign() == 0:
+			return cmp.Compare(ca, 0)
+		}
+		left := new(big.Rat).Mul(new(big.Rat).SetInt64(ca), eb)
+		return left.Cmp(new(big.Rat).Mul(new(big.Rat).SetInt64(cb), ea))
+	}
+	pick := func(ready []Process, _ int64) int {
+		// the lowest ratio, earliest arrived on a tie, unless it's no lower than the running process'.
+		next := 0
+		for i, p := range ready {
+			if compareRatios(p, ready[next]) < 0 {
+				next = i
+			}
+		}
+		if i := readyIndex(ready, running); i >= 0 && compareRatios(ready[next], ready[i]) >= 0 {
+			next = i
+		}
+		running = ready[next].ProcessID
+		return next
+	}
+
+	result, _ := Simulate(processes, pick, SimOptions{
+		Title:      title,
+		Preemptive: true,
+		KeepPlace:  true,
+		Arrived: func(p Process, _ int64) {
+			present = append(present, p.ProcessID)
+			entitled[p.ProcessID] = new(big.Rat)
+		},
+		Ran: func(p Process, start, stop int64) {
+			consumed[p.ProcessID] += stop - start
+			share := big.NewRat(stop-start, int64(len(present)))
+			for _, id := range present {
+				entitled[id].Add(entitled[id], share)
+			}
+		},
+		Left: func(p Process, _ int64) {
+			present = slices.DeleteFunc(present, func(id string) bool { return id == p.ProcessID })
+		},
+	})
+	outputResult(w, result)
+
+	return result
+}
+
+// UserShares is the CPU each user's processes got in result while every user still had work,
+// until the first of them completed their last process, in the order the users first appear among
+// its processes. After that, the users left take all the CPU whatever their share.
+func UserShares(result ScheduleResult) []UserShare {
+	var (
+		shares []UserShare
+		total  int64
+	)
+	index := make(map[string]int)
+	owner := make(map[string]int, len(result.Processes))
+	finish := make(map[string]int64)
+	for _, p := range result.Processes {
+		user := userOf(p.Process)
+		i, ok := index[user]
+		if !ok {
+			i = len(shares)
+			index[user] = i
+			shares = append(shares, UserShare{User: user})
+		}
+		owner[p.ProcessID] = i
+		finish[user] = max(finish[user], p.Completion)
+	}
+	until := int64(math.MaxInt64)
+	for _, f := range finish {
+		until = min(until, f)
+	}
+	for _, slice := range result.Gantt {
+		i, ok := owner[slice.PID]
+		if !ok || slice.Start >= until {
+			continue
+		}
+		ran := min(slice.Stop, until) - slice.Start
+		shares[i].CPU += ran
+		total += ran
+	}
+	for i := range shares {
+		shares[i].Entitled = 1 / float64(len(shares))
+		if total > 0 {
+			shares[i].Share = float64(shares[i].CPU) / float64(total)
+		}
+	}
+
+	return shares
+}
+
+// outputUserShares writes each user's share of the CPU against their entitlement.
+func outputUserShares(w io.Writer, shares []UserShare) {
+	_, _ = fmt.Fprintln(w, "User shares while all users had work")
+	for _, s := range shares {
+		_, _ = fmt.Fprintf(w, "%s: %d CPU, %.1f%% of the CPU, entitled to %.1f%%\n", s.User, s.CPU, 100*s.Share, 100*s.Entitled)
+	}
+}
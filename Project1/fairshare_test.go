@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFairShareSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A1", ArrivalTime: 0, BurstDuration: 8, User: "ana"},
+		{ProcessID: "A2", ArrivalTime: 0, BurstDuration: 8, User: "ana"},
+		{ProcessID: "A3", ArrivalTime: 0, BurstDuration: 8, User: "ana"},
+		{ProcessID: "B", ArrivalTime: 0, BurstDuration: 12, User: "bob"},
+	}
+	var w bytes.Buffer
+	result := FairShareSchedule(&w, "fair share", processes)
+	// ana and bob alternate quanta, however many processes ana runs.
+	want := []TimeSlice{
+		{PID: "A1", Start: 0, Stop: 4},
+		{PID: "B", Start: 4, Stop: 8},
+		{PID: "A2", Start: 8, Stop: 12},
+		{PID: "B", Start: 12, Stop: 16},
+		{PID: "A3", Start: 16, Stop: 20},
+		{PID: "B", Start: 20, Stop: 24},
+		{PID: "A1", Start: 24, Stop: 28},
+		{PID: "A2", Start: 28, Stop: 32},
+		{PID: "A3", Start: 32, Stop: 36},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	wantShares := []UserShare{
+		{User: "ana", CPU: 12, Share: 0.5, Entitled: 0.5},
+		{User: "bob", CPU: 12, Share: 0.5, Entitled: 0.5},
+	}
+	if diff := cmp.Diff(wantShares, UserShares(result)); diff != "" {
+		t.Errorf(diff)
+	}
+	if !strings.Contains(w.String(), "User shares while all users had work\nana: 12 CPU, 50.0% of the CPU, entitled to 50.0%\n") {
+		t.Errorf("output is missing the user shares:\n%s", w.String())
+	}
+
+	// round-robin shares by process, so bob gets one quantum of the 28 ticks ana's three take.
+	wantShares = []UserShare{
+		{User: "ana", CPU: 24, Share: 24.0 / 28, Entitled: 0.5},
+		{User: "bob", CPU: 4, Share: 4.0 / 28, Entitled: 0.5},
+	}
+	if diff := cmp.Diff(wantShares, UserShares(RRSchedule(io.Discard, "rr", processes))); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestUserShares_noUser(t *testing.T) {
+	t.Parallel()
+	// without a User, every process is a user of its own.
+	result := FairShareSchedule(io.Discard, "fair share", []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 4},
+	})
+	want := []UserShare{
+		{User: "P0", CPU: 4, Share: 1, Entitled: 0.5},
+	}
+	if diff := cmp.Diff(want, UserShares(result)[:1]); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestGuaranteedSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 2},
+	}
+	result := GuaranteedSchedule(io.Discard, "guaranteed", processes)
+	// B is owed its share on arrival; at 2 it has consumed 1 of 0.5 and A 1 of 1.5, and at 3 they
+	// tie at 1, so A keeps the CPU.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 1},
+		{PID: "B", Start: 1, Stop: 2},
+		{PID: "A", Start: 2, Stop: 4},
+		{PID: "B", Start: 4, Stop: 5},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+
+	// processes arriving together take turns, the running one keeping the CPU on the ties at 3 and 6.
+	result = GuaranteedSchedule(io.Discard, "guaranteed", []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: "P2", ArrivalTime: 0, BurstDuration: 3},
+	})
+	want = []TimeSlice{
+		{PID: "P0", Start: 0, Stop: 1},
+		{PID: "P1", Start: 1, Stop: 2},
+		{PID: "P2", Start: 2, Stop: 4},
+		{PID: "P0", Start: 4, Stop: 5},
+		{PID: "P1", Start: 5, Stop: 7},
+		{PID: "P0", Start: 7, Stop: 8},
+		{PID: "P2", Start: 8, Stop: 9},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
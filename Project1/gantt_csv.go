@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+var ganttCSVHeader = []string{"PID", "Start", "Stop"}
+
+// OutputGanttCSV writes gantt as "PID,Start,Stop" rows under a header, so a trace can be
+// stored and read back with LoadGanttCSV to analyze later.
+func OutputGanttCSV(w io.Writer, gantt []TimeSlice) {
+	cw := csv.NewWriter(w)
+	_ = cw.Write(ganttCSVHeader)
+	for _, slice := range gantt {
+		_ = cw.Write([]string{
+			slice.PID,
+			strconv.FormatInt(slice.Start, 10),
+			strconv.FormatInt(slice.Stop, 10),
+		})
+	}
+	cw.Flush()
+}
+
+// LoadGanttCSV reads back a gantt written by OutputGanttCSV. Every slice must start before
+// it stops; the slices are otherwise kept as written.
+func LoadGanttCSV(r io.Reader) ([]TimeSlice, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(ganttCSVHeader)
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidGanttCSV, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidGanttCSV)
+	}
+	var gantt []TimeSlice
+	for i, row := range rows[1:] { // skip header row
+		start, startErr := strconv.ParseInt(row[1], 10, 64)
+		stop, stopErr := strconv.ParseInt(row[2], 10, 64)
+		switch {
+		case startErr != nil || stopErr != nil:
+			return nil, fmt.Errorf("%w: row %d: bad time in %q", ErrInvalidGanttCSV, i+2, row)
+		case start >= stop:
+			return nil, fmt.Errorf("%w: row %d: %s starts at %d but stops at %d", ErrInvalidGanttCSV, i+2, row[0], start, stop)
+		}
+		gantt = append(gantt, TimeSlice{PID: row[0], Start: start, Stop: stop})
+	}
+
+	return gantt, nil
+}
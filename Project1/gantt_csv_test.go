@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGanttCSV_roundTrip(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9},
+		{ProcessID: "P2", ArrivalTime: 20, BurstDuration: 6},
+	}
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+	}{
+		{name: "empty"},
+		{name: "fcfs with idle", gantt: FCFSSchedule(io.Discard, "fcfs", processes).Gantt},
+		{name: "rr", gantt: RRSchedule(io.Discard, "rr", processes).Gantt},
+		{name: "quoted PID", gantt: []TimeSlice{{PID: `a,"b"`, Start: 1, Stop: 2}}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			OutputGanttCSV(&w, tt.gantt)
+			got, err := LoadGanttCSV(&w)
+			if err != nil {
+				t.Fatalf("LoadGanttCSV() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.gantt, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestLoadGanttCSV(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		csv     string
+		want    []TimeSlice
+		wantErr error
+	}{
+		{
+			name: "valid",
+			csv:  "PID,Start,Stop\nP0,0,5\nIDLE,5,7\nP1,7,9\n",
+			want: []TimeSlice{{PID: "P0", Start: 0, Stop: 5}, {PID: "IDLE", Start: 5, Stop: 7}, {PID: "P1", Start: 7, Stop: 9}},
+		},
+		{
+			name:    "stop before start",
+			csv:     "PID,Start,Stop\nP0,5,3\n",
+			wantErr: ErrInvalidGanttCSV,
+		},
+		{
+			name:    "zero length",
+			csv:     "PID,Start,Stop\nP0,5,5\n",
+			wantErr: ErrInvalidGanttCSV,
+		},
+		{
+			name:    "bad time",
+			csv:     "PID,Start,Stop\nP0,zero,5\n",
+			wantErr: ErrInvalidGanttCSV,
+		},
+		{
+			name:    "missing column",
+			csv:     "PID,Start,Stop\nP0,0\n",
+			wantErr: ErrInvalidGanttCSV,
+		},
+		{
+			name:    "missing header",
+			wantErr: ErrInvalidGanttCSV,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := LoadGanttCSV(strings.NewReader(tt.csv))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LoadGanttCSV() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Divergence is an interval [Start, Stop) where two schedules run different processes.
+// An idle CPU is "-".
+type Divergence struct {
+	Start, Stop int64
+	A, B        string
+}
+
+// DiffGantts returns every interval where a and b run different processes, merging neighbouring
+// intervals with the same pair. Both gantts must be canonical (see CanonicalizeGantt).
+func DiffGantts(a, b []TimeSlice) []Divergence {
+	var boundaries []int64
+	for _, gantt := range [][]TimeSlice{a, b} {
+		for _, slice := range gantt {
+			boundaries = append(boundaries, slice.Start, slice.Stop)
+		}
+	}
+	slices.Sort(boundaries)
+	boundaries = slices.Compact(boundaries)
+
+	var diffs []Divergence
+	for i := 0; i+1 < len(boundaries); i++ {
+		start, stop := boundaries[i], boundaries[i+1]
+		pidA, pidB := runningAt(a, start), runningAt(b, start)
+		if pidA == pidB {
+			continue
+		}
+		if n := len(diffs); n > 0 && diffs[n-1].Stop == start && diffs[n-1].A == pidA && diffs[n-1].B == pidB {
+			diffs[n-1].Stop = stop
+			continue
+		}
+		diffs = append(diffs, Divergence{Start: start, Stop: stop, A: pidA, B: pidB})
+	}
+
+	return diffs
+}
+
+// runningAt is the PID running at time t in a canonical gantt, or "-" when idle.
+func runningAt(gantt []TimeSlice, t int64) string {
+	i, found := slices.BinarySearchFunc(gantt, t, func(slice TimeSlice, t int64) int { return cmp.Compare(slice.Start, t) })
+	if !found {
+		i--
+	}
+	if i >= 0 && t < gantt[i].Stop {
+		return gantt[i].PID
+	}
+	return "-"
+}
+
+// diffColumns is the widest a gantt diff gets before time units share a column.
+const diffColumns = 80
+
+// OutputGanttDiff draws two gantts proportionally, one above the other on a shared time axis,
+// with a row marking where they diverge ('!' at the first divergence, '^' after it), followed
+// by every divergent interval and their total length.
+func OutputGanttDiff(w io.Writer, nameA string, a []TimeSlice, nameB string, b []TimeSlice) {
+	diffs := DiffGantts(a, b)
+	end := max(Makespan(a), Makespan(b))
+	unit := max((end+diffColumns-1)/diffColumns, 1)
+	columns := int((end + unit - 1) / unit)
+	width := max(len(nameA), len(nameB), len("diff"))
+
+	_, _ = fmt.Fprintf(w, "Gantt diff: %s vs %s\n", nameA, nameB)
+	_, _ = fmt.Fprintf(w, "%-*s |%s|\n", width, nameA, diffRow(a, unit, columns))
+	_, _ = fmt.Fprintf(w, "%-*s |%s|\n", width, nameB, diffRow(b, unit, columns))
+
+	marks := []byte(strings.Repeat(" ", columns))
+	for i, d := range diffs {
+		for c := d.Start / unit; c < (d.Stop+unit-1)/unit; c++ {
+			marks[c] = '^'
+		}
+		if i == 0 {
+			marks[d.Start/unit] = '!'
+		}
+	}
+	_, _ = fmt.Fprintf(w, "%-*s |%s|\n", width, "diff", marks)
+	axisEnd := fmt.Sprint(end)
+	_, _ = fmt.Fprintf(w, "%-*s  0%s%s\n", width, "", strings.Repeat(" ", max(columns-1, 1)), axisEnd)
+	if unit > 1 {
+		_, _ = fmt.Fprintf(w, "(each column is %d time units)\n", unit)
+	}
+	_, _ = fmt.Fprintln(w)
+
+	if len(diffs) == 0 {
+		_, _ = fmt.Fprintln(w, "The schedules are identical.")
+		return
+	}
+	var total int64
+	_, _ = fmt.Fprintf(w, "First divergence at %d\n", diffs[0].Start)
+	for _, d := range diffs {
+		_, _ = fmt.Fprintf(w, "[%d,%d): %s runs %s, %s runs %s\n", d.Start, d.Stop, nameA, d.A, nameB, d.B)
+		total += d.Stop - d.Start
+	}
+	_, _ = fmt.Fprintf(w, "Total divergent time: %d of %d\n", total, end)
+}
+
+// diffRow draws a gantt as one row of columns, each slice labeled at its start and filled with '='.
+func diffRow(gantt []TimeSlice, unit int64, columns int) string {
+	row := []byte(strings.Repeat(" ", columns))
+	for _, slice := range gantt {
+		from, to := int(slice.Start/unit), int((slice.Stop+unit-1)/unit)
+		for c := from; c < to; c++ {
+			row[c] = '='
+		}
+		copy(row[from:to], slice.PID)
+	}
+	return string(row)
+}
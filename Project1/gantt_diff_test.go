@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffGantts(t *testing.T) {
+	t.Parallel()
+	// FCFS and RR agree on A and D; only B and C's middle section differs.
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: "B", ArrivalTime: 2, BurstDuration: 6},
+		{ProcessID: "C", ArrivalTime: 3, BurstDuration: 2},
+		{ProcessID: "D", ArrivalTime: 10, BurstDuration: 3},
+	}
+	fcfsGantt := FCFSSchedule(io.Discard, "fcfs", processes).Gantt
+	rrGantt := RRSchedule(io.Discard, "rr", processes).Gantt
+
+	want := []Divergence{
+		{Start: 6, Stop: 8, A: "B", B: "C"},
+		{Start: 8, Stop: 10, A: "C", B: "B"},
+	}
+	if diff := cmp.Diff(want, DiffGantts(fcfsGantt, rrGantt)); diff != "" {
+		t.Errorf(diff)
+	}
+	if got := DiffGantts(fcfsGantt, fcfsGantt); len(got) != 0 {
+		t.Errorf("DiffGantts() of identical gantts = %v, want none", got)
+	}
+
+	idle := []TimeSlice{{PID: "A", Start: 0, Stop: 2}, {PID: "B", Start: 4, Stop: 6}}
+	busy := []TimeSlice{{PID: "A", Start: 0, Stop: 2}, {PID: "B", Start: 2, Stop: 4}}
+	want = []Divergence{
+		{Start: 2, Stop: 4, A: "-", B: "B"},
+		{Start: 4, Stop: 6, A: "B", B: "-"},
+	}
+	if diff := cmp.Diff(want, DiffGantts(idle, busy)); diff != "" {
+		t.Errorf(diff)
+	}
+
+	var w bytes.Buffer
+	OutputGanttDiff(&w, "fcfs", fcfsGantt, "rr", rrGantt)
+	for _, line := range []string{
+		"diff |      !^^^   |",
+		"First divergence at 6",
+		"Total divergent time: 4 of 13",
+	} {
+		if !strings.Contains(w.String(), line) {
+			t.Errorf("output is missing %q:\n%s", line, w.String())
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// CPUShare is the part of the busy CPU time one process used.
+type CPUShare struct {
+	PID string
+	// Tenths is the share in tenths of a percent. The Tenths of every share sum to exactly 1000.
+	Tenths int64
+}
+
+// CPUShares aggregates the gantt per PID, in order of first appearance, as shares of the busy time.
+// Idle time is excluded, so the shares always sum to 100%. Rounding leftovers go to the shares
+// that were rounded down the most (largest remainder), breaking ties by order of appearance.
+func CPUShares(gantt []TimeSlice) []CPUShare {
+	var (
+		order []string
+		ran   = make(map[string]int64)
+		busy  int64
+	)
+	for _, slice := range gantt {
+		if _, ok := ran[slice.PID]; !ok {
+			order = append(order, slice.PID)
+		}
+		ran[slice.PID] += slice.Stop - slice.Start
+		busy += slice.Stop - slice.Start
+	}
+	if busy == 0 {
+		return nil
+	}
+
+	shares := make([]CPUShare, len(order))
+	remainders := make([]int64, len(order))
+	var given int64
+	for i, pid := range order {
+		shares[i] = CPUShare{PID: pid, Tenths: ran[pid] * 1000 / busy}
+		remainders[i] = ran[pid] * 1000 % busy
+		given += shares[i].Tenths
+	}
+	byRemainder := make([]int, len(order))
+	for i := range byRemainder {
+		byRemainder[i] = i
+	}
+	slices.SortStableFunc(byRemainder, func(a, b int) int {
+		return cmp.Compare(remainders[b], remainders[a])
+	})
+	for _, i := range byRemainder[:1000-given] {
+		shares[i].Tenths++
+	}
+
+	return shares
+}
+
+// OutputGanttPercent writes a gantt-like bar with one segment per process, labeled with its
+// share of the busy CPU time (see CPUShares) instead of start and stop times.
+func OutputGanttPercent(w io.Writer, gantt []TimeSlice) {
+	_, _ = fmt.Fprintln(w, "CPU share")
+	_, _ = fmt.Fprintf(w, "|")
+	for _, share := range CPUShares(gantt) {
+		_, _ = fmt.Fprintf(w, "  %s %s  |", share.PID, share)
+	}
+	_, _ = fmt.Fprintf(w, "\n\n")
+}
+
+// String formats the share as a percentage with one decimal, e.g. "33.4%".
+func (s CPUShare) String() string {
+	return fmt.Sprintf("%d.%d%%", s.Tenths/10, s.Tenths%10)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCPUShares(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  []CPUShare
+	}{
+		{
+			name:  "empty",
+			gantt: nil,
+			want:  nil,
+		},
+		{
+			name: "aggregated per pid",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 2},
+				{PID: "P1", Start: 2, Stop: 5},
+				{PID: "P0", Start: 5, Stop: 8},
+			},
+			want: []CPUShare{{PID: "P0", Tenths: 625}, {PID: "P1", Tenths: 375}},
+		},
+		{
+			name: "thirds round to 100",
+			gantt: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 1},
+				{PID: "B", Start: 1, Stop: 2},
+				{PID: "C", Start: 2, Stop: 3},
+			},
+			want: []CPUShare{{PID: "A", Tenths: 334}, {PID: "B", Tenths: 333}, {PID: "C", Tenths: 333}},
+		},
+		{
+			name: "idle excluded",
+			gantt: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 1},
+				{PID: "B", Start: 5, Stop: 8},
+			},
+			want: []CPUShare{{PID: "A", Tenths: 250}, {PID: "B", Tenths: 750}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := CPUShares(tt.gantt)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf(diff)
+			}
+			var sum int64
+			for _, share := range got {
+				sum += share.Tenths
+			}
+			if len(got) > 0 && sum != 1000 {
+				t.Errorf("shares sum to %d tenths, want 1000", sum)
+			}
+		})
+	}
+}
+
+func TestOutputGanttPercent(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	OutputGanttPercent(&w, []TimeSlice{
+		{PID: "A", Start: 0, Stop: 1},
+		{PID: "B", Start: 1, Stop: 2},
+		{PID: "C", Start: 2, Stop: 3},
+	})
+	want := "|  A 33.4%  |  B 33.3%  |  C 33.3%  |"
+	if !strings.Contains(w.String(), want) {
+		t.Errorf("output = %q, want it to contain %q", w.String(), want)
+	}
+}
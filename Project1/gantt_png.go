@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strings"
+)
+
+// glyphs is a tiny 3x5 bitmap font so PNG labels need nothing beyond the stdlib.
+// Each glyph is five rows of three columns, '#' marking a lit pixel.
+var glyphs = map[rune][5]string{
+	'0': {"###", "#.#", "#.#", "#.#", "###"},
+	'1': {".#.", "##.", ".#.", ".#.", "###"},
+	'2': {"###", "..#", "###", "#..", "###"},
+	'3': {"###", "..#", "###", "..#", "###"},
+	'4': {"#.#", "#.#", "###", "..#", "..#"},
+	'5': {"###", "#..", "###", "..#", "###"},
+	'6': {"###", "#..", "###", "#.#", "###"},
+	'7': {"###", "..#", ".#.", ".#.", ".#."},
+	'8': {"###", "#.#", "###", "#.#", "###"},
+	'9': {"###", "#.#", "###", "..#", "###"},
+	'A': {".#.", "#.#", "###", "#.#", "#.#"},
+	'B': {"##.", "#.#", "##.", "#.#", "##."},
+	'C': {"###", "#..", "#..", "#..", "###"},
+	'D': {"##.", "#.#", "#.#", "#.#", "##."},
+	'E': {"###", "#..", "##.", "#..", "###"},
+	'F': {"###", "#..", "##.", "#..", "#.."},
+	'G': {"###", "#..", "#.#", "#.#", "###"},
+	'H': {"#.#", "#.#", "###", "#.#", "#.#"},
+	'I': {"###", ".#.", ".#.", ".#.", "###"},
+	'J': {"..#", "..#", "..#", "#.#", "###"},
+	'K': {"#.#", "#.#", "##.", "#.#", "#.#"},
+	'L': {"#..", "#..", "#..", "#..", "###"},
+	'M': {"#.#", "###", "###", "#.#", "#.#"},
+	'N': {"##.", "#.#", "#.#", "#.#", "#.#"},
+	'O': {"###", "#.#", "#.#", "#.#", "###"},
+	'P': {"###", "#.#", "###", "#..", "#.."},
+	'Q': {"###", "#.#", "#.#", "###", "..#"},
+	'R': {"##.", "#.#", "##.", "#.#", "#.#"},
+	'S': {"###", "#..", "###", "..#", "###"},
+	'T': {"###", ".#.", ".#.", ".#.", ".#."},
+	'U': {"#.#", "#.#", "#.#", "#.#", "###"},
+	'V': {"#.#", "#.#", "#.#", "#.#", ".#."},
+	'W': {"#.#", "#.#", "###", "###", "#.#"},
+	'X': {"#.#", "#.#", ".#.", "#.#", "#.#"},
+	'Y': {"#.#", "#.#", ".#.", ".#.", ".#."},
+	'Z': {"###", "..#", ".#.", "#..", "###"},
+	'-': {"...", "...", "###", "...", "..."},
+	'_': {"...", "...", "...", "...", "###"},
+}
+
+const (
+	glyphWidth  = 3
+	glyphHeight = 5
+	glyphScale  = 2
+	pngMargin   = 4
+)
+
+// OutputGanttPNG draws the gantt as proportional, labeled bars and encodes it as a PNG to w.
+// Every PID gets a deterministic color; idle time is left as background.
+func OutputGanttPNG(w io.Writer, gantt []TimeSlice, width, height int) error {
+	if width <= 2*pngMargin || height <= 2*pngMargin {
+		return fmt.Errorf("%w: png must be larger than %dx%d", ErrInvalidArgs, 2*pngMargin, 2*pngMargin)
+	}
+
+	gantt, err := CanonicalizeGantt(gantt)
+	if err != nil {
+		return err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+
+	if len(gantt) > 0 {
+		first, last := gantt[0].Start, gantt[0].Stop
+		for _, slice := range gantt {
+			if slice.Start < first {
+				first = slice.Start
+			}
+			if slice.Stop > last {
+				last = slice.Stop
+			}
+		}
+		span := last - first
+		if span == 0 {
+			span = 1
+		}
+
+		barWidth := width - 2*pngMargin
+		scale := func(t int64) int {
+			return pngMargin + int((t-first)*int64(barWidth)/span)
+		}
+		for _, slice := range gantt {
+			bar := image.Rect(scale(slice.Start), pngMargin, scale(slice.Stop), height-pngMargin)
+			draw.Draw(img, bar, image.NewUniform(pidColor(slice.PID)), image.Point{}, draw.Src)
+			// outline the left edge so adjacent slices of similar color stay distinguishable.
+			draw.Draw(img, image.Rect(bar.Min.X, bar.Min.Y, bar.Min.X+1, bar.Max.Y),
+				image.NewUniform(color.Black), image.Point{}, draw.Src)
+			drawLabel(img, bar, slice.PID)
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+// pidColor derives a stable, reasonably saturated color from a PID.
+func pidColor(pid string) color.RGBA {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(pid))
+	sum := h.Sum32()
+	return color.RGBA{
+		R: 64 + uint8(sum)%160,
+		G: 64 + uint8(sum>>8)%160,
+		B: 64 + uint8(sum>>16)%160,
+		A: 0xff,
+	}
+}
+
+// drawLabel centers text within bar, skipping it when it doesn't fit.
+func drawLabel(img draw.Image, bar image.Rectangle, text string) {
+	text = strings.ToUpper(text)
+	textWidth := len(text)*(glyphWidth+1)*glyphScale - glyphScale
+	textHeight := glyphHeight * glyphScale
+	if textWidth > bar.Dx()-2 || textHeight > bar.Dy()-2 {
+		return
+	}
+
+	x := bar.Min.X + (bar.Dx()-textWidth)/2
+	y := bar.Min.Y + (bar.Dy()-textHeight)/2
+	ink := image.NewUniform(color.Black)
+	for _, r := range text {
+		glyph, ok := glyphs[r]
+		if ok {
+			for row, line := range glyph {
+				for col, px := range line {
+					if px != '#' {
+						continue
+					}
+					dot := image.Rect(
+						x+col*glyphScale, y+row*glyphScale,
+						x+(col+1)*glyphScale, y+(row+1)*glyphScale,
+					)
+					draw.Draw(img, dot, ink, image.Point{}, draw.Src)
+				}
+			}
+		}
+		x += (glyphWidth + 1) * glyphScale
+	}
+}
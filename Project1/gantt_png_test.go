@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestOutputGanttPNG(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		gantt         []TimeSlice
+		width, height int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "consecutive processes",
+			args: args{
+				gantt: []TimeSlice{
+					{PID: "P0", Start: 0, Stop: 5},
+					{PID: "P1", Start: 5, Stop: 14},
+					{PID: "P2", Start: 14, Stop: 20},
+				},
+				width:  400,
+				height: 40,
+			},
+		},
+		{
+			name: "idle gap",
+			args: args{
+				gantt: []TimeSlice{
+					{PID: "A", Start: 1, Stop: 2},
+					{PID: "B", Start: 5, Stop: 6},
+				},
+				width:  120,
+				height: 30,
+			},
+		},
+		{
+			name: "too small",
+			args: args{
+				width:  4,
+				height: 4,
+			},
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			err := OutputGanttPNG(&w, tt.args.gantt, tt.args.width, tt.args.height)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			img, err := png.Decode(&w)
+			if err != nil {
+				t.Fatalf("could not decode PNG: %v", err)
+			}
+			if got := img.Bounds().Size(); got.X != tt.args.width || got.Y != tt.args.height {
+				t.Errorf("size = %v, want %dx%d", got, tt.args.width, tt.args.height)
+			}
+
+			blank := true
+			bounds := img.Bounds()
+			for y := bounds.Min.Y; y < bounds.Max.Y && blank; y++ {
+				for x := bounds.Min.X; x < bounds.Max.X; x++ {
+					if color.RGBAModel.Convert(img.At(x, y)) != color.RGBAModel.Convert(color.White) {
+						blank = false
+						break
+					}
+				}
+			}
+			if blank {
+				t.Error("PNG is blank")
+			}
+		})
+	}
+}
+
+func Test_pidColor(t *testing.T) {
+	t.Parallel()
+	if pidColor("P1") != pidColor("P1") {
+		t.Error("color for the same PID is not deterministic")
+	}
+	if pidColor("P1") == pidColor("P2") {
+		t.Error("different PIDs share a color")
+	}
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// StackGantts renders several gantts one above another on a shared time axis scaled to the
+// longest makespan, so the schedules of different algorithms can be compared at a glance. Each
+// gantt is a block of its label over a row of columns, in label order. Idle time is blank.
+func StackGantts(labeled map[string][]TimeSlice) string {
+	labels := make([]string, 0, len(labeled))
+	var end int64
+	for label, gantt := range labeled {
+		labels = append(labels, label)
+		end = max(end, Makespan(gantt))
+	}
+	slices.Sort(labels)
+	unit := max((end+diffColumns-1)/diffColumns, 1)
+	columns := int((end + unit - 1) / unit)
+
+	var b strings.Builder
+	for _, label := range labels {
+		_, _ = fmt.Fprintf(&b, "%s\n|%s|\n", label, diffRow(labeled[label], unit, columns))
+	}
+	_, _ = fmt.Fprintf(&b, " 0%s%d\n", strings.Repeat(" ", max(columns-1, 1)), end)
+	if unit > 1 {
+		_, _ = fmt.Fprintf(&b, "(each column is %d time units)\n", unit)
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStackGantts(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9},
+		{ProcessID: "P2", ArrivalTime: 6, BurstDuration: 6},
+	}
+	got := StackGantts(map[string][]TimeSlice{
+		"sjf":  SJFSchedule(io.Discard, "sjf", processes).Gantt,
+		"fcfs": FCFSSchedule(io.Discard, "fcfs", processes).Gantt,
+	})
+
+	want := "fcfs\n" +
+		"|P0===P1=======P2====|\n" +
+		"sjf\n" +
+		"|P0===PP2====P1======|\n" +
+		" 0                   20\n"
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf(diff)
+	}
+
+	lines := strings.Split(got, "\n")
+	if len(lines[1]) != len(lines[3]) {
+		t.Errorf("rows have different widths: %q and %q", lines[1], lines[3])
+	}
+}
+
+func TestStackGantts_scaled(t *testing.T) {
+	t.Parallel()
+	got := StackGantts(map[string][]TimeSlice{
+		"long":  {{PID: "A", Start: 0, Stop: 400}},
+		"short": {{PID: "B", Start: 0, Stop: 100}},
+	})
+	lines := strings.Split(got, "\n")
+	if want := "|A" + strings.Repeat("=", diffColumns-1) + "|"; lines[1] != want {
+		t.Errorf("long row = %q, want %q", lines[1], want)
+	}
+	if want := "|B" + strings.Repeat("=", 19) + strings.Repeat(" ", 60) + "|"; lines[3] != want {
+		t.Errorf("short row = %q, want %q", lines[3], want)
+	}
+	if !strings.Contains(got, "(each column is 5 time units)") {
+		t.Errorf("missing the column unit in %q", got)
+	}
+}
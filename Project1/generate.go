@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand/v2"
+	"strconv"
+)
+
+// Burst distributions of a WorkloadSpec, for the generate -burst-dist.
+const (
+	BurstExponential = "exponential"
+	BurstUniform     = "uniform"
+	BurstNormal      = "normal"
+	BurstPareto      = "pareto"
+)
+
+// WorkloadSpec describes a synthetic workload for GenerateWorkload: N processes arriving as a
+// Poisson process, with bursts drawn from one of the Burst distributions.
+type WorkloadSpec struct {
+	N int
+	// ArrivalRate is the mean number of arrivals per tick, the gaps between them being
+	// exponential with mean 1/ArrivalRate.
+	ArrivalRate float64
+	// Burst is the burst distribution.
+	Burst string
+	// BurstMean is the mean of exponential and normal bursts, BurstStdDev the standard deviation
+	// of normal ones.
+	BurstMean   float64
+	BurstStdDev float64
+	// BurstMin and BurstMax bound uniform bursts. BurstMin is also the scale of Pareto bursts,
+	// their smallest value, and ParetoShape their shape α: the smaller, the heavier the tail.
+	BurstMin    float64
+	BurstMax    float64
+	ParetoShape float64
+	// Seed seeds the draws, so the same spec always gives the same workload.
+	Seed uint64
+}
+
+// GenerateWorkload draws the processes spec describes, named P0, P1... in arrival order. Times
+// are rounded to whole ticks, and bursts that round below 1 are raised to 1; clamped is how many
+// were, as they skew the distribution.
+func GenerateWorkload(spec WorkloadSpec) (processes []Process, clamped int, err error) {
+	if spec.N < 1 {
+		return nil, 0, fmt.Errorf("%w: a workload needs at least 1 process, got %d", ErrInvalidArgs, spec.N)
+	}
+	if spec.ArrivalRate <= 0 {
+		return nil, 0, fmt.Errorf("%w: arrival rate %g is not positive", ErrInvalidArgs, spec.ArrivalRate)
+	}
+	rng := rand.New(rand.NewPCG(spec.Seed, spec.Seed))
+	var draw func() float64
+	switch spec.Burst {
+	case BurstExponential:
+		if spec.BurstMean <= 0 {
+			return nil, 0, fmt.Errorf("%w: exponential burst mean %g is not positive", ErrInvalidArgs, spec.BurstMean)
+		}
+		draw = func() float64 { return spec.BurstMean * rng.ExpFloat64() }
+	case BurstUniform:
+		if spec.BurstMin < 0 || spec.BurstMax < spec.BurstMin {
+			return nil, 0, fmt.Errorf("%w: uniform bursts from %g to %g", ErrInvalidArgs, spec.BurstMin, spec.BurstMax)
+		}
+		draw = func() float64 { return spec.BurstMin + (spec.BurstMax-spec.BurstMin)*rng.Float64() }
+	case BurstNormal:
+		if spec.BurstMean <= 0 || spec.BurstStdDev < 0 {
+			return nil, 0, fmt.Errorf("%w: normal bursts of mean %g and standard deviation %g", ErrInvalidArgs, spec.BurstMean, spec.BurstStdDev)
+		}
+		draw = func() float64 { return spec.BurstMean + spec.BurstStdDev*rng.NormFloat64() }
+	case BurstPareto:
+		if spec.BurstMin <= 0 || spec.ParetoShape <= 0 {
+			return nil, 0, fmt.Errorf("%w: Pareto bursts of scale %g and shape %g", ErrInvalidArgs, spec.BurstMin, spec.ParetoShape)
+		}
+		// inverse transform sampling; 1-Float64() is never 0.
+		draw = func() float64 { return spec.BurstMin / math.Pow(1-rng.Float64(), 1/spec.ParetoShape) }
+	default:
+		return nil, 0, fmt.Errorf("%w: unknown burst distribution %q", ErrInvalidArgs, spec.Burst)
+	}
+
+	processes = make([]Process, spec.N)
+	var arrival float64
+	for i := range processes {
+		arrival += rng.ExpFloat64() / spec.ArrivalRate
+		burst := int64(math.Round(draw()))
+		if burst < 1 {
+			burst = 1
+			clamped++
+		}
+		processes[i] = Process{
+			ProcessID:     fmt.Sprintf("P%d", i),
+			ArrivalTime:   int64(math.Round(arrival)),
+			BurstDuration: burst,
+		}
+	}
+
+	return processes, clamped, nil
+}
+
+// WriteProcessesCSV writes processes as a process file loadProcesses reads back, with the ID,
+// burst, arrival and priority columns.
+func WriteProcessesCSV(w io.Writer, processes []Process) error {
+	cw := csv.NewWriter(w)
+	_ = cw.Write([]string{"ProcessID", "Burst Duration", "Arrival Time", "Priority"})
+	for _, p := range processes {
+		_ = cw.Write([]string{
+			p.ProcessID,
+			strconv.FormatInt(p.BurstDuration, 10),
+			strconv.FormatInt(p.ArrivalTime, 10),
+			strconv.FormatInt(p.Priority, 10),
+		})
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// runGenerate is the generate subcommand: it writes the synthetic workload its flags in args
+// describe to stdout as CSV, warning on stderr when bursts were clamped, and returns the exit
+// code. Its output can be piped into a scheduler run, e.g.
+// "scheduler generate -n 20 | scheduler -rr".
+func runGenerate(name string, args []string, stdout, stderr io.Writer) int {
+	var (
+		spec   WorkloadSpec
+		strict bool
+	)
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+	flagSet.SetOutput(stderr)
+	flagSet.IntVar(&spec.N, "n", 0, "Number of processes to generate")
+	flagSet.Float64Var(&spec.ArrivalRate, "arrival-rate", 0.1, "Mean arrivals per tick of the Poisson arrivals")
+	flagSet.StringVar(&spec.Burst, "burst-dist", BurstExponential, `Burst distribution: "exponential", "uniform", "normal" or "pareto"`)
+	flagSet.Float64Var(&spec.BurstMean, "burst-mean", 10, "Mean of exponential and normal bursts")
+	flagSet.Float64Var(&spec.BurstStdDev, "burst-stddev", 3, "Standard deviation of normal bursts")
+	flagSet.Float64Var(&spec.BurstMin, "burst-min", 1, "Smallest uniform burst, and the scale of Pareto ones")
+	flagSet.Float64Var(&spec.BurstMax, "burst-max", 20, "Largest uniform burst")
+	flagSet.Float64Var(&spec.ParetoShape, "pareto-shape", 2, "Shape of Pareto bursts; smaller is heavier-tailed")
+	flagSet.Uint64Var(&spec.Seed, "seed", 1, "Seed for the arrival and burst draws")
+	flagSet.BoolVar(&strict, "strict", false, "Fail instead of warning when bursts below 1 tick are raised to 1")
+	if err := flagSet.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 1
+	}
+	if flagSet.NArg() > 0 {
+		_, _ = fmt.Fprintf(stderr, "error: unexpected arguments %q\n", flagSet.Args())
+		return 1
+	}
+
+	processes, clamped, err := GenerateWorkload(spec)
+	if err == nil && clamped > 0 {
+		clampErr := fmt.Errorf("%d of %d bursts were drawn below 1 tick and raised to 1", clamped, spec.N)
+		if strict {
+			err = fmt.Errorf("%w: %v", ErrInvalidArgs, clampErr)
+		} else {
+			_, _ = fmt.Fprintln(stderr, "warning:", clampErr)
+		}
+	}
+	if err == nil {
+		err = WriteProcessesCSV(stdout, processes)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "error:", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenerateWorkload(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		spec      WorkloadSpec
+		wantMean  float64
+		wantFloor int64
+	}{
+		{
+			name:     "exponential",
+			spec:     WorkloadSpec{Burst: BurstExponential, BurstMean: 20},
+			wantMean: 20,
+		},
+		{
+			name:      "uniform",
+			spec:      WorkloadSpec{Burst: BurstUniform, BurstMin: 10, BurstMax: 30},
+			wantMean:  20,
+			wantFloor: 10,
+		},
+		{
+			name:     "normal",
+			spec:     WorkloadSpec{Burst: BurstNormal, BurstMean: 20, BurstStdDev: 4},
+			wantMean: 20,
+		},
+		{
+			// the mean of a Pareto distribution is scale*shape/(shape-1).
+			name:      "pareto",
+			spec:      WorkloadSpec{Burst: BurstPareto, BurstMin: 10, ParetoShape: 3},
+			wantMean:  15,
+			wantFloor: 10,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tt.spec.N, tt.spec.ArrivalRate, tt.spec.Seed = 20000, 0.05, 7
+			processes, _, err := GenerateWorkload(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(processes) != tt.spec.N {
+				t.Fatalf("GenerateWorkload() gave %d processes, want %d", len(processes), tt.spec.N)
+			}
+			var bursts int64
+			for i, p := range processes {
+				if p.BurstDuration < max(tt.wantFloor, 1) {
+					t.Fatalf("%s burst %d is below %d", p.ProcessID, p.BurstDuration, max(tt.wantFloor, 1))
+				}
+				if i > 0 && p.ArrivalTime < processes[i-1].ArrivalTime {
+					t.Fatalf("%s arrives at %d, before %s", p.ProcessID, p.ArrivalTime, processes[i-1].ProcessID)
+				}
+				bursts += p.BurstDuration
+			}
+			if mean := float64(bursts) / float64(len(processes)); math.Abs(mean-tt.wantMean) > 0.05*tt.wantMean {
+				t.Errorf("mean burst = %.2f, want about %g", mean, tt.wantMean)
+			}
+			// arrivals are a Poisson process of rate 0.05, so 20000 take about 400000 ticks.
+			if last := processes[len(processes)-1].ArrivalTime; math.Abs(float64(last)-400000) > 0.05*400000 {
+				t.Errorf("last arrival = %d, want about 400000", last)
+			}
+
+			again, _, _ := GenerateWorkload(tt.spec)
+			if diff := cmp.Diff(processes, again); diff != "" {
+				t.Errorf("the same seed gave a different workload:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGenerateWorkload_invalid(t *testing.T) {
+	t.Parallel()
+	valid := WorkloadSpec{N: 10, ArrivalRate: 0.1, Burst: BurstExponential, BurstMean: 10}
+	tests := []struct {
+		name   string
+		modify func(*WorkloadSpec)
+	}{
+		{name: "no processes", modify: func(s *WorkloadSpec) { s.N = 0 }},
+		{name: "zero arrival rate", modify: func(s *WorkloadSpec) { s.ArrivalRate = 0 }},
+		{name: "unknown distribution", modify: func(s *WorkloadSpec) { s.Burst = "weibull" }},
+		{name: "exponential without a mean", modify: func(s *WorkloadSpec) { s.BurstMean = 0 }},
+		{name: "uniform upside down", modify: func(s *WorkloadSpec) { s.Burst, s.BurstMin, s.BurstMax = BurstUniform, 5, 2 }},
+		{name: "normal with a negative deviation", modify: func(s *WorkloadSpec) { s.Burst, s.BurstStdDev = BurstNormal, -1 }},
+		{name: "pareto without a shape", modify: func(s *WorkloadSpec) { s.Burst, s.BurstMin = BurstPareto, 1 }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			spec := valid
+			tt.modify(&spec)
+			if _, _, err := GenerateWorkload(spec); !errors.Is(err, ErrInvalidArgs) {
+				t.Errorf("GenerateWorkload() error = %v, want %v", err, ErrInvalidArgs)
+			}
+		})
+	}
+}
+
+func TestWriteProcessesCSV(t *testing.T) {
+	t.Parallel()
+	processes, _, err := GenerateWorkload(WorkloadSpec{N: 50, ArrivalRate: 0.2, Burst: BurstUniform, BurstMin: 1, BurstMax: 9, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := WriteProcessesCSV(&b, processes); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadProcesses(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(processes, got); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestGenerateWorkload_clamped(t *testing.T) {
+	t.Parallel()
+	// a normal distribution of mean 2 and deviation 2 draws about a fifth of its bursts below 0.5.
+	processes, clamped, err := GenerateWorkload(WorkloadSpec{N: 1000, ArrivalRate: 0.1, Burst: BurstNormal, BurstMean: 2, BurstStdDev: 2, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ones int
+	for _, p := range processes {
+		if p.BurstDuration == 1 {
+			ones++
+		}
+	}
+	if clamped < 150 || clamped > 250 || clamped >= ones {
+		t.Errorf("GenerateWorkload() clamped %d bursts, of %d of 1, want about 200", clamped, ones)
+	}
+}
+
+func TestRunGenerate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantLines  int
+		wantStderr string
+	}{
+		{
+			name:      "pareto",
+			args:      []string{"generate", "-n", "3", "-burst-dist", "pareto", "-seed", "2"},
+			wantLines: 4,
+		},
+		{
+			name:       "clamped bursts",
+			args:       []string{"generate", "-n", "100", "-burst-dist", "normal", "-burst-mean", "1", "-burst-stddev", "2"},
+			wantLines:  101,
+			wantStderr: "warning: ",
+		},
+		{
+			name:       "clamped bursts with -strict",
+			args:       []string{"generate", "-n", "100", "-burst-dist", "normal", "-burst-mean", "1", "-burst-stddev", "2", "-strict"},
+			wantCode:   1,
+			wantStderr: "bursts were drawn below 1 tick and raised to 1",
+		},
+		{
+			name:       "scheduler flag",
+			args:       []string{"generate", "-n", "3", "-fcfs"},
+			wantCode:   1,
+			wantStderr: "flag provided but not defined: -fcfs",
+		},
+		{
+			name:       "unknown distribution",
+			args:       []string{"generate", "-n", "3", "-burst-dist", "weibull"},
+			wantCode:   1,
+			wantStderr: `unknown burst distribution "weibull"`,
+		},
+		{
+			name:       "no processes",
+			args:       []string{"generate"},
+			wantCode:   1,
+			wantStderr: "a workload needs at least 1 process",
+		},
+		{
+			name:       "generator flags without generate",
+			args:       []string{"-fcfs", "-burst-dist", "pareto", "-"},
+			wantCode:   1,
+			wantStderr: "flag provided but not defined: -burst-dist",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var stdout, stderr bytes.Buffer
+			if code := run("scheduler", tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Fatalf("run() = %d, want %d; stdout:\n%s\nstderr:\n%s", code, tt.wantCode, stdout.String(), stderr.String())
+			}
+			if !strings.Contains(stderr.String()+stdout.String(), tt.wantStderr) {
+				t.Errorf("output is missing %q; stdout:\n%s\nstderr:\n%s", tt.wantStderr, stdout.String(), stderr.String())
+			}
+			if tt.wantLines == 0 {
+				return
+			}
+			if lines := strings.Split(strings.TrimSpace(stdout.String()), "\n"); len(lines) != tt.wantLines || lines[0] != "ProcessID,Burst Duration,Arrival Time,Priority" {
+				t.Errorf("generate wrote:\n%s", stdout.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var ErrSuspiciousWorkload = errors.New("suspicious workload")
+
+// workloadCheck inspects a parsed workload and the selected options, returning a warning
+// message when something is legal but most likely a mistake.
+type workloadCheck func(processes []Process, scheduler Scheduler, quantum int64) (string, bool)
+
+// workloadChecks are run in order by WorkloadWarnings; append new heuristics here.
+var workloadChecks = []workloadCheck{
+	checkSingleProcess,
+	checkLateIdenticalArrivals,
+	checkQuantumExceedsBursts,
+	checkEqualPriorities,
+}
+
+// WorkloadWarnings runs every heuristic and returns the messages of those that fired.
+func WorkloadWarnings(processes []Process, scheduler Scheduler, quantum int64) []string {
+	var warnings []string
+	for _, check := range workloadChecks {
+		if msg, ok := check(processes, scheduler, quantum); ok {
+			warnings = append(warnings, msg)
+		}
+	}
+
+	return warnings
+}
+
+// checkWorkload prints every warning to w and returns how many there were.
+// In strict mode any warning is an error instead.
+func checkWorkload(w io.Writer, processes []Process, scheduler Scheduler, quantum int64, strict bool) (int, error) {
+	warnings := WorkloadWarnings(processes, scheduler, quantum)
+	if strict && len(warnings) > 0 {
+		return 0, fmt.Errorf("%w: %s", ErrSuspiciousWorkload, strings.Join(warnings, "; "))
+	}
+	for _, msg := range warnings {
+		_, _ = fmt.Fprintln(w, "warning:", msg)
+	}
+
+	return len(warnings), nil
+}
+
+func checkSingleProcess(processes []Process, _ Scheduler, _ int64) (string, bool) {
+	if len(processes) != 1 {
+		return "", false
+	}
+	return "only one process given, every scheduler produces the same schedule", true
+}
+
+func checkLateIdenticalArrivals(processes []Process, _ Scheduler, _ int64) (string, bool) {
+	if len(processes) < 2 || processes[0].ArrivalTime == 0 {
+		return "", false
+	}
+	for _, p := range processes[1:] {
+		if p.ArrivalTime != processes[0].ArrivalTime {
+			return "", false
+		}
+	}
+	return fmt.Sprintf("all processes arrive at %d, the CPU idles until then; were arrival and burst columns swapped?",
+		processes[0].ArrivalTime), true
+}
+
+func checkQuantumExceedsBursts(processes []Process, scheduler Scheduler, quantum int64) (string, bool) {
+	if scheduler != rr || len(processes) == 0 {
+		return "", false
+	}
+	for _, p := range processes {
+		if p.BurstDuration > quantum {
+			return "", false
+		}
+	}
+	return fmt.Sprintf("round-robin quantum %d is at least every burst, so it behaves exactly like FCFS", quantum), true
+}
+
+func checkEqualPriorities(processes []Process, scheduler Scheduler, _ int64) (string, bool) {
+	if scheduler != sjfp || len(processes) < 2 {
+		return "", false
+	}
+	for _, p := range processes[1:] {
+		if p.Priority != processes[0].Priority {
+			return "", false
+		}
+	}
+	return fmt.Sprintf("priority scheduler selected but all priorities are %d", processes[0].Priority), true
+}
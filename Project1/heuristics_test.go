@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWorkloadWarnings(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []Process
+		scheduler Scheduler
+		quantum   int64
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "nothing suspicious",
+			args: args{
+				processes: []Process{
+					{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+					{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+				},
+				scheduler: sjfp,
+				quantum:   defaultQuantum,
+			},
+		},
+		{
+			name: "single process",
+			args: args{
+				processes: []Process{{ProcessID: "P0", BurstDuration: 5}},
+				scheduler: fcfs,
+			},
+			want: []string{"only one process given, every scheduler produces the same schedule"},
+		},
+		{
+			name: "identical late arrivals",
+			args: args{
+				processes: []Process{
+					{ProcessID: "P0", ArrivalTime: 500, BurstDuration: 5},
+					{ProcessID: "P1", ArrivalTime: 500, BurstDuration: 9},
+				},
+				scheduler: fcfs,
+			},
+			want: []string{"all processes arrive at 500, the CPU idles until then; were arrival and burst columns swapped?"},
+		},
+		{
+			name: "quantum exceeds bursts",
+			args: args{
+				processes: []Process{
+					{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 1},
+					{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 1},
+				},
+				scheduler: rr,
+				quantum:   1000,
+			},
+			want: []string{"round-robin quantum 1000 is at least every burst, so it behaves exactly like FCFS"},
+		},
+		{
+			name: "equal priorities",
+			args: args{
+				processes: []Process{
+					{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5},
+					{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 9},
+				},
+				scheduler: sjfp,
+			},
+			want: []string{"priority scheduler selected but all priorities are 0"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := WorkloadWarnings(tt.args.processes, tt.args.scheduler, tt.args.quantum)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func Test_checkWorkload(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: "P0", BurstDuration: 5}}
+	tests := []struct {
+		name    string
+		strict  bool
+		wantW   string
+		wantErr error
+	}{
+		{
+			name:  "non-strict warns",
+			wantW: "warning: only one process given, every scheduler produces the same schedule\n",
+		},
+		{
+			name:    "strict fails",
+			strict:  true,
+			wantErr: ErrSuspiciousWorkload,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			_, err := checkWorkload(&w, processes, fcfs, defaultQuantum, tt.strict)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "only one process") {
+				t.Errorf("error %q does not name the warning", err)
+			}
+			if diff := cmp.Diff(tt.wantW, w.String()); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
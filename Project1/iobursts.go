@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IOBurst is I/O a process blocks on once it has run After ticks of CPU in all, for Duration
+// ticks, after which it's ready again.
+type IOBurst struct {
+	After    int64 `json:"after"`
+	Duration int64 `json:"duration"`
+}
+
+// parseIOBursts reads I/O bursts written as "<after>:<duration>", like "3:4;7:2" for 4 ticks of
+// I/O after 3 ticks of CPU and 2 more after 7. An empty string has none.
+func parseIOBursts(s string) ([]IOBurst, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var bursts []IOBurst
+	for _, field := range strings.Split(s, ";") {
+		after, duration, ok := strings.Cut(strings.TrimSpace(field), ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: I/O burst %q is not <after>:<duration>", ErrInvalidArgs, field)
+		}
+		var (
+			b   IOBurst
+			err error
+		)
+		if b.After, err = strconv.ParseInt(after, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: I/O burst %q: %v", ErrInvalidArgs, field, err)
+		}
+		if b.Duration, err = strconv.ParseInt(duration, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: I/O burst %q: %v", ErrInvalidArgs, field, err)
+		}
+		bursts = append(bursts, b)
+	}
+
+	return bursts, nil
+}
+
+// formatIOBursts writes bursts as parseIOBursts reads them.
+func formatIOBursts(bursts []IOBurst) string {
+	fields := make([]string, len(bursts))
+	for i, b := range bursts {
+		fields[i] = fmt.Sprintf("%d:%d", b.After, b.Duration)
+	}
+	return strings.Join(fields, ";")
+}
+
+// checkIOBursts rejects I/O bursts that aren't in order of After, strictly inside p's burst, or
+// that take no time.
+func (p Process) checkIOBursts() error {
+	var after int64
+	for _, b := range p.IOBursts {
+		if b.After <= after || b.After >= p.BurstDuration || b.Duration < 1 {
+			return fmt.Errorf("%w: process %q has I/O bursts %s, which must last at least a tick each, in order, after 0 and before its burst %d",
+				ErrInvalidArgs, p.ProcessID, formatIOBursts(p.IOBursts), p.BurstDuration)
+		}
+		after = b.After
+	}
+	return nil
+}
+
+// cpuBeforeIO is how long p can run, at most limit, before it blocks on I/O, having run for used.
+func (p Process) cpuBeforeIO(used, limit int64) int64 {
+	for _, b := range p.IOBursts {
+		if b.After > used {
+			return min(limit, b.After-used)
+		}
+	}
+	return limit
+}
+
+// ioAfter is how long the I/O p blocks on once it has run for used lasts, 0 when it doesn't block.
+func (p Process) ioAfter(used int64) int64 {
+	for _, b := range p.IOBursts {
+		if b.After == used {
+			return b.Duration
+		}
+	}
+	return 0
+}
+
+// ioWindows are the windows p spent blocked on I/O in gantt, each from the end of the slice in
+// which it had run for the After of one of its IOBursts.
+func (p Process) ioWindows(gantt []TimeSlice) []Window {
+	var (
+		windows []Window
+		ran     int64
+		next    int
+	)
+	for _, slice := range gantt {
+		if slice.PID != p.ProcessID {
+			continue
+		}
+		ran += slice.Stop - slice.Start
+		for ; next < len(p.IOBursts) && p.IOBursts[next].After <= ran; next++ {
+			start := slice.Stop - (ran - p.IOBursts[next].After)
+			windows = append(windows, Window{Start: start, Stop: start + p.IOBursts[next].Duration})
+		}
+	}
+	return windows
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseIOBursts(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    []IOBurst
+		wantErr error
+	}{
+		{name: "none", s: "", want: nil},
+		{name: "one", s: "3:4", want: []IOBurst{{After: 3, Duration: 4}}},
+		{name: "several", s: "3:4; 7:2", want: []IOBurst{{After: 3, Duration: 4}, {After: 7, Duration: 2}}},
+		{name: "missing duration", s: "3", wantErr: ErrInvalidArgs},
+		{name: "not a number", s: "a:b", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseIOBursts(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseIOBursts() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
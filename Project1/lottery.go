@@ -0,0 +1,54 @@
+package main
+
+import (
+	"io"
+	"math/rand/v2"
+)
+
+// LotterySchedule is lottery scheduling: every defaultQuantum the ready processes hold a drawing,
+// each with its Priority as its ticket count, and the winner runs for the quantum. A process with
+// fewer than one ticket holds one, so none can starve outright. The drawings are seeded, so the
+// same seed always gives the same schedule.
+func LotterySchedule(w io.Writer, title string, processes []Process, seed uint64) ScheduleResult {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	result, _ := Simulate(processes, func(ready []Process, _ int64) int {
+		return drawTicket(ready, rng)
+	}, SimOptions{Title: title, Quantum: defaultQuantum})
+	outputResult(w, result)
+
+	return result
+}
+
+// RandomSchedule dispatches a uniformly random ready process every defaultQuantum, a baseline to
+// measure how much better the other schedulers do on the same workload. The draws are seeded, so
+// the same seed always gives the same schedule.
+func RandomSchedule(w io.Writer, title string, processes []Process, seed uint64) ScheduleResult {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	result, _ := Simulate(processes, func(ready []Process, _ int64) int {
+		return rng.IntN(len(ready))
+	}, SimOptions{Title: title, Quantum: defaultQuantum})
+	outputResult(w, result)
+
+	return result
+}
+
+// drawTicket is the Simulate pick of lottery scheduling: the index of the ready process holding
+// a ticket drawn uniformly from all of theirs.
+func drawTicket(ready []Process, rng *rand.Rand) int {
+	var total int64
+	for _, p := range ready {
+		total += tickets(p)
+	}
+	winner := rng.Int64N(total)
+	for i, p := range ready {
+		if winner -= tickets(p); winner < 0 {
+			return i
+		}
+	}
+	return len(ready) - 1
+}
+
+// tickets is the lottery tickets p holds: its Priority, but at least one.
+func tickets(p Process) int64 {
+	return max(p.Priority, 1)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLotterySchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "rich", ArrivalTime: 0, BurstDuration: 100, Priority: 9},
+		{ProcessID: "poor", ArrivalTime: 0, BurstDuration: 100, Priority: 1},
+		{ProcessID: "none", ArrivalTime: 5, BurstDuration: 10},
+	}
+	result := LotterySchedule(io.Discard, "lottery", processes, 42)
+	if diff := cmp.Diff(result, LotterySchedule(io.Discard, "lottery", processes, 42)); diff != "" {
+		t.Errorf("same seed, different schedule: %s", diff)
+	}
+	if diff := cmp.Diff(result.Gantt, LotterySchedule(io.Discard, "lottery", processes, 43).Gantt); diff == "" {
+		t.Errorf("seeds 42 and 43 drew the same schedule")
+	}
+
+	ran := make(map[string]int64)
+	completion := make(map[string]int64)
+	for _, slice := range result.Gantt {
+		ran[slice.PID] += slice.Stop - slice.Start
+		completion[slice.PID] = slice.Stop
+	}
+	// every process runs its whole burst, the one without tickets included.
+	if diff := cmp.Diff(map[string]int64{"rich": 100, "poor": 100, "none": 10}, ran); diff != "" {
+		t.Errorf(diff)
+	}
+	// nine times the tickets wins the CPU far more often.
+	if completion["rich"] >= completion["poor"] {
+		t.Errorf("rich completes at %d, want before poor at %d", completion["rich"], completion["poor"])
+	}
+}
+
+func TestLotterySchedule_singleProcessAlwaysWins(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: "A", ArrivalTime: 2, BurstDuration: 7, Priority: 3}}
+	want := []TimeSlice{{PID: "A", Start: 2, Stop: 9}}
+	if diff := cmp.Diff(want, LotterySchedule(io.Discard, "lottery", processes, 1).Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestRandomSchedule(t *testing.T) {
+	t.Parallel()
+	processes := exponentialWorkload(100, 10, 8, 10)
+	result := RandomSchedule(io.Discard, "random", processes, 7)
+	if diff := cmp.Diff(result, RandomSchedule(io.Discard, "random", processes, 7)); diff != "" {
+		t.Errorf("same seed, different schedule: %s", diff)
+	}
+	if diff := cmp.Diff(result.Gantt, RandomSchedule(io.Discard, "random", processes, 8).Gantt); diff == "" {
+		t.Errorf("seeds 7 and 8 drew the same schedule")
+	}
+
+	ran := make(map[string]int64)
+	for _, slice := range result.Gantt {
+		ran[slice.PID] += slice.Stop - slice.Start
+	}
+	for _, p := range processes {
+		if ran[p.ProcessID] != p.BurstDuration {
+			t.Errorf("%s ran %d, want its burst of %d", p.ProcessID, ran[p.ProcessID], p.BurstDuration)
+		}
+	}
+	// a random order waits longer on average than shortest job first.
+	if sjf := SJFSchedule(io.Discard, "sjf", processes); sjf.AverageWait >= result.AverageWait {
+		t.Errorf("SJF average wait %.2f, want less than random's %.2f", sjf.AverageWait, result.AverageWait)
+	}
+}
@@ -1,46 +1,280 @@
 package main
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 )
 
 func main() {
-	// parse args.
-	flagSet := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
-	scheduler, data, err := parseCLI(flagSet, os.Args[1:])
+	os.Exit(run(os.Args[0], os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// runSummary counts what a run did, for the RESULT line that ends it on stderr.
+type runSummary struct {
+	schedulers int
+	processes  int
+	warnings   int
+}
+
+// run is the whole command: it parses args, writes the schedule to stdout and warnings and
+// errors to stderr, and returns the exit code. Unless -quiet is given, its last line on stderr
+// is a RESULT summary for scripts, even when the run fails. Args starting with "generate" are the
+// generate subcommand instead, see runGenerate.
+func run(name string, args []string, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "generate" {
+		return runGenerate(name+" generate", args[1:], stdout, stderr)
+	}
+	start := time.Now()
+	var summary runSummary
+
+	// parse args, reporting any error once below.
+	flagSet := flag.NewFlagSet(name, flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+	cfg, err := parseCLI(flagSet, args)
+	flagSet.SetOutput(stderr)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		flagSet.Usage()
+		return 0
+	case err != nil:
+		_, _ = fmt.Fprintln(stdout, err)
+		flagSet.Usage()
+	default:
+		if err = runConfig(flagSet, cfg, stdout, stderr, &summary); err != nil {
+			_, _ = fmt.Fprintln(stderr, "error:", err)
+		}
+	}
+
+	var (
+		errs    int
+		batched batchErrors
+	)
+	if errors.As(err, &batched) {
+		// a dry run reports every bad batch line at once.
+		errs = len(batched)
+	} else if err != nil {
+		errs = 1
+	}
+	if !cfg.quiet {
+		_, _ = fmt.Fprintf(stderr, "RESULT algos=%d procs=%d warnings=%d errors=%d elapsed=%dms\n",
+			summary.schedulers, summary.processes, summary.warnings, errs, time.Since(start).Milliseconds())
+	}
 	if err != nil {
-		_, _ = fmt.Fprintln(os.Stdout, err)
-		flagSet.PrintDefaults()
-		os.Exit(1)
+		return 1
 	}
+	return 0
+}
 
-	// Load and parse processes.
-	processes, err := loadProcesses(data)
+// runConfig does what the parsed command line cfg asks, counting into summary as it goes.
+func runConfig(flagSet *flag.FlagSet, cfg cliConfig, stdout, stderr io.Writer, summary *runSummary) error {
+	summary.warnings += len(cfg.warnings)
+	for _, warning := range cfg.warnings {
+		_, _ = fmt.Fprintln(stderr, "warning:", warning)
+	}
+
+	// Run every line of a batch script instead of a single scheduler.
+	if cfg.batchPath != "" && cfg.dryRun {
+		plans, err := planBatchFile(cfg.batchPath)
+		if err != nil {
+			return err
+		}
+		outputPlan(stdout, plans)
+		return nil
+	}
+	if cfg.batchPath != "" {
+		return runBatchFile(stdout, cfg.batchPath, summary)
+	}
+
+	// Run everything a scenario file describes.
+	if cfg.scenarioPath != "" {
+		return runScenarioFile(stdout, cfg.scenarioPath, summary)
+	}
+
+	// Re-render a saved result without simulating again.
+	if cfg.loadPath != "" {
+		result, err := loadResultFile(cfg.loadPath)
+		if err != nil {
+			return err
+		}
+		outputResultWith(stdout, result, cfg.render)
+		return nil
+	}
+
+	// Load and parse processes, hashing the input for the manifest.
+	digest := sha256.New()
+	processes, err := loadProcesses(io.TeeReader(cfg.data, digest))
+	if err != nil {
+		return err
+	}
+	processes, remaps, err := applyDedupePolicy(processes, cfg.dedupe)
 	if err != nil {
-		log.Fatal(err)
+		return err
+	}
+	if err := ValidateProcesses(processes); err != nil {
+		return err
+	}
+	outputRemaps(stdout, remaps)
+	if cfg.resumePath != "" {
+		state, err := loadStateFile(cfg.resumePath)
+		if err != nil {
+			return err
+		}
+		if err := ValidateState(processes, state); err != nil {
+			return err
+		}
+		cfg.options.resume = &state
 	}
 
+	if cfg.dryRun {
+		plans := []plannedRun{cfg.plan(cfg.scheduler, processes)}
+		if len(cfg.diff) == 2 {
+			plans = []plannedRun{cfg.plan(cfg.diff[0], processes), cfg.plan(cfg.diff[1], processes)}
+		} else if cfg.optimal {
+			plans = plans[:0]
+			for _, spec := range schedulerSpecs {
+				plans = append(plans, cfg.plan(spec.scheduler, processes))
+			}
+		} else if cfg.scheduler == 0 {
+			plans = plans[:0]
+			for _, name := range cfg.profile.Algorithms {
+				scheduler, _ := parseScheduler(name)
+				plans = append(plans, cfg.plan(scheduler, processes))
+			}
+		} else if _, err := checkWorkload(stderr, processes, cfg.scheduler, cfg.options.resolveQuantum(processes), cfg.strict); err != nil {
+			return err
+		}
+		outputPlan(stdout, plans)
+		return nil
+	}
+
+	// Show where two schedulers diverge instead of running one.
+	if len(cfg.diff) == 2 {
+		if err := runDiff(stdout, processes, cfg.diff[0], cfg.diff[1], cfg.options); err != nil {
+			return err
+		}
+		summary.schedulers, summary.processes = 2, len(processes)
+		return nil
+	}
+
+	// Compare every scheduler with the offline optimum instead of running one.
+	if cfg.optimal {
+		summary.schedulers, summary.processes = runOptimal(stdout, processes, cfg.options), len(processes)
+		return nil
+	}
+
+	// Run every scheduler of the -profile, each as it would run on its own. JSON results are the
+	// elements of one array instead of sections.
+	if cfg.scheduler == 0 {
+		output := cfg.profile.Output
+		if output == "" {
+			output = ScenarioText
+		}
+		if output == ScenarioJSON {
+			_, _ = fmt.Fprintln(stdout, "[")
+		}
+		for i, name := range cfg.profile.Algorithms {
+			scheduler, err := parseScheduler(name)
+			if err != nil {
+				return err
+			}
+			name = scheduler.Name()
+			if takesQuantum(scheduler) {
+				name = fmt.Sprintf("%s quantum %d", name, cfg.options.resolveQuantum(processes))
+			}
+			switch {
+			case output != ScenarioJSON:
+				_, _ = fmt.Fprintf(stdout, "== %s %s ==\n", name, output)
+			case i > 0:
+				_, _ = fmt.Fprintln(stdout, ",")
+			}
+			if err := runSchedulerConfig(flagSet, cfg, scheduler, processes, digest.Sum(nil), stdout, stderr, summary); err != nil {
+				return err
+			}
+			if output != ScenarioJSON {
+				_, _ = fmt.Fprintln(stdout)
+			}
+		}
+		if output == ScenarioJSON {
+			_, _ = fmt.Fprintln(stdout, "]")
+		}
+		return nil
+	}
+
+	return runSchedulerConfig(flagSet, cfg, cfg.scheduler, processes, digest.Sum(nil), stdout, stderr, summary)
+}
+
+// runSchedulerConfig runs scheduler over processes with the options and output cfg asks for,
+// counting into summary. digest is the hash of the input, for the manifest.
+func runSchedulerConfig(flagSet *flag.FlagSet, cfg cliConfig, scheduler Scheduler, processes []Process, digest []byte, stdout, stderr io.Writer, summary *runSummary) error {
+	warnings, err := checkWorkload(stderr, processes, scheduler, cfg.options.resolveQuantum(processes), cfg.strict)
+	if err != nil {
+		return err
+	}
+	summary.warnings += warnings
+
 	// Run the given scheduler.
-	switch scheduler {
-	case fcfs:
-		FCFSSchedule(os.Stdout, "First-come, first-serve", processes)
-	case sjf:
-		SJFSchedule(os.Stdout, "Shortest-job-first", processes)
-	case sjfp:
-		SJFPrioritySchedule(os.Stdout, "Priority", processes)
-	case rr:
-		RRSchedule(os.Stdout, "Round-robin", processes)
+	result, err := runScheduler(io.Discard, scheduler, processes, cfg.options)
+	if err != nil {
+		return err
+	}
+	summary.schedulers++
+	summary.processes += len(processes)
+	if result.State != nil {
+		// paused by -pause-at, so there's no schedule to show yet.
+		return SaveState(stdout, *result.State)
+	}
+	if cfg.throughputWindow > 0 {
+		result.ThroughputSeries, result.ThroughputWindow = ThroughputSeries(result.Processes, cfg.throughputWindow)
+	}
+	if cfg.options.noise > 0 {
+		// show the degradation against perfect burst knowledge.
+		exact := cfg.options
+		exact.noise = 0
+		if baseline, err := runScheduler(io.Discard, scheduler, processes, exact); err == nil {
+			result.NoiseFreeWait = &baseline.AverageWait
+		}
+	}
+	manifest := NewManifest(cfg.inputName, digest, scheduler, flagSet)
+	result.Manifest = &manifest
+	if cfg.profile.Output == "" || cfg.profile.Output == ScenarioText {
+		outputResultWith(stdout, result, cfg.render)
+	} else if err := writeOutput(stdout, result, cfg.profile.Output); err != nil {
+		return err
 	}
+	if cfg.mm1 {
+		if estimate, err := EstimateMM1(processes); err != nil {
+			summary.warnings++
+			_, _ = fmt.Fprintln(stderr, "warning:", err)
+		} else {
+			outputMM1(stdout, estimate, result.AverageWait)
+		}
+	}
+
+	if cfg.savePath != "" {
+		result.States = StateTimeline(result)
+		if err := saveResultFile(cfg.savePath, result); err != nil {
+			return err
+		}
+	}
+	if cfg.eventsPath != "" {
+		return writeEventsFile(cfg.eventsPath, result)
+	}
+
+	return nil
 }
 
 //go:generate stringer -type=Scheduler
@@ -53,60 +287,426 @@ const (
 	rr
 )
 
-func parseCLI(flagSet *flag.FlagSet, args []string) (cmd Scheduler, data io.Reader, err error) {
-	fcfsFlag := flagSet.Bool(fcfs.String(), false, "First-come, first-serve scheduling")
-	sjfFlag := flagSet.Bool(sjf.String(), false, "Shortest-job-first scheduling")
-	sjfpFlag := flagSet.Bool(sjfp.String(), false, "Shortest-job-first with priority scheduling")
-	rrFlag := flagSet.Bool(rr.String(), false, "Round-robin scheduling")
+// runOptions are the scheduler tuning options given on the command line.
+type runOptions struct {
+	quantum    quantumFlag
+	percentile float64
+	noise      float64
+	noiseDist  string
+	seed       uint64
+	pauseAt    *int64
+	resume     *SimState
+	queue      bool
+	decay      int64
+	// priorityFloor and priorityCeiling bound the priorities aging and decay give, unbounded if nil.
+	priorityFloor   *int64
+	priorityCeiling *int64
+	// the options of the registered algorithms, named after their flags.
+	age        int64
+	boost      int64
+	srrA       int64
+	srrB       int64
+	minRun     int64
+	waitBound  int64
+	formula    string
+	foreground int64
+	levels     levelsFlag
+	classify   int64
+	// classifyVerbose notes why auto-classify put each process in its class.
+	classifyVerbose bool
+	cpus            int
+	policy          policyFlag
+	steal           int
+	pack            string
+	speeds          speedsFlag
+}
+
+// defaultRunOptions are the tuning options of a run that sets none, as the flags default to.
+func defaultRunOptions() runOptions {
+	return runOptions{
+		percentile: defaultQuantumPercentile,
+		noiseDist:  NoiseUniform,
+		seed:       1,
+		age:        5,
+		boost:      20,
+		srrA:       2,
+		srrB:       1,
+		minRun:     2,
+		waitBound:  20,
+		formula:    QuantumMean,
+		foreground: 80,
+		levels:     levelsFlag{{Class: ClassInteractive, Quantum: defaultQuantum}, {Class: ClassBatch}},
+		classify:   defaultQuantum,
+		cpus:       2,
+		policy:     policyFlag(rr),
+		steal:      1,
+		pack:       PackFirstFit,
+		speeds:     speedsFlag{2, 1},
+	}
+}
+
+// priorityBounds are the bounds of -priority-floor and -priority-ceiling, lower numbers being more
+// important; UnboundedPriorities without either.
+func (o runOptions) priorityBounds() PriorityBounds {
+	bounds := UnboundedPriorities
+	if o.priorityFloor != nil {
+		bounds.Floor = *o.priorityFloor
+	}
+	if o.priorityCeiling != nil {
+		bounds.Ceiling = *o.priorityCeiling
+	}
+	return bounds
+}
+
+// scheduleOptions are the ScheduleOptions that apply to every scheduler.
+func (o runOptions) scheduleOptions() []ScheduleOption {
+	var opts []ScheduleOption
+	if o.pauseAt != nil {
+		opts = append(opts, WithPauseAt(*o.pauseAt))
+	}
+	if o.resume != nil {
+		opts = append(opts, WithResume(*o.resume))
+	}
+	if o.queue {
+		opts = append(opts, WithQueueSnapshots())
+	}
+	return opts
+}
+
+// resolveQuantum returns the round-robin quantum to use, picking one from the workload in auto mode.
+func (o runOptions) resolveQuantum(processes []Process) int64 {
+	switch {
+	case o.quantum.auto:
+		return AutoQuantum(processes, o.percentile)
+	case o.quantum.ticks > 0:
+		return o.quantum.ticks
+	default:
+		return defaultQuantum
+	}
+}
+
+// runScheduler dispatches to the scheduler selected on the command line, built-in or registered.
+func runScheduler(w io.Writer, scheduler Scheduler, processes []Process, opts runOptions) (ScheduleResult, error) {
+	with := opts.scheduleOptions()
+	if scheduler >= fcfs && scheduler <= rr {
+		if err := checkOneCPU(processes); err != nil {
+			return ScheduleResult{}, err
+		}
+	}
+	switch scheduler {
+	case fcfs:
+		return FCFSScheduleWith(w, "First-come, first-serve", processes, with...), nil
+	case sjf:
+		if opts.noise == 0 {
+			return SJFScheduleWith(w, "Shortest-job-first", processes, with...), nil
+		}
+		estimates, err := PerturbBursts(processes, opts.noise, opts.noiseDist, opts.seed)
+		if err != nil {
+			return ScheduleResult{}, err
+		}
+		title := fmt.Sprintf("Shortest-job-first (%s burst noise %g, seed %d)", opts.noiseDist, opts.noise, opts.seed)
+		return SJFScheduleWith(w, title, processes, append(with, WithBurstEstimates(estimates))...), nil
+	case sjfp:
+		if opts.decay == 0 {
+			return SJFPriorityScheduleWith(w, "Priority", processes, with...), nil
+		}
+		title := fmt.Sprintf("Priority (decay %d per tick)", opts.decay)
+		with = append(with, WithPriorityDecay(opts.decay), WithPriorityBounds(opts.priorityBounds()))
+		return SJFPriorityScheduleWith(w, title, processes, with...), nil
+	case rr:
+		title := "Round-robin"
+		quantum := opts.resolveQuantum(processes)
+		if opts.quantum.auto {
+			title = fmt.Sprintf("Round-robin (quantum %d, auto-selected at p%g)", quantum, opts.percentile)
+		} else if quantum != defaultQuantum {
+			title = fmt.Sprintf("Round-robin (quantum %d)", quantum)
+		}
+		return RRQuantumScheduleWith(w, title, processes, quantum, with...), nil
+	}
+	a, ok := registered(scheduler)
+	if !ok {
+		return ScheduleResult{}, fmt.Errorf("%w: %v", ErrUnknownAlgorithm, scheduler)
+	}
+	if len(with) > 0 {
+		return ScheduleResult{}, fmt.Errorf("%w: %s does not support -pause-at, -resume or -queue", ErrUnsupportedOption, a.Name())
+	}
+	result, err := a.Schedule(Workload{Processes: processes, Options: opts})
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	outputResult(w, result)
+
+	return result, nil
+}
+
+// runDiff runs schedulers a and b over processes and draws where their gantts diverge.
+func runDiff(w io.Writer, processes []Process, a, b Scheduler, opts runOptions) error {
+	resultA, err := runScheduler(io.Discard, a, processes, opts)
+	if err != nil {
+		return err
+	}
+	resultB, err := runScheduler(io.Discard, b, processes, opts)
+	if err != nil {
+		return err
+	}
+	OutputGanttDiff(w, a.Name(), resultA.Gantt, b.Name(), resultB.Gantt)
+
+	return nil
+}
+
+// cliConfig is everything parsed from the command line.
+type cliConfig struct {
+	scheduler        Scheduler
+	data             io.Reader
+	inputName        string
+	savePath         string
+	loadPath         string
+	batchPath        string
+	scenarioPath     string
+	profileName      string
+	profilePath      string
+	profile          Profile
+	eventsPath       string
+	resumePath       string
+	strict           bool
+	mm1              bool
+	diff             []Scheduler
+	optimal          bool
+	dedupe           string
+	options          runOptions
+	throughputWindow int64
+	render           renderOptions
+	warnUnsupported  bool
+	warnings         []string
+	quiet            bool
+	dryRun           bool
+}
+
+// registerFlags defines every command line flag on flagSet, storing values into cfg.
+// It returns the scheduler selection flags.
+func registerFlags(flagSet *flag.FlagSet, cfg *cliConfig) map[Scheduler]*bool {
+	flagSet.Usage = func() {
+		_, _ = fmt.Fprintf(flagSet.Output(), "Usage of %s:\n", flagSet.Name())
+		flagSet.PrintDefaults()
+		_, _ = fmt.Fprint(flagSet.Output(), schedulerHelp())
+	}
+	chosen := make(map[Scheduler]*bool, len(schedulerSpecs))
+	for _, spec := range schedulerSpecs {
+		chosen[spec.scheduler] = flagSet.Bool(spec.scheduler.Name(), false, spec.usage)
+	}
+	defaults := defaultRunOptions()
+	cfg.options.levels, cfg.options.policy, cfg.options.speeds = defaults.levels, defaults.policy, defaults.speeds
+	flagSet.StringVar(&cfg.savePath, "save", "", "Save the schedule result to this file for later re-rendering")
+	flagSet.StringVar(&cfg.loadPath, "load", "", "Render a previously saved schedule result instead of scheduling")
+	flagSet.Var(&cfg.options.quantum, "quantum", `Time quantum in ticks of round-robin and the other schedulers with one, or "auto" to pick one from the bursts`)
+	flagSet.Float64Var(&cfg.options.percentile, "percentile", defaults.percentile, "Burst percentile used by -quantum auto")
+	flagSet.Float64Var(&cfg.options.noise, "noise", 0, "Relative error of the burst estimates SJF orders by, e.g. 0.2 for ±20%")
+	flagSet.StringVar(&cfg.options.noiseDist, "noise-dist", defaults.noiseDist, `Burst noise distribution: "uniform" or "gaussian"`)
+	flagSet.Uint64Var(&cfg.options.seed, "seed", defaults.seed, "Seed for the burst noise and the lottery and random draws")
+	flagSet.Int64Var(&cfg.options.decay, "decay", 0, "Priority levels a process loses for every tick it runs")
+	flagSet.Func("priority-floor", "Least important priority decay may give a process, unbounded if unset", func(s string) error {
+		floor, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: -priority-floor wants a priority, got %q", ErrInvalidArgs, s)
+		}
+		cfg.options.priorityFloor = &floor
+		return nil
+	})
+	flagSet.Func("priority-ceiling", "Most important priority aging may give a process, unbounded if unset", func(s string) error {
+		ceiling, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: -priority-ceiling wants a priority, got %q", ErrInvalidArgs, s)
+		}
+		cfg.options.priorityCeiling = &ceiling
+		return nil
+	})
+	flagSet.Int64Var(&cfg.options.age, "age", defaults.age, "Ticks of waiting that gain a process a priority level under aging, 0 for none")
+	flagSet.Int64Var(&cfg.options.boost, "boost", defaults.boost, "Ticks of waiting after which round-robin boosts a process to the front, 0 for never")
+	flagSet.Int64Var(&cfg.options.srrA, "srr-a", defaults.srrA, "Priority growth per tick of new selfish round-robin processes")
+	flagSet.Int64Var(&cfg.options.srrB, "srr-b", defaults.srrB, "Priority growth per tick of accepted selfish round-robin processes")
+	flagSet.Int64Var(&cfg.options.minRun, "min-run", defaults.minRun, "Ticks a dispatched process runs before SJF may preempt it")
+	flagSet.Int64Var(&cfg.options.waitBound, "wait-bound", defaults.waitBound, "Ticks of waiting after which SJF runs a process regardless, 0 for never")
+	flagSet.StringVar(&cfg.options.formula, "quantum-formula", defaults.formula, `Burst statistic dynamic round-robin takes its quantum from: "mean" or "median"`)
+	flagSet.Int64Var(&cfg.options.foreground, "foreground", defaults.foreground, "Percentage of the CPU the foreground queue gets while both queues have work")
+	flagSet.Var(&cfg.options.levels, "levels", `Multilevel queue levels as "<class>:<quantum>" in priority order, a quantum of 0 running to completion`)
+	flagSet.Int64Var(&cfg.options.classify, "classify-threshold", defaults.classify, "Longest CPU burst in ticks of a process classified interactive")
+	flagSet.BoolVar(&cfg.options.classifyVerbose, "classify-verbose", false, "Show the class auto-classify gives each process and the CPU bursts it went by")
+	flagSet.IntVar(&cfg.options.cpus, "cpus", defaults.cpus, "Number of CPUs of the multicore schedulers")
+	flagSet.Var(&cfg.options.policy, "policy", `Scheduler each CPU of a multicore scheduler picks by: "fcfs", "sjf" or "rr"`)
+	flagSet.IntVar(&cfg.options.steal, "steal", defaults.steal, "Processes another runqueue must have waiting to be stolen from, 0 for no stealing")
+	flagSet.StringVar(&cfg.options.pack, "pack", defaults.pack, `Bin-packing strategy of the packed scheduler: "first-fit", "best-fit" or "worst-fit"`)
+	flagSet.Var(&cfg.options.speeds, "speeds", `Speed of each CPU of the heterogeneous scheduler, e.g. "2,1"`)
+	flagSet.Int64Var(&cfg.throughputWindow, "window", 0, "Width of the throughput-over-time windows (default a tenth of the schedule)")
+	flagSet.StringVar(&cfg.batchPath, "batch", "", "Run each \"<scheduler> <file>\" line of this batch script")
+	flagSet.StringVar(&cfg.scenarioPath, "scenario", "", "Run the processes, schedulers, quanta and outputs described by this YAML scenario file")
+	flagSet.StringVar(&cfg.profileName, "profile", "", "Take the quantum, schedulers and output format of this profile from the -profile-file")
+	flagSet.StringVar(&cfg.profilePath, "profile-file", profileFile, "TOML file of [profiles.<name>] tables for -profile")
+	flagSet.Func("diff", "Compare the gantts of two schedulers, e.g. \"fcfs,rr\"", func(s string) error {
+		a, b, ok := strings.Cut(s, ",")
+		if !ok {
+			return fmt.Errorf("%w: -diff wants two schedulers, e.g. \"fcfs,rr\"", ErrInvalidArgs)
+		}
+		cfg.diff = cfg.diff[:0]
+		for _, name := range []string{a, b} {
+			scheduler, err := parseScheduler(strings.TrimSpace(name))
+			if err != nil {
+				return err
+			}
+			cfg.diff = append(cfg.diff, scheduler)
+		}
+		return nil
+	})
+	flagSet.BoolVar(&cfg.optimal, "optimal", false, "Compare every scheduler's average wait with the offline optimum instead of running one")
+	flagSet.StringVar(&cfg.eventsPath, "events", "", "Write every scheduling event to this CSV file")
+	flagSet.StringVar(&cfg.resumePath, "resume", "", "Continue from the simulation state in this JSON file instead of an empty system")
+	flagSet.Func("pause-at", "Stop at the first decision point at or after this time and print the state to -resume from", func(s string) error {
+		t, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || t < 0 {
+			return fmt.Errorf("%w: -pause-at wants a time of 0 or more, got %q", ErrInvalidArgs, s)
+		}
+		cfg.options.pauseAt = &t
+		return nil
+	})
+	flagSet.StringVar(&cfg.dedupe, "dedupe", DedupeReject, `Duplicate process IDs: "reject" them, or "rename" later ones to <id>#2, <id>#3...`)
+	flagSet.BoolVar(&cfg.render.showRemaining, "debug", false, "Show each process' final remaining burst in the table")
+	flagSet.BoolVar(&cfg.render.timeline, "timeline", false, "Show a per-process timeline of running, waiting and suspended time")
+	flagSet.Func("unit", `Real-world time per tick to show times in, e.g. "10ms"`, func(s string) (err error) {
+		cfg.render.unit, err = ParseTimeUnit(s)
+		return err
+	})
+	flagSet.BoolVar(&cfg.render.critical, "critical", false, "Highlight in the gantt chart when the longest-waiting process was ready but not running")
+	flagSet.BoolVar(&cfg.options.queue, "queue", false, "Show the ready queue at every dispatch")
+	flagSet.BoolVar(&cfg.warnUnsupported, "warn-unsupported", false, "Warn instead of failing when an option doesn't apply to the scheduler")
+	flagSet.BoolVar(&cfg.mm1, "mm1", false, "Compare the average wait with the M/M/1 queueing prediction for the workload")
+	flagSet.BoolVar(&cfg.strict, "strict", false, "Fail instead of warning when the workload looks like a mistake")
+	flagSet.BoolVar(&cfg.dryRun, "dry-run", false, "Load and validate the input and print the planned runs without simulating")
+	flagSet.BoolVar(&cfg.quiet, "quiet", false, "Leave off the RESULT summary line at the end of stderr")
+
+	return chosen
+}
+
+func parseCLI(flagSet *flag.FlagSet, args []string) (cfg cliConfig, err error) {
+	chosen := registerFlags(flagSet, &cfg)
+	// fail keeps -quiet, which holds even when the rest of the command line is bad.
+	fail := func(err error) (cliConfig, error) { return cliConfig{quiet: cfg.quiet}, err }
 	if err := flagSet.Parse(args); err != nil {
-		return 0, nil, err
+		return fail(err)
+	}
+	if err := cfg.options.priorityBounds().Validate(); err != nil {
+		return fail(err)
+	}
+	if cfg.profileName != "" {
+		// the profile fills in what the command line leaves out.
+		if cfg.profile, err = loadProfile(cfg.profilePath, cfg.profileName); err != nil {
+			return fail(err)
+		}
+		var quantumSet bool
+		flagSet.Visit(func(f *flag.Flag) { quantumSet = quantumSet || f.Name == "quantum" })
+		if !quantumSet && cfg.profile.Quantum > 0 {
+			cfg.options.quantum = quantumFlag{ticks: cfg.profile.Quantum}
+		}
 	}
 	// validate only one flag is set
 	var count int
-	if *fcfsFlag {
-		count++
-		cmd = fcfs
+	for _, spec := range schedulerSpecs {
+		if *chosen[spec.scheduler] {
+			count++
+			cfg.scheduler = spec.scheduler
+		}
+	}
+	if cfg.batchPath != "" {
+		// the batch script names a scheduler and data file per line.
+		if count > 0 || cfg.loadPath != "" {
+			return fail(fmt.Errorf("a scheduler flag or -load cannot be combined with -batch"))
+		}
+		return cfg, nil
+	}
+	if cfg.scenarioPath != "" {
+		// the scenario names its processes and schedulers itself.
+		if count > 0 || cfg.loadPath != "" || len(cfg.diff) > 0 || cfg.optimal || cfg.dryRun {
+			return fail(fmt.Errorf("a scheduler flag, -load, -diff, -optimal or -dry-run cannot be combined with -scenario"))
+		}
+		return cfg, nil
+	}
+	if cfg.loadPath != "" {
+		// a saved result already holds its schedule, so no scheduler or data is needed.
+		if count > 0 {
+			return fail(fmt.Errorf("a scheduler flag cannot be combined with -load"))
+		}
+		return cfg, nil
 	}
-	if *sjfFlag {
-		count++
-		cmd = sjf
+	if cfg.optimal {
+		// every scheduler runs.
+		if count > 0 || len(cfg.diff) > 0 {
+			return fail(fmt.Errorf("a scheduler flag or -diff cannot be combined with -optimal"))
+		}
+		if cfg.data, cfg.inputName, err = readData(flagSet.Args()); err != nil {
+			return fail(err)
+		}
+		return cfg, nil
 	}
-	if *sjfpFlag {
-		count++
-		cmd = sjfp
+	if len(cfg.diff) > 0 {
+		// the diff names both schedulers itself.
+		if count > 0 {
+			return fail(fmt.Errorf("a scheduler flag cannot be combined with -diff"))
+		}
+		if cfg.data, cfg.inputName, err = readData(flagSet.Args()); err != nil {
+			return fail(err)
+		}
+		return cfg, nil
 	}
-	if *rrFlag {
-		count++
-		cmd = rr
+	if count == 0 && len(cfg.profile.Algorithms) > 0 {
+		// the profile's schedulers all run.
+		if cfg.savePath != "" || cfg.eventsPath != "" {
+			return fail(fmt.Errorf("-save or -events cannot be combined with a profile's schedulers"))
+		}
+		if cfg.data, cfg.inputName, err = readData(flagSet.Args()); err != nil {
+			return fail(err)
+		}
+		return cfg, nil
 	}
 	switch count {
 	case 0:
-		return 0, nil, fmt.Errorf("one scheduler flag must be set")
+		return fail(fmt.Errorf("one scheduler flag must be set"))
 	case 1:
+		// validate that the tuning options given apply to the chosen scheduler.
+		var set []string
+		flagSet.Visit(func(f *flag.Flag) { set = append(set, f.Name) })
+		if err := checkSchedulerOptions(cfg.scheduler, set); err != nil {
+			if !cfg.warnUnsupported {
+				return fail(err)
+			}
+			cfg.warnings = append(cfg.warnings, err.Error())
+		}
 		// validate that data file is piped in.
-		if data, err := readData(os.Args[:2]); err != nil {
-			return 0, nil, err
-		} else {
-			return cmd, data, nil
+		if cfg.data, cfg.inputName, err = readData(flagSet.Args()); err != nil {
+			return fail(err)
 		}
+		return cfg, nil
 	default:
-		return 0, nil, fmt.Errorf("only one scheduler flag must be set")
+		return fail(fmt.Errorf("only one scheduler flag must be set"))
 	}
 }
 
-func readData(args []string) (io.Reader, error) {
-	fi, _ := os.Stdin.Stat()
-	if (fi.Mode() & os.ModeCharDevice) == 0 {
-		return os.Stdin, nil
-	} else if len(args) == 1 {
-		return nil, fmt.Errorf("scheduler data must be passed in or file given as last argument")
+// readData opens the process list: the file named by the first argument, stdin if that's "-",
+// or stdin if there's no argument and something is piped in, e.g.
+// "scheduler generate -n 20 | scheduler -rr".
+func readData(args []string) (io.Reader, string, error) {
+	if len(args) == 0 {
+		fi, _ := os.Stdin.Stat()
+		if fi == nil || (fi.Mode()&os.ModeCharDevice) != 0 {
+			return nil, "", fmt.Errorf("scheduler data must be passed in or file given as last argument")
+		}
+	}
+	if len(args) == 0 || args[0] == "-" {
+		return os.Stdin, "stdin", nil
 	}
-	r, err := os.Open(os.Args[2])
+	r, err := os.Open(args[0])
 	if err != nil {
-		return nil, fmt.Errorf("%w: error opening data file", err)
+		return nil, "", fmt.Errorf("%w: error opening data file", err)
 	}
 
-	return r, nil
+	return r, args[0], nil
 }
 
 func openProcessingFile(args ...string) (*os.File, func(), error) {
@@ -136,62 +736,251 @@ func outputTitle(w io.Writer, title string) {
 }
 
 func outputGantt(w io.Writer, gantt []TimeSlice) {
+	OutputGanttFunc(w, gantt, func(slice TimeSlice) string { return slice.PID })
+}
+
+// OutputGanttFunc writes the gantt chart like outputGantt, labeling each slice with label(slice)
+// instead of its PID. Idle gaps between slices are always labeled "-".
+func OutputGanttFunc(w io.Writer, gantt []TimeSlice, label func(TimeSlice) string) {
+	outputGanttWith(w, gantt, label, TimeUnit{}, nil)
+}
+
+// OutputGanttUnit writes the gantt chart like outputGantt, with its times shown in unit.
+func OutputGanttUnit(w io.Writer, gantt []TimeSlice, unit TimeUnit) {
+	outputGanttWith(w, gantt, func(slice TimeSlice) string { return slice.PID }, unit, nil)
+}
+
+// outputGanttWith writes the gantt chart, marking the columns that overlap any highlight
+// interval on an extra row below the times.
+func outputGanttWith(w io.Writer, gantt []TimeSlice, label func(TimeSlice) string, unit TimeUnit, highlight []StateInterval) {
 	_, _ = fmt.Fprintln(w, "Gantt schedule")
+	if len(gantt) == 0 {
+		_, _ = fmt.Fprintf(w, "\n")
+		return
+	}
 
-	buffer := 2
-	widest := 0
-	for _, slice := range gantt {
-		if len(slice.PID) > widest {
-			widest = len(slice.PID)
+	// fill in empty time slices in the gantt.
+	filled := make([]TimeSlice, 0, len(gantt))
+	labels := make([]string, 0, len(gantt))
+	for i, slice := range gantt {
+		if i > 0 && slice.Start > gantt[i-1].Stop {
+			filled = append(filled, TimeSlice{PID: "-", Start: gantt[i-1].Stop, Stop: slice.Start})
+			labels = append(labels, "-")
 		}
+		filled = append(filled, slice)
+		labels = append(labels, label(slice))
 	}
 
-	// fill in empty time slices in the gantt.
-	for i := 1; i < len(gantt); i++ {
-		if gantt[i].Start > gantt[i-1].Stop {
-			gantt = append(gantt[:i], append([]TimeSlice{{
-				PID: "-", Start: gantt[i-1].Stop, Stop: gantt[i].Start,
-			}}, gantt[i:]...)...)
-			i++
+	buffer := 2
+	widest := 0
+	for _, l := range labels {
+		if len(l) > widest {
+			widest = len(l)
 		}
 	}
 
 	_, _ = fmt.Fprintf(w, "|")
-	last := gantt[0].Start
-	for _, slice := range gantt {
+	for i := range filled {
 		_, _ = fmt.Fprint(w, strings.Repeat(" ", buffer))
-		if slice.Start > last {
-			_, _ = fmt.Fprint(w, strings.Repeat(" ", widest))
-		} else {
-			_, _ = fmt.Fprint(w, slice.PID)
-		}
+		_, _ = fmt.Fprint(w, labels[i])
 		_, _ = fmt.Fprint(w, strings.Repeat(" ", buffer)+"|")
-		last = slice.Stop
 	}
 	_, _ = fmt.Fprintf(w, "\n")
 	width := buffer + widest + buffer + 1
-	for i := range gantt {
-		t := fmt.Sprint(gantt[i].Start)
+	for i := range filled {
+		t := unit.Format(filled[i].Start)
 		_, _ = fmt.Fprint(w, t)
-		_, _ = fmt.Fprint(w, strings.Repeat(" ", width-len(t)))
-		if i == len(gantt)-1 {
-			_, _ = fmt.Fprint(w, gantt[i].Stop)
+		_, _ = fmt.Fprint(w, strings.Repeat(" ", max(width-len(t), 1)))
+		if i == len(filled)-1 {
+			_, _ = fmt.Fprint(w, unit.Format(filled[i].Stop))
+		}
+	}
+	if len(highlight) > 0 {
+		var marks strings.Builder
+		for i := range filled {
+			mark := " "
+			if overlapsAny(filled[i], highlight) {
+				mark = ganttHighlight
+			}
+			marks.WriteString(" " + strings.Repeat(mark, width-1))
 		}
+		_, _ = fmt.Fprintf(w, "\n%s", strings.TrimRight(marks.String(), " "))
 	}
 
 	_, _ = fmt.Fprintf(w, "\n\n")
 }
 
-func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64) {
+// outputRemaps lists renamed duplicate process IDs so results can be traced back to the input.
+func outputRemaps(w io.Writer, remaps []Remap) {
+	if len(remaps) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Renamed duplicate process IDs")
+	for _, r := range remaps {
+		_, _ = fmt.Fprintf(w, "  %s -> %s\n", r.OriginalID, r.NewID)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// renderOptions tweak how a schedule result is rendered.
+type renderOptions struct {
+	// showRemaining adds each process' final remaining burst to the table as a sanity check.
+	showRemaining bool
+	// timeline adds a per-process timeline below the gantt chart.
+	timeline bool
+	// less orders the table rows; nil keeps them in input order.
+	less func(a, b ProcessResult) bool
+	// unit shows times in real-world units instead of ticks.
+	unit TimeUnit
+	// critical highlights when the longest-waiting process was ready but not running.
+	critical bool
+}
+
+// outputResult renders a schedule result as a title, gantt chart and schedule table.
+func outputResult(w io.Writer, result ScheduleResult) {
+	outputResultWith(w, result, renderOptions{})
+}
+
+// OutputResultSorted is outputResult with the table rows ordered by less, e.g. by wait
+// descending to put the worst cases first. A nil less keeps the input order.
+func OutputResultSorted(w io.Writer, result ScheduleResult, less func(a, b ProcessResult) bool) {
+	outputResultWith(w, result, renderOptions{less: less})
+}
+
+// outputResultWith is outputResult with rendering options.
+func outputResultWith(w io.Writer, result ScheduleResult, opts renderOptions) {
+	header := []string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"}
+	if opts.showRemaining {
+		header = append(header, "Remaining")
+	}
+	showStatus := slices.ContainsFunc(result.Processes, func(p ProcessResult) bool { return p.Aborted || p.Limited })
+	if showStatus {
+		header = append(header, "Status", "CPU")
+	}
+	showSuspended := slices.ContainsFunc(result.Processes, func(p ProcessResult) bool { return len(p.Suspensions) > 0 })
+	if showSuspended {
+		header = append(header, "Suspended")
+	}
+	showBlocked := slices.ContainsFunc(result.Processes, func(p ProcessResult) bool { return len(p.IOBursts) > 0 })
+	if showBlocked {
+		header = append(header, "I/O")
+	}
+	processes := result.Processes
+	if opts.less != nil {
+		processes = slices.Clone(processes)
+		slices.SortStableFunc(processes, func(a, b ProcessResult) int {
+			switch {
+			case opts.less(a, b):
+				return -1
+			case opts.less(b, a):
+				return 1
+			default:
+				return 0
+			}
+		})
+	}
+	unit := opts.unit
+	rows := make([][]string, len(processes))
+	for i, p := range processes {
+		rows[i] = []string{
+			fmt.Sprint(p.ProcessID),
+			fmt.Sprint(p.Priority),
+			unit.Format(p.BurstDuration),
+			unit.Format(p.ArrivalTime),
+			unit.Format(p.Wait),
+			unit.Format(p.Turnaround),
+			unit.Format(p.Completion),
+		}
+		if opts.showRemaining {
+			rows[i] = append(rows[i], unit.Format(p.Remaining))
+		}
+		if showStatus {
+			status := "done"
+			switch {
+			case p.Limited:
+				status = "limit exceeded"
+				rows[i][4], rows[i][5], rows[i][6] = "-", "-", unit.Format(p.Terminated)
+			case p.Aborted:
+				status = "aborted"
+				rows[i][4], rows[i][5], rows[i][6] = "-", "-", "-"
+			}
+			rows[i] = append(rows[i], status, unit.Format(p.BurstDuration-p.Remaining))
+		}
+		if showSuspended {
+			rows[i] = append(rows[i], unit.Format(p.Suspended))
+		}
+		if showBlocked {
+			rows[i] = append(rows[i], unit.Format(p.Blocked))
+		}
+	}
+
+	outputTitle(w, result.Title)
+	if opts.critical {
+		_, waits := CriticalWaits(result)
+		outputGanttWith(w, result.Gantt, func(slice TimeSlice) string { return slice.PID }, opts.unit, waits)
+		outputCriticalWaits(w, result, opts.unit)
+	} else if result.CPUs != nil {
+		outputCPUs(w, result)
+	} else {
+		OutputGanttUnit(w, result.Gantt, opts.unit)
+	}
+	if opts.timeline {
+		OutputTimeline(w, result)
+	}
+	if len(result.ReadyQueueSnapshots) > 0 {
+		OutputQueueSnapshots(w, result.ReadyQueueSnapshots)
+	}
+	outputSchedule(w, header, rows, result.AverageWait, result.AverageTurnaround, result.Throughput, opts.unit)
+	outputLittlesLaw(w, MeasureLittlesLaw(result))
+	if result.CPUs != nil {
+		outputCPUUtilization(w, result)
+	}
+	if result.WastedCPU > 0 {
+		_, _ = fmt.Fprintf(w, "Wasted CPU: %d\n", result.WastedCPU)
+	}
+	if result.LimitKills > 0 {
+		_, _ = fmt.Fprintf(w, "CPU limit kills: %d (used %d CPU)\n", result.LimitKills, result.LimitCPU)
+	}
+	if result.NoiseFreeWait != nil {
+		_, _ = fmt.Fprintf(w, "Average wait without noise: %.2f\n", *result.NoiseFreeWait)
+	}
+	if result.UnpinnedWait != nil {
+		_, _ = fmt.Fprintf(w, "Average wait without affinity: %.2f\n", *result.UnpinnedWait)
+	}
+	if result.LateJobs > 0 {
+		_, _ = fmt.Fprintf(w, "Missed deadlines: %d (%s), %s late in total\n",
+			result.LateJobs, strings.Join(MissedDeadlines(result.Processes), ", "), unit.Format(result.TotalTardiness))
+	}
+	if len(result.Incomplete) > 0 {
+		_, _ = fmt.Fprintf(w, "Incomplete: %d (%s)\n", len(result.Incomplete), strings.Join(result.Incomplete, ", "))
+	}
+	for _, note := range result.Notes {
+		_, _ = fmt.Fprintln(w, note)
+	}
+	outputThroughputSeries(w, result.ThroughputWindow, result.ThroughputSeries, unit)
+	outputManifest(w, result.Manifest)
+}
+
+// noteLines are the lines write writes, as a ScheduleResult's Notes.
+func noteLines(write func(w io.Writer)) []string {
+	var sb strings.Builder
+	write(&sb)
+	if sb.Len() == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(sb.String(), "\n"), "\n")
+}
+
+func outputSchedule(w io.Writer, header []string, rows [][]string, wait, turnaround, throughput float64, unit TimeUnit) {
 	_, _ = fmt.Fprintln(w, "Schedule table")
 	table := tablewriter.NewWriter(w)
-	table.SetHeader([]string{"ID", "Priority", "Burst", "Arrival", "Wait", "Turnaround", "Exit"})
+	table.SetHeader(header)
 	table.AppendBulk(rows)
 	table.Render()
 	_, _ = fmt.Fprintln(w)
-	_, _ = fmt.Fprintf(w, "Average wait: %.2f\n", wait)
-	_, _ = fmt.Fprintf(w, "Average turnaround: %.2f\n", turnaround)
-	_, _ = fmt.Fprintf(w, "Throughput: %.2f\n", throughput)
+	_, _ = fmt.Fprintf(w, "Average wait: %s\n", unit.formatAverage(wait))
+	_, _ = fmt.Fprintf(w, "Average turnaround: %s\n", unit.formatAverage(turnaround))
+	_, _ = fmt.Fprintf(w, "Throughput: %s\n", unit.formatRate(throughput))
 }
 
 //endregion
@@ -200,31 +989,342 @@ func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput f
 
 var ErrInvalidArgs = errors.New("invalid args")
 
+// loadProcesses reads processes written as CSV with an optional header row, or as a JSON array
+// when the input starts with '['. Blank lines and lines starting with '#' are skipped.
 func loadProcesses(r io.Reader) ([]Process, error) {
-	rows, err := csv.NewReader(r).ReadAll()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading processes", err)
+	}
+	// spreadsheets often start a CSV export with a byte order mark.
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	if stripped := stripCommentLines(data); bytes.HasPrefix(bytes.TrimSpace(stripped), []byte("[")) {
+		return loadProcessesJSON(stripped)
+	}
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.TrimLeadingSpace = true
+	// short rows leave the columns they lack empty.
+	reader.FieldsPerRecord = -1
+	var (
+		rows  [][]string
+		lines []int
+	)
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading CSV", err)
+		}
+		if len(row) == 1 && row[0] == "" {
+			// a line of nothing but spaces.
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		rows, lines = append(rows, row), append(lines, line)
+	}
+	columns, records, err := csvColumnsOf(rows)
 	if err != nil {
-		return nil, fmt.Errorf("%w: reading CSV", err)
+		return nil, err
 	}
-	rows = rows[1:] // skip header row
-	processes := make([]Process, len(rows))
-	for i := range rows {
-		processes[i].ProcessID = rows[i][0]
-		processes[i].BurstDuration = mustStrToInt(rows[i][1])
-		processes[i].ArrivalTime = mustStrToInt(rows[i][2])
-		if len(rows[i]) == 4 {
-			processes[i].Priority = mustStrToInt(rows[i][3])
+	// the header, if any, was the first row.
+	lines = lines[len(rows)-len(records):]
+	processes := make([]Process, len(records))
+	for i, row := range records {
+		if processes[i], err = csvProcess(columns, row); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lines[i], err)
 		}
 	}
 
 	return processes, nil
 }
 
-func mustStrToInt(s string) int64 {
-	i, err := strconv.ParseInt(s, 10, 64)
+// processInput is a process as a process file gives it, with its suspensions, I/O bursts, CPUs
+// and bandwidth written as in the CSV columns, e.g. "20-35;40-45", "3:4;7:2", "0;2" and "2/5".
+// JSON takes the CSV column names, and also the JSON a Process is written as, so the tool reads
+// back its own: suspensions as windows, and softDeadline, affinity, quota, period,
+// preemptionThreshold and ioBursts. Only originalID, which deduplication sets, is not taken.
+type processInput struct {
+	ProcessID     string           `json:"processID"`
+	BurstDuration int64            `json:"burstDuration"`
+	ArrivalTime   int64            `json:"arrivalTime"`
+	Priority      int64            `json:"priority"`
+	KillAt        int64            `json:"killAt"`
+	Suspensions   suspensionsInput `json:"suspensions"`
+	CPULimit      int64            `json:"cpuLimit"`
+	Class         string           `json:"class"`
+	Deadline      int64            `json:"deadline"`
+	User          string           `json:"user"`
+	CPUs          string           `json:"cpus"`
+	Bandwidth     string           `json:"bandwidth"`
+	Group         string           `json:"group"`
+	Threshold     int64            `json:"threshold"`
+	IO            string           `json:"io"`
+	// the Process fields of what the columns above give in another form.
+	SoftDeadline        int64     `json:"softDeadline"`
+	Affinity            uint64    `json:"affinity"`
+	Quota               int64     `json:"quota"`
+	Period              int64     `json:"period"`
+	PreemptionThreshold int64     `json:"preemptionThreshold"`
+	IOBursts            []IOBurst `json:"ioBursts"`
+}
+
+// suspensionsInput is the suspensions of a processInput: the CSV form, or in JSON also the
+// windows of a Process.
+type suspensionsInput struct {
+	text    string
+	windows []Window
+}
+
+func (s *suspensionsInput) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		return json.Unmarshal(data, &s.windows)
+	}
+	return json.Unmarshal(data, &s.text)
+}
+
+// process parses the input's suspensions, I/O bursts, CPUs and bandwidth into a Process.
+func (in processInput) process() (Process, error) {
+	p := Process{
+		ProcessID:           in.ProcessID,
+		ArrivalTime:         in.ArrivalTime,
+		BurstDuration:       in.BurstDuration,
+		Priority:            in.Priority,
+		SoftDeadline:        in.Deadline,
+		KillAt:              in.KillAt,
+		CPULimit:            in.CPULimit,
+		Class:               in.Class,
+		User:                in.User,
+		Group:               in.Group,
+		PreemptionThreshold: in.Threshold,
+	}
+	// each field may come in either form, but not both.
+	both := func(given bool, csv, process string) error {
+		if given {
+			return fmt.Errorf("%w: process %q has both %s and %s", ErrInvalidArgs, p.ProcessID, csv, process)
+		}
+		return nil
+	}
+	var err error
+	if p.Suspensions, err = parseWindows(in.Suspensions.text); err != nil {
+		return Process{}, fmt.Errorf("%w: process %q", err, p.ProcessID)
+	}
+	for _, w := range in.Suspensions.windows {
+		if w.Stop <= w.Start {
+			return Process{}, fmt.Errorf("%w: process %q: suspension window %d-%d ends before it starts", ErrInvalidArgs, p.ProcessID, w.Start, w.Stop)
+		}
+		p.Suspensions = append(p.Suspensions, w)
+	}
+	if p.IOBursts, err = parseIOBursts(in.IO); err != nil {
+		return Process{}, fmt.Errorf("%w: process %q", err, p.ProcessID)
+	}
+	if in.IOBursts != nil {
+		if err := both(in.IO != "", "io", "ioBursts"); err != nil {
+			return Process{}, err
+		}
+		p.IOBursts = in.IOBursts
+	}
+	if p.Affinity, err = parseCPUList(in.CPUs); err != nil {
+		return Process{}, fmt.Errorf("%w: process %q", err, p.ProcessID)
+	}
+	if in.Affinity != 0 {
+		if err := both(in.CPUs != "", "cpus", "affinity"); err != nil {
+			return Process{}, err
+		}
+		p.Affinity = in.Affinity
+	}
+	if in.Bandwidth != "" {
+		if p.Quota, p.Period, err = parseBandwidth(in.Bandwidth); err != nil {
+			return Process{}, fmt.Errorf("%w: process %q", err, p.ProcessID)
+		}
+	}
+	if in.Quota != 0 || in.Period != 0 {
+		if err := both(in.Bandwidth != "", "bandwidth", "quota and period"); err != nil {
+			return Process{}, err
+		}
+		p.Quota, p.Period = in.Quota, in.Period
+	}
+	if in.SoftDeadline != 0 {
+		if err := both(in.Deadline != 0, "deadline", "softDeadline"); err != nil {
+			return Process{}, err
+		}
+		p.SoftDeadline = in.SoftDeadline
+	}
+	if in.PreemptionThreshold != 0 {
+		if err := both(in.Threshold != 0, "threshold", "preemptionThreshold"); err != nil {
+			return Process{}, err
+		}
+		p.PreemptionThreshold = in.PreemptionThreshold
+	}
+
+	return p, nil
+}
+
+// csvProcess reads a CSV row into a process, its columns where csvColumnsOf found them.
+func csvProcess(columns [len(csvColumnNames)]int, row []string) (Process, error) {
+	field := func(column int) string {
+		if columns[column] < 0 || columns[column] >= len(row) {
+			return ""
+		}
+		return row[columns[column]]
+	}
+	var (
+		in  processInput
+		err error
+	)
+	in.ProcessID = field(colPID)
+	// integer parses the column into n, leaving it 0 when the column is empty and may be.
+	integer := func(n *int64, column int, optional bool) {
+		s := field(column)
+		if err != nil || s == "" && optional {
+			return
+		}
+		if *n, err = strconv.ParseInt(s, 10, 64); err != nil {
+			err = fmt.Errorf("%w: process %q: %s %q is not an integer", ErrInvalidArgs, in.ProcessID, csvColumnNames[column][0], s)
+		}
+	}
+	integer(&in.BurstDuration, colBurst, false)
+	integer(&in.ArrivalTime, colArrival, false)
+	integer(&in.Priority, colPriority, true)
+	// the kill time, CPU limit, soft deadline and preemption threshold are left empty for processes
+	// without one.
+	integer(&in.KillAt, colKillAt, true)
+	integer(&in.CPULimit, colCPULimit, true)
+	integer(&in.Deadline, colDeadline, true)
+	integer(&in.Threshold, colThreshold, true)
 	if err != nil {
-		log.Fatalln(err)
+		return Process{}, err
+	}
+	in.Suspensions.text = field(colSuspensions)
+	in.Class = field(colClass)
+	in.User = field(colUser)
+	in.CPUs = field(colCPUs)
+	in.Bandwidth = field(colBandwidth)
+	in.Group = field(colGroup)
+	in.IO = field(colIO)
+
+	return in.process()
+}
+
+// stripCommentLines drops the blank lines and lines starting with '#' from a JSON process file,
+// so its test cases can be annotated. A JSON string can't span lines, so none is cut short.
+func stripCommentLines(data []byte) []byte {
+	var kept []byte
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) == 0 || trimmed[0] == '#' {
+			continue
+		}
+		kept = append(kept, line...)
+	}
+	return kept
+}
+
+// The CSV columns, in the order they're read in when the header doesn't name them.
+const (
+	colPID = iota
+	colBurst
+	colArrival
+	colPriority
+	colKillAt
+	colSuspensions
+	colCPULimit
+	colClass
+	colDeadline
+	colUser
+	colCPUs
+	colBandwidth
+	colGroup
+	colThreshold
+	colIO
+)
+
+// csvColumnNames are the header names each CSV column is known by, compared ignoring case,
+// spaces, '_' and '-'.
+var csvColumnNames = [...][]string{
+	colPID:         {"pid", "processid", "id"},
+	colBurst:       {"burst", "burstduration"},
+	colArrival:     {"arrival", "arrivaltime"},
+	colPriority:    {"priority"},
+	colKillAt:      {"killat", "kill"},
+	colSuspensions: {"suspensions"},
+	colCPULimit:    {"cpulimit"},
+	colClass:       {"class"},
+	colDeadline:    {"deadline", "softdeadline"},
+	colUser:        {"user"},
+	colCPUs:        {"cpus", "affinity"},
+	colBandwidth:   {"bandwidth"},
+	colGroup:       {"group"},
+	colThreshold:   {"threshold", "preemptionthreshold"},
+	colIO:          {"io", "ioburst", "iobursts"},
+}
+
+// csvColumnsOf finds where each CSV column is in rows, -1 for a missing one, and returns the
+// rows without their header. The header row is optional: without one, or with one naming none
+// of the columns, they're read in their usual order. A header naming columns may have them in
+// any order and have others, which are ignored, but must name the ID, burst and arrival.
+func csvColumnsOf(rows [][]string) (columns [len(csvColumnNames)]int, data [][]string, err error) {
+	for column := range columns {
+		columns[column] = column
+	}
+	if len(rows) == 0 {
+		return columns, rows, nil
 	}
-	return i
+	if header := rows[0]; len(header) > colBurst {
+		if _, err := strconv.ParseInt(header[colBurst], 10, 64); err == nil {
+			// a burst where the header would be is data.
+			return columns, rows, nil
+		}
+	}
+
+	named := columns
+	for column := range named {
+		named[column] = -1
+	}
+	var found bool
+	for i, name := range rows[0] {
+		name = strings.NewReplacer(" ", "", "_", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(name)))
+		for column, names := range csvColumnNames {
+			if named[column] < 0 && slices.Contains(names, name) {
+				named[column], found = i, true
+			}
+		}
+	}
+	if !found {
+		return columns, rows[1:], nil
+	}
+	for _, column := range []int{colPID, colBurst, colArrival} {
+		if named[column] < 0 {
+			return named, nil, fmt.Errorf("%w: CSV header has no %s column", ErrInvalidArgs, csvColumnNames[column][0])
+		}
+	}
+
+	return named, rows[1:], nil
+}
+
+// loadProcessesJSON reads a JSON array of processes in processInput's form, e.g.
+// {"processID": "P0", "burstDuration": 5, "arrivalTime": 0, "deadline": 12, "user": "ana",
+// "suspensions": "2-4"}, every field but the ID, burst and arrival optional. The suspensions are
+// absolute windows, like the CSV column's; "io" gives I/O bursts relative to the CPU time run.
+// Unknown fields are rejected, so a misspelled one isn't silently left out.
+func loadProcessesJSON(data []byte) ([]Process, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var inputs []processInput
+	if err := decoder.Decode(&inputs); err != nil {
+		return nil, fmt.Errorf("%w: reading JSON: %v", ErrInvalidArgs, err)
+	}
+	processes := make([]Process, len(inputs))
+	for i, in := range inputs {
+		var err error
+		if processes[i], err = in.process(); err != nil {
+			return nil, fmt.Errorf("JSON process %d: %w", i+1, err)
+		}
+	}
+
+	return processes, nil
 }
 
 //endregion
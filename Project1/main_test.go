@@ -3,9 +3,11 @@ package main
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path"
+	"regexp"
 	"strings"
 	"testing"
 	"testing/iotest"
@@ -112,6 +114,250 @@ P2,6,3,3`),
 				},
 			},
 		},
+		{
+			name: "class and deadline columns",
+			args: args{
+				r: strings.NewReader(`ProcessID,Burst Duration,Arrival Time,Priority,Kill At,Suspensions,CPU Limit,Class,Deadline
+P0,5,0,2,,,,system,12
+P1,9,3,1,,,,batch,`),
+			},
+			want: []Process{
+				{
+					ProcessID:     "P0",
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					Class:         "system",
+					SoftDeadline:  12,
+				},
+				{
+					ProcessID:     "P1",
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+					Class:         "batch",
+				},
+			},
+		},
+		{
+			name: "user column",
+			args: args{
+				r: strings.NewReader(`ProcessID,Burst Duration,Arrival Time,Priority,Kill At,Suspensions,CPU Limit,Class,Deadline,User
+P0,5,0,2,,,,,,root
+P1,9,3,1,,,,,,`),
+			},
+			want: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2, User: "root"},
+				{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+			},
+		},
+		{
+			name: "affinity column",
+			args: args{
+				r: strings.NewReader(`ProcessID,Burst Duration,Arrival Time,Priority,Kill At,Suspensions,CPU Limit,Class,Deadline,User,CPUs
+P0,5,0,2,,,,,,,0;2
+P1,9,3,1,,,,,,,`),
+			},
+			want: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2, Affinity: 0b101},
+				{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+			},
+		},
+		{
+			name: "bandwidth column",
+			args: args{
+				r: strings.NewReader(`ProcessID,Burst Duration,Arrival Time,Priority,Kill At,Suspensions,CPU Limit,Class,Deadline,User,CPUs,Bandwidth
+P0,5,0,2,,,,,,,,2/5`),
+			},
+			want: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2, Quota: 2, Period: 5},
+			},
+		},
+		{
+			name: "group column",
+			args: args{
+				r: strings.NewReader(`ProcessID,Burst Duration,Arrival Time,Priority,Kill At,Suspensions,CPU Limit,Class,Deadline,User,CPUs,Bandwidth,Group
+P0,5,0,2,,,,,,,,,db
+P1,9,3,1,,,,,,,,,`),
+			},
+			want: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2, Group: "db"},
+				{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+			},
+		},
+		{
+			name: "threshold column",
+			args: args{
+				r: strings.NewReader(`ProcessID,Burst Duration,Arrival Time,Priority,Preemption Threshold
+P0,5,0,3,1
+P1,9,3,1,`),
+			},
+			want: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 3, PreemptionThreshold: 1},
+				{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+			},
+		},
+		{
+			name: "I/O column",
+			args: args{
+				r: strings.NewReader(`pid,burst,arrival,io
+P0,9,0,3:4;7:2
+P1,4,3,`),
+			},
+			want: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 9, IOBursts: []IOBurst{{After: 3, Duration: 4}, {After: 7, Duration: 2}}},
+				{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 4},
+			},
+		},
+		{
+			name: "no header",
+			args: args{
+				r: strings.NewReader(`P0,5,0,2
+P1,9,3,1`),
+			},
+			want: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+				{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+			},
+		},
+		{
+			name: "named columns in any order",
+			args: args{
+				r: strings.NewReader(`Arrival,Notes,PID,User,burst_duration,Deadline
+0,first,P0,ana,5,12
+3,,P1,,9,`),
+			},
+			want: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, SoftDeadline: 12, User: "ana"},
+				{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9},
+			},
+		},
+		{
+			name: "unnamed header columns",
+			args: args{
+				r: strings.NewReader(`a,b,c
+P0,5,0`),
+			},
+			want: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5},
+			},
+		},
+		{
+			name: "header without a burst column",
+			args: args{
+				r: strings.NewReader(`pid,arrival,priority
+P0,0,2`),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "spreadsheet export",
+			args: args{
+				r: strings.NewReader("\ufeffpid, burst, arrival\nP0, 5, 0\n"),
+			},
+			want: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5},
+			},
+		},
+		{
+			name: "burst not an integer",
+			args: args{
+				r: strings.NewReader(`pid,burst,arrival
+P0,five,0`),
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "JSON",
+			args: args{
+				r: strings.NewReader(`
+[
+	{"processID": "P0", "burstDuration": 5, "arrivalTime": 0, "priority": 2, "deadline": 12, "user": "ana", "threshold": 1},
+	{"processID": "P1", "burstDuration": 9, "arrivalTime": 3, "suspensions": "5-8", "cpus": "0;2", "bandwidth": "2/5"}
+]`),
+			},
+			want: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2, SoftDeadline: 12, User: "ana", PreemptionThreshold: 1},
+				{
+					ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Suspensions: []Window{{Start: 5, Stop: 8}},
+					Affinity: 0b101, Quota: 2, Period: 5,
+				},
+			},
+		},
+		{
+			name: "comments and blank lines",
+			args: args{
+				r: strings.NewReader(`# two CPU-bound processes arriving together
+ProcessID,Burst Duration,Arrival Time,Priority
+
+P0,5,0,2
+# P1 is the longer
+P1,9,0,1
+`),
+			},
+			want: []Process{
+				{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+				{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 9, Priority: 1},
+			},
+		},
+		{
+			name: "commented JSON",
+			args: args{
+				r: strings.NewReader(`# a single process
+[{"processID": "P0", "burstDuration": 5, "user": "#root"}]`),
+			},
+			want: []Process{
+				{ProcessID: "P0", BurstDuration: 5, User: "#root"},
+			},
+		},
+		{
+			name:    "JSON with an unknown field",
+			args:    args{r: strings.NewReader(`[{"processID": "P0", "burstDuration": 5, "burst": 12}]`)},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "JSON as a Process is written",
+			args: args{
+				r: strings.NewReader(`[{
+	"processID": "P0", "arrivalTime": 1, "burstDuration": 9, "priority": 2, "softDeadline": 12,
+	"suspensions": [{"start": 5, "stop": 8}], "affinity": 5, "quota": 2, "period": 5,
+	"preemptionThreshold": 1, "ioBursts": [{"after": 3, "duration": 4}]
+}]`),
+			},
+			want: []Process{{
+				ProcessID: "P0", ArrivalTime: 1, BurstDuration: 9, Priority: 2, SoftDeadline: 12,
+				Suspensions: []Window{{Start: 5, Stop: 8}}, Affinity: 0b101, Quota: 2, Period: 5,
+				PreemptionThreshold: 1, IOBursts: []IOBurst{{After: 3, Duration: 4}},
+			}},
+		},
+		{
+			name:    "JSON with both forms of a field",
+			args:    args{r: strings.NewReader(`[{"processID": "P0", "burstDuration": 5, "deadline": 3, "softDeadline": 12}]`)},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "JSON with backwards suspension windows",
+			args:    args{r: strings.NewReader(`[{"processID": "P0", "burstDuration": 5, "suspensions": [{"start": 5, "stop": 2}]}]`)},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "JSON with the internal original ID",
+			args:    args{r: strings.NewReader(`[{"processID": "P0", "burstDuration": 5, "originalID": "P9"}]`)},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "JSON with a backwards suspension",
+			args:    args{r: strings.NewReader(`[{"processID": "P0", "burstDuration": 5, "suspensions": "5-2"}]`)},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "bad affinity",
+			args: args{
+				r: strings.NewReader(`ProcessID,Burst Duration,Arrival Time,Priority,Kill At,Suspensions,CPU Limit,Class,Deadline,User,CPUs
+P0,5,0,2,,,,,,,0;x`),
+			},
+			wantErr: ErrInvalidArgs,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -128,6 +374,14 @@ P2,6,3,3`),
 	}
 }
 
+func Test_loadProcesses_lineNumbers(t *testing.T) {
+	t.Parallel()
+	_, err := loadProcesses(strings.NewReader("# a bad burst\npid,burst,arrival\n\nP0,5,0\nP1,x,0\n"))
+	if err == nil || !strings.HasPrefix(err.Error(), "line 5: ") {
+		t.Errorf("error = %v, want it to point at line 5", err)
+	}
+}
+
 func loadFixture(t *testing.T, p ...string) string {
 	b, err := os.ReadFile(path.Join(p...))
 	if err != nil {
@@ -208,6 +462,60 @@ func Test_openProcessingFile1(t *testing.T) {
 	}
 }
 
+func Test_readData(t *testing.T) {
+	t.Parallel()
+	tmpFile, err := os.CreateTemp(t.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = tmpFile.Close() })
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantName string
+		wantErr  bool
+	}{
+		{
+			name:     "dash reads stdin",
+			args:     []string{"-"},
+			wantName: "stdin",
+		},
+		{
+			name:     "file",
+			args:     []string{tmpFile.Name()},
+			wantName: tmpFile.Name(),
+		},
+		{
+			name:    "bad file",
+			args:    []string{"bad_file_name"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r, name, err := readData(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readData() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if c, ok := r.(io.Closer); ok && r != os.Stdin {
+				t.Cleanup(func() { _ = c.Close() })
+			}
+			if name != tt.wantName {
+				t.Errorf("readData() name = %q, want %q", name, tt.wantName)
+			}
+			if (name == "stdin") != (r == os.Stdin) {
+				t.Errorf("readData() reader = %v for %q", r, name)
+			}
+		})
+	}
+}
+
 func Test_outputGantt(t *testing.T) {
 	t.Parallel()
 	type args struct {
@@ -264,3 +572,169 @@ func Test_outputGantt(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputGanttFunc(t *testing.T) {
+	t.Parallel()
+	gantt := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 3},
+		{PID: "B", Start: 5, Stop: 6},
+	}
+	want := `Gantt schedule
+|  A:3  |  -  |  B:1  |
+0       3       5       6
+
+`
+	w := &bytes.Buffer{}
+	OutputGanttFunc(w, gantt, func(slice TimeSlice) string {
+		return fmt.Sprintf("%s:%d", slice.PID, slice.Stop-slice.Start)
+	})
+	if diff := cmp.Diff(want, w.String()); diff != "" {
+		t.Errorf(diff)
+	}
+	if gantt[1].PID != "B" {
+		t.Errorf("gantt was modified: %v", gantt)
+	}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	procs := path.Join(dir, "procs.csv")
+	if err := os.WriteFile(procs, []byte("ProcessID,Burst Duration,Arrival Time,Priority\nP0,5,0,2\nP1,9,3,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	writeBatch := func(name, batch string) string {
+		name = path.Join(dir, name)
+		if err := os.WriteFile(name, []byte(batch), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return name
+	}
+	elapsed := regexp.MustCompile(`elapsed=\d+ms`)
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantResult string
+	}{
+		{
+			name:       "success",
+			args:       []string{"-batch", writeBatch("ok.txt", "fcfs "+procs+"\nrr "+procs+"\n")},
+			wantResult: "RESULT algos=2 procs=4 warnings=0 errors=0 elapsed=?ms",
+		},
+		{
+			name:       "partial failure",
+			args:       []string{"-batch", writeBatch("partial.txt", "sjf "+procs+"\nrr "+path.Join(dir, "missing.csv")+"\n")},
+			wantCode:   1,
+			wantResult: "RESULT algos=1 procs=2 warnings=0 errors=1 elapsed=?ms",
+		},
+		{
+			name:       "dry run",
+			args:       []string{"-dry-run", "-batch", writeBatch("dry.txt", "fcfs "+procs+"\nrr "+procs+"\n")},
+			wantResult: "RESULT algos=0 procs=0 warnings=0 errors=0 elapsed=?ms",
+		},
+		{
+			name:       "dry run failures",
+			args:       []string{"-dry-run", "-batch", writeBatch("dry-bad.txt", "fcfs missing.csv\nrr "+procs+"\nnonesuch "+procs+"\n")},
+			wantCode:   1,
+			wantResult: "RESULT algos=0 procs=0 warnings=0 errors=2 elapsed=?ms",
+		},
+		{
+			name:       "optimal",
+			args:       []string{"-optimal", "-quantum", "3", procs},
+			wantResult: fmt.Sprintf("RESULT algos=%d procs=2 warnings=0 errors=0 elapsed=?ms", len(schedulerSpecs)),
+		},
+		{
+			name:       "optimal with a scheduler",
+			args:       []string{"-optimal", "-rr", procs},
+			wantCode:   1,
+			wantResult: "RESULT algos=0 procs=0 warnings=0 errors=1 elapsed=?ms",
+		},
+		{
+			name:       "bad flags",
+			args:       []string{"-fcfs", "-sjf"},
+			wantCode:   1,
+			wantResult: "RESULT algos=0 procs=0 warnings=0 errors=1 elapsed=?ms",
+		},
+		{
+			name: "quiet",
+			args: []string{"-quiet", "-batch", writeBatch("quiet.txt", "fcfs "+procs+"\n")},
+		},
+		{
+			name:     "quiet failure",
+			args:     []string{"-quiet", "-batch", path.Join(dir, "missing.txt")},
+			wantCode: 1,
+		},
+		{
+			name:     "quiet with bad flags",
+			args:     []string{"-quiet", "-fcfs", "-sjf"},
+			wantCode: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var stdout, stderr bytes.Buffer
+			if code := run("scheduler", tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Errorf("run() = %d, want %d; stderr:\n%s", code, tt.wantCode, stderr.String())
+			}
+			lines := strings.Split(strings.TrimSuffix(stderr.String(), "\n"), "\n")
+			last := elapsed.ReplaceAllString(lines[len(lines)-1], "elapsed=?ms")
+			if tt.wantResult == "" {
+				if strings.HasPrefix(last, "RESULT ") {
+					t.Errorf("-quiet still printed a summary:\n%s", stderr.String())
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.wantResult, last); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestOutputResultSorted(t *testing.T) {
+	t.Parallel()
+	result := FCFSSchedule(io.Discard, "fcfs", []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2}, // waits 0
+		{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1}, // waits 2
+		{ProcessID: "P2", ArrivalTime: 6, BurstDuration: 6, Priority: 3}, // waits 8
+	})
+	tests := []struct {
+		name string
+		less func(a, b ProcessResult) bool
+		want []string
+	}{
+		{
+			name: "input order",
+			want: []string{"P0", "P1", "P2"},
+		},
+		{
+			name: "wait descending",
+			less: func(a, b ProcessResult) bool { return a.Wait > b.Wait },
+			want: []string{"P2", "P1", "P0"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			OutputResultSorted(&w, result, tt.less)
+			var got []string
+			for _, line := range strings.Split(w.String(), "\n") {
+				if id, ok := strings.CutPrefix(line, "| P"); ok {
+					got = append(got, "P"+strings.Fields(id)[0])
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf(diff)
+			}
+			if result.Processes[0].ProcessID != "P0" {
+				t.Errorf("sorting reordered the result's processes: %v", result.Processes)
+			}
+		})
+	}
+}
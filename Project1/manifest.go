@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"slices"
+	"strings"
+)
+
+// Manifest records everything needed to reproduce a result from its output alone.
+type Manifest struct {
+	Input       string            `json:"input"`
+	InputSHA256 string            `json:"inputSHA256"`
+	Algorithm   string            `json:"algorithm"`
+	Options     map[string]string `json:"options"`
+	Build       string            `json:"build"`
+}
+
+// NewManifest describes a run of scheduler over the named input with the given SHA-256 digest.
+// Options holds the effective value of every flag in flagSet, defaulted or not, except the
+// scheduler selection flags.
+func NewManifest(input string, digest []byte, scheduler Scheduler, flagSet *flag.FlagSet) Manifest {
+	options := make(map[string]string)
+	flagSet.VisitAll(func(f *flag.Flag) {
+		if _, err := parseScheduler(f.Name); err == nil {
+			return
+		}
+		options[f.Name] = f.Value.String()
+	})
+
+	return Manifest{
+		Input:       input,
+		InputSHA256: hex.EncodeToString(digest),
+		Algorithm:   scheduler.Name(),
+		Options:     options,
+		Build:       buildVersion(),
+	}
+}
+
+// buildVersion identifies the binary from its embedded build info.
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s %s %s", info.Main.Path, info.Main.Version, info.GoVersion)
+}
+
+func outputManifest(w io.Writer, m *Manifest) {
+	if m == nil {
+		return
+	}
+	names := make([]string, 0, len(m.Options))
+	for name := range m.Options {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	options := make([]string, len(names))
+	for i, name := range names {
+		options[i] = fmt.Sprintf("%s=%q", name, m.Options[name])
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "Manifest")
+	_, _ = fmt.Fprintf(w, "Input: %s (sha256 %s)\n", m.Input, m.InputSHA256)
+	_, _ = fmt.Fprintf(w, "Algorithm: %s\n", m.Algorithm)
+	_, _ = fmt.Fprintf(w, "Options: %s\n", strings.Join(options, " "))
+	_, _ = fmt.Fprintf(w, "Build: %s\n", m.Build)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func manifestFor(t *testing.T, args ...string) Manifest {
+	t.Helper()
+	flagSet := flag.NewFlagSet("manifest", flag.ContinueOnError)
+	flagSet.SetOutput(io.Discard)
+	registerFlags(flagSet, &cliConfig{})
+	if err := flagSet.Parse(args); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return NewManifest("example_processes.csv", []byte{0xab}, rr, flagSet)
+}
+
+func TestNewManifest(t *testing.T) {
+	t.Parallel()
+	defaults := manifestFor(t, "-rr")
+	if defaults.Algorithm != "rr" {
+		t.Errorf("Algorithm = %q, want rr", defaults.Algorithm)
+	}
+	if defaults.InputSHA256 != "ab" {
+		t.Errorf("InputSHA256 = %q, want ab", defaults.InputSHA256)
+	}
+	if _, ok := defaults.Options["rr"]; ok {
+		t.Error("Options include the scheduler selection flag")
+	}
+	if got := defaults.Options["quantum"]; got != "4" {
+		t.Errorf("defaulted quantum = %q, want 4", got)
+	}
+
+	changed := manifestFor(t, "-rr", "-quantum", "3")
+	if changed.Options["quantum"] != "3" {
+		t.Errorf("quantum = %q, want 3", changed.Options["quantum"])
+	}
+	if defaults.Options["quantum"] == changed.Options["quantum"] {
+		t.Error("changing an option did not change the manifest")
+	}
+}
+
+func TestManifest_hashesInput(t *testing.T) {
+	t.Parallel()
+	raw, err := os.ReadFile("example_processes.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := sha256.Sum256(raw)
+
+	var out bytes.Buffer
+	if _, err := runBatchLine(&out, "fcfs", "example_processes.csv"); err != nil {
+		t.Fatalf("runBatchLine() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "sha256 "+hex.EncodeToString(want[:])) {
+		t.Errorf("output is missing the input hash:\n%s", out.String())
+	}
+}
+
+func TestManifest_saved(t *testing.T) {
+	t.Parallel()
+	result := FCFSSchedule(io.Discard, "fcfs", []Process{{ProcessID: "P0", BurstDuration: 2}})
+	manifest := manifestFor(t, "-fcfs")
+	result.Manifest = &manifest
+
+	var w bytes.Buffer
+	if err := SaveResult(&w, result); err != nil {
+		t.Fatalf("SaveResult() error = %v", err)
+	}
+	var saved struct {
+		Result map[string]json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(w.Bytes(), &saved); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := saved.Result["manifest"]; !ok {
+		t.Errorf("saved result has no manifest: %s", w.String())
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Makespan is the total length of a schedule: the latest Stop of any slice,
+// including trailing idle slices. An empty gantt has a makespan of 0.
+func Makespan(gantt []TimeSlice) int64 {
+	var makespan int64
+	for _, slice := range gantt {
+		makespan = max(makespan, slice.Stop)
+	}
+
+	return makespan
+}
+
+// SoftDeadlineScore sums how late processes completed past their soft deadline
+// (max(0, completion - SoftDeadline)) and counts the late ones. Processes without a
+// soft deadline are ignored.
+func SoftDeadlineScore(processes []ProcessResult) (tardiness int64, late int) {
+	for _, p := range processes {
+		if p.SoftDeadline == 0 || p.Completion <= p.SoftDeadline {
+			continue
+		}
+		tardiness += p.Completion - p.SoftDeadline
+		late++
+	}
+
+	return tardiness, late
+}
+
+// MissedDeadlines lists the IDs of the processes that completed past their soft deadline, the
+// ones SoftDeadlineScore counts as late.
+func MissedDeadlines(processes []ProcessResult) []string {
+	var missed []string
+	for _, p := range processes {
+		if p.SoftDeadline != 0 && p.Completion > p.SoftDeadline {
+			missed = append(missed, p.ProcessID)
+		}
+	}
+
+	return missed
+}
+
+// ContextSwitches counts the times gantt hands the CPU from one process to another, idle gaps
+// in between or not.
+func ContextSwitches(gantt []TimeSlice) int {
+	var switches int
+	for i := 1; i < len(gantt); i++ {
+		if gantt[i].PID != gantt[i-1].PID {
+			switches++
+		}
+	}
+
+	return switches
+}
+
+// CompletedOnly is r with its averages and throughput taken over the processes that completed,
+// and the IDs of the rest in Incomplete. It suits schedules cut short, like a cancelled
+// FCFSScheduleCtx run, where unfinished processes would otherwise count as zero wait and
+// turnaround. Aborted and CPU-limited processes are already left out of the averages and aren't listed.
+func (r ScheduleResult) CompletedOnly() ScheduleResult {
+	var (
+		metrics        MetricsAccumulator
+		lastCompletion int64
+	)
+	r.Incomplete = nil
+	for _, p := range r.Processes {
+		if p.Aborted || p.Limited {
+			continue
+		}
+		if p.Remaining > 0 {
+			r.Incomplete = append(r.Incomplete, p.ProcessID)
+			continue
+		}
+		metrics.AddCompletion(p.Wait, p.Turnaround)
+		lastCompletion = max(lastCompletion, p.Completion)
+	}
+
+	averages := metrics.Result()
+	r.AverageWait, r.AverageTurnaround, r.Throughput = averages.AverageWait, averages.AverageTurnaround, 0
+	if lastCompletion > 0 {
+		r.Throughput = float64(averages.Completed) / float64(lastCompletion)
+	}
+
+	return r
+}
+
+// MetricsAccumulator computes average wait and turnaround online, one completion at a time,
+// without keeping per-process rows. The zero value is ready to use.
+type MetricsAccumulator struct {
+	completed       int64
+	totalWait       int64
+	totalTurnaround int64
+}
+
+// StreamingMetrics are the averages over every completion added to a MetricsAccumulator.
+type StreamingMetrics struct {
+	Completed         int64
+	AverageWait       float64
+	AverageTurnaround float64
+}
+
+// AddCompletion records a completed process' wait and turnaround.
+func (m *MetricsAccumulator) AddCompletion(wait, turnaround int64) {
+	m.completed++
+	m.totalWait += wait
+	m.totalTurnaround += turnaround
+}
+
+// Result is the averages so far; both are 0 before the first completion.
+func (m *MetricsAccumulator) Result() StreamingMetrics {
+	if m.completed == 0 {
+		return StreamingMetrics{}
+	}
+	return StreamingMetrics{
+		Completed:         m.completed,
+		AverageWait:       float64(m.totalWait) / float64(m.completed),
+		AverageTurnaround: float64(m.totalTurnaround) / float64(m.completed),
+	}
+}
+
+// AveragesApproxEqual reports whether got's average wait, average turnaround and throughput are
+// each within tol of want's. AveragesDiff says which ones aren't.
+func AveragesApproxEqual(got, want ScheduleResult, tol float64) bool {
+	return AveragesDiff(got, want, tol) == ""
+}
+
+// AveragesDiff describes each average of got further than tol from want's, one per line, or
+// returns "" when they all agree. Comparing within a tolerance keeps tests from depending on
+// the order a float average was summed in.
+func AveragesDiff(got, want ScheduleResult, tol float64) string {
+	averages := []struct {
+		name      string
+		got, want float64
+	}{
+		{"average wait", got.AverageWait, want.AverageWait},
+		{"average turnaround", got.AverageTurnaround, want.AverageTurnaround},
+		{"throughput", got.Throughput, want.Throughput},
+	}
+	var diffs []string
+	for _, a := range averages {
+		// written so that NaN is never within tolerance.
+		if !(math.Abs(a.got-a.want) <= tol) {
+			diffs = append(diffs, fmt.Sprintf("%s: got %g, want %g ± %g", a.name, a.got, a.want, tol))
+		}
+	}
+	return strings.Join(diffs, "\n")
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMakespan(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  int64
+	}{
+		{
+			name: "empty",
+			want: 0,
+		},
+		{
+			name: "consecutive",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 5},
+				{PID: "P1", Start: 5, Stop: 14},
+				{PID: "P2", Start: 14, Stop: 20},
+			},
+			want: 20,
+		},
+		{
+			name: "trailing idle",
+			gantt: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 5},
+				{PID: "IDLE", Start: 5, Stop: 9},
+			},
+			want: 9,
+		},
+		{
+			name: "unordered",
+			gantt: []TimeSlice{
+				{PID: "P1", Start: 7, Stop: 12},
+				{PID: "P0", Start: 2, Stop: 7},
+			},
+			want: 12,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := Makespan(tt.gantt); got != tt.want {
+				t.Errorf("Makespan() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContextSwitches(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []TimeSlice
+		want  int
+	}{
+		{name: "empty", want: 0},
+		{name: "one process", gantt: []TimeSlice{{PID: "A", Start: 0, Stop: 4}}, want: 0},
+		{
+			name: "idle gap without a switch",
+			gantt: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 2},
+				{PID: "A", Start: 5, Stop: 6},
+			},
+			want: 0,
+		},
+		{
+			name: "back and forth",
+			gantt: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 2},
+				{PID: "B", Start: 2, Stop: 3},
+				{PID: "A", Start: 4, Stop: 6},
+			},
+			want: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ContextSwitches(tt.gantt); got != tt.want {
+				t.Errorf("ContextSwitches() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSoftDeadlineScore(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, SoftDeadline: 6},  // completes at 5, on time
+		{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, SoftDeadline: 10}, // completes at 14, 4 late
+		{ProcessID: "P2", ArrivalTime: 6, BurstDuration: 6, SoftDeadline: 17}, // completes at 20, 3 late
+		{ProcessID: "P3", ArrivalTime: 6, BurstDuration: 1},                   // no soft deadline
+	}
+	result := FCFSSchedule(io.Discard, "fcfs", processes)
+	if result.TotalTardiness != 7 {
+		t.Errorf("TotalTardiness = %d, want 7", result.TotalTardiness)
+	}
+	if result.LateJobs != 2 {
+		t.Errorf("LateJobs = %d, want 2", result.LateJobs)
+	}
+	if diff := cmp.Diff([]string{"P1", "P2"}, MissedDeadlines(result.Processes)); diff != "" {
+		t.Errorf(diff)
+	}
+
+	onTime := FCFSSchedule(io.Discard, "fcfs", processes[:1])
+	if onTime.TotalTardiness != 0 || onTime.LateJobs != 0 {
+		t.Errorf("on time score = %d, %d, want 0, 0", onTime.TotalTardiness, onTime.LateJobs)
+	}
+}
+
+func TestScheduleResult_CompletedOnly(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 8},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 6},
+	}
+	// capped after two processes, so C never runs.
+	ctx := &countdownContext{Context: context.Background(), n: 2}
+	capped, err := FCFSScheduleCtx(ctx, io.Discard, "capped", processes)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want %v", err, context.Canceled)
+	}
+
+	got := capped.CompletedOnly()
+	want := ScheduleResult{
+		// A waits 0 and turns around in 10; B waits 9 and turns around in 17.
+		AverageWait:       4.5,
+		AverageTurnaround: 13.5,
+		Throughput:        2.0 / 18,
+		Incomplete:        []string{"C"},
+	}
+	if diff := AveragesDiff(got, want, 1e-9); diff != "" {
+		t.Errorf(diff)
+	}
+	if diff := cmp.Diff(want.Incomplete, got.Incomplete); diff != "" {
+		t.Errorf(diff)
+	}
+	if capped.AverageWait >= got.AverageWait {
+		t.Errorf("all-process average wait %.2f should be dragged below %.2f", capped.AverageWait, got.AverageWait)
+	}
+
+	var w bytes.Buffer
+	outputResult(&w, got)
+	if !strings.Contains(w.String(), "Incomplete: 1 (C)") {
+		t.Errorf("output is missing the incomplete processes:\n%s", w.String())
+	}
+}
+
+func TestMetricsAccumulator(t *testing.T) {
+	t.Parallel()
+	var empty MetricsAccumulator
+	if diff := cmp.Diff(StreamingMetrics{}, empty.Result()); diff != "" {
+		t.Errorf(diff)
+	}
+
+	processes := exponentialWorkload(500, 10, 8, 3)
+	for _, result := range []ScheduleResult{
+		FCFSSchedule(io.Discard, "fcfs", processes),
+		SJFSchedule(io.Discard, "sjf", processes),
+		RRSchedule(io.Discard, "rr", processes),
+	} {
+		var metrics MetricsAccumulator
+		for _, p := range result.Processes {
+			metrics.AddCompletion(p.Wait, p.Turnaround)
+		}
+		want := StreamingMetrics{
+			Completed:         int64(len(processes)),
+			AverageWait:       result.AverageWait,
+			AverageTurnaround: result.AverageTurnaround,
+		}
+		if diff := cmp.Diff(want, metrics.Result()); diff != "" {
+			t.Errorf("%s: %s", result.Title, diff)
+		}
+	}
+}
+
+func TestAveragesApproxEqual(t *testing.T) {
+	t.Parallel()
+	want := ScheduleResult{AverageWait: 3.5, AverageTurnaround: 10, Throughput: 0.15}
+	tests := []struct {
+		name     string
+		got      ScheduleResult
+		wantDiff string
+	}{
+		{
+			name: "exact",
+			got:  want,
+		},
+		{
+			name: "just inside",
+			got:  ScheduleResult{AverageWait: 3.59, AverageTurnaround: 9.91, Throughput: 0.24},
+		},
+		{
+			name:     "wait just outside",
+			got:      ScheduleResult{AverageWait: 3.61, AverageTurnaround: 10, Throughput: 0.15},
+			wantDiff: "average wait: got 3.61, want 3.5 ± 0.1",
+		},
+		{
+			name:     "turnaround and throughput just outside",
+			got:      ScheduleResult{AverageWait: 3.5, AverageTurnaround: 9.89, Throughput: 0.26},
+			wantDiff: "average turnaround: got 9.89, want 10 ± 0.1\nthroughput: got 0.26, want 0.15 ± 0.1",
+		},
+		{
+			name:     "NaN",
+			got:      ScheduleResult{AverageWait: math.NaN(), AverageTurnaround: 10, Throughput: 0.15},
+			wantDiff: "average wait: got NaN, want 3.5 ± 0.1",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.wantDiff, AveragesDiff(tt.got, want, 0.1)); diff != "" {
+				t.Errorf(diff)
+			}
+			if got := AveragesApproxEqual(tt.got, want, 0.1); got != (tt.wantDiff == "") {
+				t.Errorf("AveragesApproxEqual() = %t, want %t", got, tt.wantDiff == "")
+			}
+		})
+	}
+}
@@ -0,0 +1,823 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MulticoreSchedule runs processes on cpus CPUs pulling from one global ready queue: every tick,
+// each idle CPU takes the process policy picks, fcfs the earliest queued to completion, sjf the
+// least burst left for a tick at a time, and rr the earliest queued for quantum ticks (defaultQuantum
+// below 1). So with one CPU it makes the same schedule as FCFSSchedule, SJFSchedule or
+// RRQuantumSchedule. A process picked again goes back to the CPU it last ran on if that's idle, and
+// a process with an Affinity only runs on the CPUs it allows. Kill times, I/O bursts, CPU limits
+// and quotas are honoured as Simulate does, but a process suspended while it runs leaves its CPU for
+// the back of the queue, where FCFSSchedule would hold the CPU for it. The result's CPUs holds each
+// CPU's gantt, and it writes one gantt row per CPU with their utilization, the load imbalance and
+// the migrations, and for pinned processes the average wait without affinity.
+func MulticoreSchedule(w io.Writer, title string, processes []Process, cpus int, policy Scheduler, quantum int64) (ScheduleResult, error) {
+	cfg, err := newMulticoreConfig(cpus, policy, quantum)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	result, _, err := runMulticore(title, processes, cfg)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	outputResult(w, result)
+
+	return result, nil
+}
+
+// PartitionedSchedule is MulticoreSchedule with a runqueue per CPU instead of a global one: an
+// arrival joins the runqueue of the CPU with the fewest processes that it's allowed on, the lowest
+// numbered on a tie, and each CPU only runs the processes of its own runqueue, picked by policy. A
+// CPU that idles with an empty runqueue steals the last process it's allowed to run that's queued
+// on the longest other runqueue, the lowest numbered on a tie, if that holds at least steal waiting
+// processes; 0 turns stealing off. Stealing evens out the load at the cost of migrations, so it
+// writes how many processes were stolen as well as the migrations.
+func PartitionedSchedule(w io.Writer, title string, processes []Process, cpus int, policy Scheduler, quantum int64, steal int) (ScheduleResult, error) {
+	cfg, err := newMulticoreConfig(cpus, policy, quantum)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	if steal < 0 {
+		return ScheduleResult{}, fmt.Errorf("%w: steal threshold %d is negative", ErrInvalidArgs, steal)
+	}
+	cfg.partitioned, cfg.steal = true, steal
+	result, steals, err := runMulticore(title, processes, cfg)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	result.Notes = []string{fmt.Sprintf("Work steals: %d", steals)}
+	outputResult(w, result)
+
+	return result, nil
+}
+
+// Bin-packing strategies of PackProcesses.
+const (
+	PackFirstFit = "first-fit"
+	PackBestFit  = "best-fit"
+	PackWorstFit = "worst-fit"
+)
+
+// PackProcesses assigns every process to one of cpus CPUs, in input order, treating the CPUs as bins
+// of the total burst divided evenly between them, rounded up. First-fit takes the lowest numbered
+// CPU the burst still fits on and best-fit the one it fills the most; both fall back to the least
+// loaded CPU when it fits nowhere. Worst-fit always takes the least loaded CPU. Only the CPUs a
+// process's Affinity allows are considered, and ties go to the lowest numbered CPU. It returns the
+// CPU of each process by ID.
+func PackProcesses(processes []Process, cpus int, strategy string) (map[string]int, error) {
+	if cpus < 1 {
+		return nil, fmt.Errorf("%w: %d CPUs", ErrInvalidArgs, cpus)
+	}
+	if !slices.Contains([]string{PackFirstFit, PackBestFit, PackWorstFit}, strategy) {
+		return nil, fmt.Errorf("%w: unknown bin-packing strategy %q", ErrInvalidArgs, strategy)
+	}
+	if err := checkAffinity(processes, cpus); err != nil {
+		return nil, err
+	}
+	var total int64
+	for _, p := range processes {
+		total += p.BurstDuration
+	}
+	capacity := (total + int64(cpus) - 1) / int64(cpus)
+
+	load := make([]int64, cpus)
+	assignment := make(map[string]int, len(processes))
+	for _, p := range processes {
+		least, fit := -1, -1
+		for cpu := range load {
+			if !p.allowedOn(cpu) {
+				continue
+			}
+			if least < 0 || load[cpu] < load[least] {
+				least = cpu
+			}
+			if load[cpu]+p.BurstDuration > capacity {
+				continue
+			}
+			switch {
+			case fit < 0:
+				fit = cpu
+			case strategy == PackBestFit && load[cpu] > load[fit]:
+				fit = cpu
+			}
+		}
+		cpu := least
+		if strategy != PackWorstFit && fit >= 0 {
+			cpu = fit
+		}
+		load[cpu] += p.BurstDuration
+		assignment[p.ProcessID] = cpu
+	}
+
+	return assignment, nil
+}
+
+// PackedSchedule is PartitionedSchedule with the processes statically assigned to CPUs by
+// PackProcesses with strategy instead of at arrival, and no stealing, so it can be set against
+// MulticoreSchedule's global queue on the same workload. It writes which processes each CPU got.
+func PackedSchedule(w io.Writer, title string, processes []Process, cpus int, policy Scheduler, quantum int64, strategy string) (ScheduleResult, error) {
+	cfg, err := newMulticoreConfig(cpus, policy, quantum)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	assignment, err := PackProcesses(processes, cpus, strategy)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	cfg.partitioned, cfg.pack, cfg.assignment = true, strategy, assignment
+	result, _, err := runMulticore(title, processes, cfg)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	result.Notes = noteLines(func(w io.Writer) { outputAssignment(w, processes, cpus, assignment) })
+	outputResult(w, result)
+
+	return result, nil
+}
+
+// GangSchedule is gang scheduling on cpus CPUs: the processes of a Group are co-scheduled, all
+// the ready ones running at once on CPUs of their own for the same time slots, as cooperating
+// threads that would otherwise spin waiting on each other must be. Time is cut into slots of
+// quantum ticks (defaultQuantum below 1), and a slot starts once every CPU is free. The gangs and
+// ungrouped processes then take the CPUs in ready queue order, round-robin, each only if every
+// one of its ready members gets a CPU, so smaller ones behind a gang that doesn't fit fill the rest.
+// A process that completes, blocks or is suspended early leaves its CPU idle until the slot ends.
+// A gang can never have more processes than there are CPUs, and its processes may not be pinned
+// by an Affinity. It writes one gantt row per CPU, on which a gang's slices line up.
+func GangSchedule(w io.Writer, title string, processes []Process, cpus int, quantum int64) (ScheduleResult, error) {
+	cfg, err := newMulticoreConfig(cpus, rr, quantum)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	members := make(map[string]int)
+	for _, p := range processes {
+		if p.Group == "" {
+			continue
+		}
+		if p.Affinity != 0 {
+			return ScheduleResult{}, fmt.Errorf("%w: process %q of gang %q is pinned by its affinity", ErrUnsupportedOption, p.ProcessID, p.Group)
+		}
+		if members[p.Group]++; members[p.Group] > cpus {
+			return ScheduleResult{}, fmt.Errorf("%w: gang %q has more processes than the %d CPUs", ErrInvalidArgs, p.Group, cpus)
+		}
+	}
+	cfg.gang = true
+	result, _, err := runMulticore(title, processes, cfg)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	outputResult(w, result)
+
+	return result, nil
+}
+
+// HeterogeneousSchedule is MulticoreSchedule on CPUs of different speeds, like the big and LITTLE
+// cores of a phone: CPU i does speeds[i] of a process's burst every tick, so a process completes
+// sooner on a faster one, its last tick rounded up. A process with more burst left than the median
+// burst of processes is placed on the fastest idle CPU, and a shorter one on the slowest, keeping
+// the fast CPUs for the long processes; a process returns to the CPU it last ran on only if that's
+// as fast. The result's CPUWork holds the burst each CPU completed, which it writes with the
+// utilization.
+func HeterogeneousSchedule(w io.Writer, title string, processes []Process, speeds []int64, policy Scheduler, quantum int64) (ScheduleResult, error) {
+	cfg, err := newMulticoreConfig(len(speeds), policy, quantum)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	for cpu, speed := range speeds {
+		if speed < 1 {
+			return ScheduleResult{}, fmt.Errorf("%w: CPU %d has speed %d", ErrInvalidArgs, cpu, speed)
+		}
+	}
+	cfg.speeds, cfg.long = speeds, BurstPercentile(processes, 50)
+	result, _, err := runMulticore(title, processes, cfg)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+	outputResult(w, result)
+
+	return result, nil
+}
+
+// multicoreConfig is how multicoreGantts runs processes on several CPUs.
+type multicoreConfig struct {
+	cpus int
+	// pick, quantum and requeueFirst are the policy: requeueFirst puts a process back ahead of
+	// the arrivals the moment its quantum ends, and a quantum of 0 never ends.
+	pick         func(ready []Process, now int64) int
+	quantum      int64
+	requeueFirst bool
+	// partitioned gives every CPU a runqueue of its own. An arrival joins the one assignment gives
+	// it, which PackProcesses made with the pack strategy, or without one the allowed CPU with the
+	// fewest processes.
+	partitioned bool
+	pack        string
+	assignment  map[string]int
+	// steal is how many processes another runqueue must have waiting for an idle CPU with an
+	// empty one to steal from it; 0 never.
+	steal int
+	// speeds is the burst each CPU does per tick, 1 for every CPU if nil. With speeds, a process
+	// with more than long burst left goes to the fastest idle CPU and the rest to the slowest.
+	speeds []int64
+	long   int64
+	// gang co-schedules the processes of each Group in slots of quantum ticks (see GangSchedule).
+	gang bool
+}
+
+// speed is the burst cpu does per tick.
+func (cfg multicoreConfig) speed(cpu int) int64 {
+	if cfg.speeds == nil {
+		return 1
+	}
+	return cfg.speeds[cpu]
+}
+
+// newMulticoreConfig is the multicoreConfig of policy on cpus CPUs with one global queue.
+func newMulticoreConfig(cpus int, policy Scheduler, quantum int64) (multicoreConfig, error) {
+	if cpus < 1 {
+		return multicoreConfig{}, fmt.Errorf("%w: %d CPUs", ErrInvalidArgs, cpus)
+	}
+	cfg := multicoreConfig{cpus: cpus, pick: pickFirst}
+	switch policy {
+	case fcfs:
+	case sjf:
+		cfg.pick, cfg.quantum, cfg.requeueFirst = pickShortest, 1, true
+	case rr:
+		cfg.quantum = quantum
+		if quantum < 1 {
+			cfg.quantum = defaultQuantum
+		}
+	default:
+		return multicoreConfig{}, fmt.Errorf("%w: %v on several CPUs", ErrUnknownAlgorithm, policy)
+	}
+	return cfg, nil
+}
+
+// runMulticore runs processes as cfg says and returns the result and how many processes were
+// stolen. When any process is pinned by its Affinity, the result has the average wait of the same
+// run without affinity, and when cfg has speeds, the burst each CPU completed.
+func runMulticore(title string, processes []Process, cfg multicoreConfig) (ScheduleResult, int, error) {
+	if err := checkAffinity(processes, cfg.cpus); err != nil {
+		return ScheduleResult{}, 0, err
+	}
+	run := multicoreGantts(processes, cfg)
+	result := newMulticoreResult(title, processes, run)
+	if cfg.speeds != nil {
+		result.CPUWork = run.work
+	}
+	if slices.ContainsFunc(processes, func(p Process) bool { return p.Affinity != 0 }) {
+		unpinned := slices.Clone(processes)
+		for i := range unpinned {
+			unpinned[i].Affinity = 0
+		}
+		if cfg.pack != "" {
+			// packed again, as the affinity constrained the packing too.
+			cfg.assignment, _ = PackProcesses(unpinned, cfg.cpus, cfg.pack)
+		}
+		wait := newMulticoreResult(title, unpinned, multicoreGantts(unpinned, cfg)).AverageWait
+		result.UnpinnedWait = &wait
+	}
+
+	return result, run.steals, nil
+}
+
+// multicoreRun is what multicoreGantts did.
+type multicoreRun struct {
+	// gantts are each CPU's gantt.
+	gantts [][]TimeSlice
+	// steals is how many processes idle CPUs stole.
+	steals int
+	// work is the burst each CPU completed, completed the burst each process did.
+	work      []int64
+	completed map[string]int64
+	// throttled are the windows each process was held back at its Quota.
+	throttled map[string][]Window
+}
+
+// multicoreBlock is a process multicoreGantts holds back, at its Quota or on I/O, until a time,
+// with the runqueue it rejoins.
+type multicoreBlock struct {
+	process Process
+	queue   int
+	until   int64
+}
+
+// multicoreGantts runs processes a tick at a time as cfg says.
+func multicoreGantts(processes []Process, cfg multicoreConfig) multicoreRun {
+	var (
+		now      int64
+		done     int
+		steals   int
+		queues   = make([][]Process, 1)
+		running  = make([]*Process, cfg.cpus)
+		ranFor   = make([]int64, cfg.cpus)
+		gantts   = make([][]TimeSlice, cfg.cpus)
+		work     = make([]int64, cfg.cpus)
+		did      = make(map[string]int64, len(processes))
+		lastCPU  = make(map[string]int, len(processes))
+		arrivals = newArrivals(processes, make(map[string]bool, len(processes)))
+		// used is the CPU time of every process; window is the Period window each process with a
+		// Quota last ran in and quotaUsed how much of the quota it used there.
+		used      = make(map[string]int64, len(processes))
+		window    = make(map[string]int64)
+		quotaUsed = make(map[string]int64)
+		blocked   []multicoreBlock
+		windows   = make(map[string][]Window)
+	)
+	if cfg.partitioned {
+		queues = make([][]Process, cfg.cpus)
+	}
+	// queueOf is the runqueue cpu takes its processes from.
+	queueOf := func(cpu int) int {
+		if cfg.partitioned {
+			return cpu
+		}
+		return 0
+	}
+	admit := func() {
+		arrivals.admit(now, func(p Process) {
+			q := 0
+			switch {
+			case cfg.assignment != nil:
+				q = cfg.assignment[p.ProcessID]
+			case cfg.partitioned:
+				load := func(cpu int) int {
+					if running[cpu] != nil {
+						return len(queues[cpu]) + 1
+					}
+					return len(queues[cpu])
+				}
+				q = -1
+				for cpu := range queues {
+					if p.allowedOn(cpu) && (q < 0 || load(cpu) < load(q)) {
+						q = cpu
+					}
+				}
+			}
+			queues[q] = append(queues[q], p)
+		})
+	}
+	// requeue puts the processes whose quantum just ended back in their runqueue, in CPU order.
+	requeue := func() {
+		for cpu, p := range running {
+			if p != nil && ranFor[cpu] == cfg.quantum {
+				queues[queueOf(cpu)] = append(queues[queueOf(cpu)], *p)
+				running[cpu] = nil
+			}
+		}
+	}
+	dispatch := func(cpu int, p Process) {
+		running[cpu], ranFor[cpu] = &p, 0
+		lastCPU[p.ProcessID] = cpu
+	}
+	// runnable reports whether p may be dispatched now.
+	runnable := func(p Process) bool { return !p.suspendedAt(now) }
+	// kill takes the processes killed by now out of the runqueues, the CPUs and the blocked.
+	kill := func() {
+		killed := func(p Process) bool { return p.killedBy(now) }
+		for q := range queues {
+			n := len(queues[q])
+			queues[q] = slices.DeleteFunc(queues[q], killed)
+			done += n - len(queues[q])
+		}
+		n := len(blocked)
+		blocked = slices.DeleteFunc(blocked, func(b multicoreBlock) bool { return killed(b.process) })
+		done += n - len(blocked)
+		for cpu, p := range running {
+			if p != nil && killed(*p) {
+				running[cpu] = nil
+				done++
+			}
+		}
+	}
+
+	for done < len(processes) {
+		if cfg.requeueFirst {
+			requeue()
+			admit()
+		} else {
+			admit()
+			requeue()
+		}
+		// blocked processes rejoin their runqueue once their I/O completes or their throttled window
+		// ends, in the order they were blocked.
+		stillBlocked := blocked[:0]
+		for _, b := range blocked {
+			if b.until <= now {
+				queues[b.queue] = append(queues[b.queue], b.process)
+			} else {
+				stillBlocked = append(stillBlocked, b)
+			}
+		}
+		blocked = stillBlocked
+		kill()
+		// a process suspended while it runs leaves its CPU for the back of its runqueue.
+		for cpu, p := range running {
+			if p != nil && !runnable(*p) {
+				queues[queueOf(cpu)] = append(queues[queueOf(cpu)], *p)
+				running[cpu] = nil
+			}
+		}
+
+		if cfg.partitioned {
+			for cpu := range running {
+				if running[cpu] != nil {
+					continue
+				}
+				if len(queues[cpu]) == 0 && cfg.steal > 0 {
+					victim := slices.IndexFunc(queues, func(queue []Process) bool { return len(queue) >= cfg.steal })
+					for q, queue := range queues {
+						if victim >= 0 && len(queue) > len(queues[victim]) {
+							victim = q
+						}
+					}
+					if victim >= 0 {
+						allowed := func(p Process) bool { return p.allowedOn(cpu) && runnable(p) }
+						if last := lastIndexFunc(queues[victim], allowed); last >= 0 {
+							queues[cpu] = append(queues[cpu], queues[victim][last])
+							queues[victim] = slices.Delete(queues[victim], last, last+1)
+							steals++
+						}
+					}
+				}
+				var candidates []int
+				for i, p := range queues[cpu] {
+					if runnable(p) {
+						candidates = append(candidates, i)
+					}
+				}
+				if len(candidates) > 0 {
+					ready := make([]Process, len(candidates))
+					for j, i := range candidates {
+						ready[j] = queues[cpu][i]
+					}
+					i := candidates[cfg.pick(ready, now)]
+					dispatch(cpu, queues[cpu][i])
+					queues[cpu] = slices.Delete(queues[cpu], i, i+1)
+				}
+			}
+		} else if cfg.gang {
+			if countIdle(running) == cfg.cpus {
+				// a slot starts: every ungrouped process, or the ready processes of a Group together,
+				// takes CPUs in queue order if they're enough, each its last CPU if it's free.
+				var (
+					free   = make([]int, cfg.cpus)
+					placed = make(map[string]bool)
+					tried  = make(map[string]bool)
+				)
+				for cpu := range free {
+					free[cpu] = cpu
+				}
+				for _, p := range queues[0] {
+					if !runnable(p) || placed[p.ProcessID] || p.Group != "" && tried[p.Group] {
+						continue
+					}
+					gang := []Process{p}
+					if p.Group != "" {
+						tried[p.Group] = true
+						gang = slices.DeleteFunc(slices.Clone(queues[0]), func(q Process) bool { return q.Group != p.Group || !runnable(q) })
+					}
+					left := slices.Clone(free)
+					cpusOf := make([]int, len(gang))
+					fits := true
+					for i, q := range gang {
+						at := slices.IndexFunc(left, q.allowedOn)
+						if last, ok := lastCPU[q.ProcessID]; ok && slices.Contains(left, last) && q.allowedOn(last) {
+							at = slices.Index(left, last)
+						}
+						if at < 0 {
+							fits = false
+							break
+						}
+						cpusOf[i] = left[at]
+						left = slices.Delete(left, at, at+1)
+					}
+					if !fits {
+						continue
+					}
+					for i, q := range gang {
+						dispatch(cpusOf[i], q)
+						placed[q.ProcessID] = true
+					}
+					free = left
+				}
+				queues[0] = slices.DeleteFunc(queues[0], func(p Process) bool { return placed[p.ProcessID] })
+			}
+		} else {
+			type placement struct {
+				cpu int
+				p   Process
+			}
+			var (
+				picked []placement
+				idle   []int
+			)
+			for cpu := range running {
+				if running[cpu] == nil {
+					idle = append(idle, cpu)
+				}
+			}
+			// pick among the processes allowed on an idle CPU, which goes to the CPU it last ran
+			// on if it's idle, else the first idle CPU; with speeds, only to a CPU as fast as the
+			// fastest or slowest idle one, as it's long or not.
+			for len(idle) > 0 {
+				var candidates []int
+				for i, p := range queues[0] {
+					if runnable(p) && slices.ContainsFunc(idle, p.allowedOn) {
+						candidates = append(candidates, i)
+					}
+				}
+				if len(candidates) == 0 {
+					break
+				}
+				ready := make([]Process, len(candidates))
+				for j, i := range candidates {
+					ready[j] = queues[0][i]
+				}
+				i := candidates[cfg.pick(ready, now)]
+				p := queues[0][i]
+				queues[0] = slices.Delete(queues[0], i, i+1)
+				at := slices.IndexFunc(idle, p.allowedOn)
+				if cfg.speeds != nil {
+					for j, cpu := range idle {
+						faster := cfg.speed(cpu) > cfg.speed(idle[at])
+						if p.allowedOn(cpu) && cfg.speed(cpu) != cfg.speed(idle[at]) && faster == (p.BurstDuration > cfg.long) {
+							at = j
+						}
+					}
+				}
+				if last, ok := lastCPU[p.ProcessID]; ok && slices.Contains(idle, last) && p.allowedOn(last) &&
+					cfg.speed(last) == cfg.speed(idle[at]) {
+					at = slices.Index(idle, last)
+				}
+				picked = append(picked, placement{idle[at], p})
+				idle = slices.Delete(idle, at, at+1)
+			}
+			// a process that found its last CPU taken by one that hadn't run there swaps with it.
+			for i := range picked {
+				last, ok := lastCPU[picked[i].p.ProcessID]
+				if !ok || picked[i].cpu == last || cfg.speed(picked[i].cpu) != cfg.speed(last) {
+					continue
+				}
+				for j := range picked {
+					if other, ok := lastCPU[picked[j].p.ProcessID]; picked[j].cpu == last && (!ok || other != last) &&
+						picked[i].p.allowedOn(last) && picked[j].p.allowedOn(picked[i].cpu) {
+						picked[i].cpu, picked[j].cpu = picked[j].cpu, picked[i].cpu
+						break
+					}
+				}
+			}
+			for _, pl := range picked {
+				dispatch(pl.cpu, pl.p)
+			}
+		}
+
+		if countIdle(running) == cfg.cpus {
+			// idle until the next arrival, I/O completion, or end of a suspension or throttled window.
+			next := arrivals.next()
+			earliest := func(t int64) {
+				if t > now && (next < 0 || t < next) {
+					next = t
+				}
+			}
+			for _, b := range blocked {
+				earliest(b.until)
+			}
+			for _, queue := range queues {
+				for _, p := range queue {
+					earliest(p.resumeAt(now))
+				}
+			}
+			now = next
+			continue
+		}
+
+		for cpu, p := range running {
+			if p == nil {
+				continue
+			}
+			gantts[cpu] = appendTimeSlice(gantts[cpu], TimeSlice{PID: p.ProcessID, Start: now, Stop: now + 1})
+			ranFor[cpu]++
+			burst := min(cfg.speed(cpu), p.BurstDuration)
+			work[cpu] += burst
+			did[p.ProcessID] += burst
+			used[p.ProcessID]++
+			if p.Quota > 0 {
+				window[p.ProcessID], quotaUsed[p.ProcessID] = p.chargeQuota(window[p.ProcessID], quotaUsed[p.ProcessID], now, now+1)
+			}
+			switch p.BurstDuration -= burst; {
+			case p.BurstDuration == 0, p.limitedBy(used[p.ProcessID]):
+				running[cpu] = nil
+				done++
+			case p.ioAfter(used[p.ProcessID]) > 0, p.throttledBy(now+1, quotaUsed[p.ProcessID]):
+				until := now + 1 + p.ioAfter(used[p.ProcessID])
+				if p.throttledBy(now+1, quotaUsed[p.ProcessID]) {
+					end := (window[p.ProcessID] + 1) * p.Period
+					windows[p.ProcessID] = append(windows[p.ProcessID], Window{Start: now + 1, Stop: end})
+					until = max(until, end)
+				}
+				blocked = append(blocked, multicoreBlock{process: *p, queue: queueOf(cpu), until: until})
+				running[cpu] = nil
+			}
+		}
+		now++
+	}
+
+	return multicoreRun{gantts: gantts, steals: steals, work: work, completed: did, throttled: windows}
+}
+
+// newMulticoreResult is the result of the run's per-CPU gantts, its Gantt all their slices by start.
+// A process waited for as long as it wasn't running, which on CPUs of different speeds isn't its
+// turnaround less its burst.
+func newMulticoreResult(title string, processes []Process, run multicoreRun) ScheduleResult {
+	var gantt []TimeSlice
+	for _, g := range run.gantts {
+		gantt = append(gantt, g...)
+	}
+	slices.SortStableFunc(gantt, func(a, b TimeSlice) int { return cmp.Compare(a.Start, b.Start) })
+	result := newScheduleResultOf(title, processes, gantt, run.completed)
+	result.CPUs = run.gantts
+	for i := range result.Processes {
+		result.Processes[i].Throttled = run.throttled[result.Processes[i].ProcessID]
+	}
+
+	return result
+}
+
+// allowedOn reports whether p's Affinity lets it run on cpu.
+func (p Process) allowedOn(cpu int) bool {
+	return p.Affinity == 0 || cpu < 64 && p.Affinity&(1<<cpu) != 0
+}
+
+// checkAffinity rejects processes whose Affinity allows none of cpus CPUs.
+func checkAffinity(processes []Process, cpus int) error {
+	for _, p := range processes {
+		if p.Affinity == 0 {
+			continue
+		}
+		allowed := false
+		for cpu := 0; cpu < cpus && !allowed; cpu++ {
+			allowed = p.allowedOn(cpu)
+		}
+		if !allowed {
+			return fmt.Errorf("%w: process %q may run on none of the %d CPUs", ErrInvalidArgs, p.ProcessID, cpus)
+		}
+	}
+	return nil
+}
+
+// parseCPUList reads the CPUs a process may run on, written as "0;2", into an Affinity mask. An
+// empty string allows every CPU.
+func parseCPUList(s string) (uint64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	var mask uint64
+	for _, field := range strings.Split(s, ";") {
+		cpu, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || cpu < 0 || cpu >= 64 {
+			return 0, fmt.Errorf("%w: CPU %q is not a number from 0 to 63", ErrInvalidArgs, field)
+		}
+		mask |= 1 << cpu
+	}
+
+	return mask, nil
+}
+
+// formatCPUList writes an Affinity mask as parseCPUList reads it.
+func formatCPUList(mask uint64) string {
+	var cpus []string
+	for cpu := 0; cpu < 64; cpu++ {
+		if mask&(1<<cpu) != 0 {
+			cpus = append(cpus, strconv.Itoa(cpu))
+		}
+	}
+	return strings.Join(cpus, ";")
+}
+
+// lastIndexFunc is the index of the last element of s satisfying f, or -1.
+func lastIndexFunc[S ~[]E, E any](s S, f func(E) bool) int {
+	for i := len(s) - 1; i >= 0; i-- {
+		if f(s[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// countIdle is how many CPUs have no process running.
+func countIdle(running []*Process) int {
+	var idle int
+	for _, p := range running {
+		if p == nil {
+			idle++
+		}
+	}
+	return idle
+}
+
+// CPUUtilization is the share of result's makespan each of its CPUs was busy, a single one for a
+// single-CPU schedule.
+func CPUUtilization(result ScheduleResult) []float64 {
+	cpus := result.CPUs
+	if cpus == nil {
+		cpus = [][]TimeSlice{result.Gantt}
+	}
+	utilization := make([]float64, len(cpus))
+	if result.Makespan == 0 {
+		return utilization
+	}
+	for i, gantt := range cpus {
+		var busy int64
+		for _, slice := range gantt {
+			busy += slice.Stop - slice.Start
+		}
+		utilization[i] = float64(busy) / float64(result.Makespan)
+	}
+	return utilization
+}
+
+// LoadImbalance is how much more the busiest CPU of result worked than the average CPU, as a
+// fraction of the average: 0 when the CPUs did equal work, and 0 for an empty schedule.
+func LoadImbalance(result ScheduleResult) float64 {
+	utilization := CPUUtilization(result)
+	var total float64
+	for _, u := range utilization {
+		total += u
+	}
+	if total == 0 {
+		return 0
+	}
+	return slices.Max(utilization)/(total/float64(len(utilization))) - 1
+}
+
+// Migrations counts the times a process of result resumed on a different CPU than the one it last
+// ran on, each a cold cache; 0 for a single-CPU schedule.
+func Migrations(result ScheduleResult) int {
+	type run struct {
+		start int64
+		cpu   int
+	}
+	runs := make(map[string][]run)
+	for cpu, gantt := range result.CPUs {
+		for _, slice := range gantt {
+			runs[slice.PID] = append(runs[slice.PID], run{slice.Start, cpu})
+		}
+	}
+	var migrations int
+	for _, r := range runs {
+		slices.SortFunc(r, func(a, b run) int { return cmp.Compare(a.start, b.start) })
+		for i := 1; i < len(r); i++ {
+			if r[i].cpu != r[i-1].cpu {
+				migrations++
+			}
+		}
+	}
+	return migrations
+}
+
+// outputCPUs writes the gantt of every CPU on a shared time axis.
+func outputCPUs(w io.Writer, result ScheduleResult) {
+	labeled := make(map[string][]TimeSlice, len(result.CPUs))
+	width := len(fmt.Sprint(len(result.CPUs) - 1))
+	for i, gantt := range result.CPUs {
+		labeled[fmt.Sprintf("CPU %*d", width, i)] = gantt
+	}
+	_, _ = fmt.Fprintln(w, "Gantt schedule per CPU")
+	_, _ = fmt.Fprintln(w, StackGantts(labeled))
+}
+
+// outputAssignment writes the processes assigned to each CPU and their total burst.
+func outputAssignment(w io.Writer, processes []Process, cpus int, assignment map[string]int) {
+	ids := make([][]string, cpus)
+	load := make([]int64, cpus)
+	for _, p := range processes {
+		cpu := assignment[p.ProcessID]
+		ids[cpu] = append(ids[cpu], p.ProcessID)
+		load[cpu] += p.BurstDuration
+	}
+	_, _ = fmt.Fprintln(w, "CPU assignment")
+	for cpu := range ids {
+		_, _ = fmt.Fprintf(w, "CPU %d: %s (burst %d)\n", cpu, strings.Join(ids[cpu], ", "), load[cpu])
+	}
+}
+
+// outputCPUUtilization writes the utilization of every CPU, and the burst it completed if that's
+// known, the load imbalance between them and the migrations.
+func outputCPUUtilization(w io.Writer, result ScheduleResult) {
+	for i, u := range CPUUtilization(result) {
+		_, _ = fmt.Fprintf(w, "CPU %d utilization: %.1f%%\n", i, 100*u)
+		if result.CPUWork != nil {
+			_, _ = fmt.Fprintf(w, "CPU %d work done: %d\n", i, result.CPUWork[i])
+		}
+	}
+	_, _ = fmt.Fprintf(w, "Load imbalance: %.1f%%\n", 100*LoadImbalance(result))
+	_, _ = fmt.Fprintf(w, "Migrations: %d\n", Migrations(result))
+}
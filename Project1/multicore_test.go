@@ -0,0 +1,523 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestMulticoreSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		processes     []Process
+		cpus          int
+		policy        Scheduler
+		quantum       int64
+		want          [][]TimeSlice
+		wantImbalance float64
+	}{
+		{
+			name: "fcfs",
+			processes: []Process{
+				{ProcessID: "A", BurstDuration: 4},
+				{ProcessID: "B", BurstDuration: 2},
+				{ProcessID: "C", ArrivalTime: 1, BurstDuration: 3},
+				{ProcessID: "D", ArrivalTime: 2, BurstDuration: 1},
+			},
+			cpus:   2,
+			policy: fcfs,
+			// C takes the CPU B frees at 2, D the one A frees at 4.
+			want: [][]TimeSlice{
+				{{PID: "A", Start: 0, Stop: 4}, {PID: "D", Start: 4, Stop: 5}},
+				{{PID: "B", Start: 0, Stop: 2}, {PID: "C", Start: 2, Stop: 5}},
+			},
+		},
+		{
+			name: "rr keeps processes on their CPU",
+			processes: []Process{
+				{ProcessID: "A", BurstDuration: 4},
+				{ProcessID: "B", BurstDuration: 4},
+				{ProcessID: "C", BurstDuration: 2},
+			},
+			cpus:    2,
+			policy:  rr,
+			quantum: 2,
+			// at 2 C and A are picked, and A goes back to CPU 0; B waits for CPU 1.
+			want: [][]TimeSlice{
+				{{PID: "A", Start: 0, Stop: 4}},
+				{{PID: "B", Start: 0, Stop: 2}, {PID: "C", Start: 2, Stop: 4}, {PID: "B", Start: 4, Stop: 6}},
+			},
+			// CPU 1 was busy 6 ticks against an average of 5.
+			wantImbalance: 0.2,
+		},
+		{
+			name: "sjf",
+			processes: []Process{
+				{ProcessID: "A", BurstDuration: 5},
+				{ProcessID: "B", BurstDuration: 3},
+				{ProcessID: "C", ArrivalTime: 1, BurstDuration: 1},
+			},
+			cpus:   2,
+			policy: sjf,
+			// B, the shortest, is picked first; C preempts A, which resumes on CPU 1 once C is done.
+			want: [][]TimeSlice{
+				{{PID: "B", Start: 0, Stop: 3}},
+				{{PID: "A", Start: 0, Stop: 1}, {PID: "C", Start: 1, Stop: 2}, {PID: "A", Start: 2, Stop: 6}},
+			},
+			// CPU 1 was busy 6 ticks against an average of 4.5.
+			wantImbalance: 1.0 / 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			got, err := MulticoreSchedule(&w, "multicore", tt.processes, tt.cpus, tt.policy, tt.quantum)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got.CPUs); diff != "" {
+				t.Errorf(diff)
+			}
+			if diff := cmp.Diff(tt.wantImbalance, LoadImbalance(got), cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf(diff)
+			}
+			for _, want := range []string{"Gantt schedule per CPU", "CPU 1\n", "CPU 1 utilization", "Load imbalance"} {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("output is missing %q:\n%s", want, w.String())
+				}
+			}
+		})
+	}
+}
+
+func TestMulticoreSchedule_oneCPU(t *testing.T) {
+	t.Parallel()
+	processes := exponentialWorkload(200, 10, 8, 7)
+	tests := []struct {
+		policy Scheduler
+		legacy func(io.Writer, string, []Process) ScheduleResult
+	}{
+		{policy: fcfs, legacy: FCFSSchedule},
+		{policy: sjf, legacy: SJFSchedule},
+		{policy: rr, legacy: RRSchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.policy.String(), func(t *testing.T) {
+			t.Parallel()
+			got, err := MulticoreSchedule(io.Discard, "one CPU", processes, 1, tt.policy, defaultQuantum)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := tt.legacy(io.Discard, "one CPU", processes)
+			if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(ScheduleResult{}, "CPUs")); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestMulticoreSchedule_killsSuspensionsAndLimits(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 4, KillAt: 2},
+		{ProcessID: "B", BurstDuration: 4, CPULimit: 1},
+		{ProcessID: "C", BurstDuration: 2, Suspensions: []Window{{Start: 0, Stop: 2}}},
+		{ProcessID: "D", BurstDuration: 4, Quota: 1, Period: 2},
+	}
+	result, err := MulticoreSchedule(io.Discard, "two CPUs", processes, 2, fcfs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// B is terminated at its limit, D is throttled after every tick that doesn't end a window, C
+	// waits out its suspension and A is killed at 2.
+	want := [][]TimeSlice{
+		{{PID: "A", Start: 0, Stop: 2}, {PID: "C", Start: 2, Stop: 4}},
+		{{PID: "B", Start: 0, Stop: 1}, {PID: "D", Start: 1, Stop: 3}, {PID: "D", Start: 4, Stop: 5}, {PID: "D", Start: 6, Stop: 7}},
+	}
+	if diff := cmp.Diff(want, result.CPUs); diff != "" {
+		t.Errorf(diff)
+	}
+	if a, b := result.Processes[0], result.Processes[1]; !a.Aborted || !b.Limited {
+		t.Errorf("A = %+v, B = %+v, want A aborted and B limited", a, b)
+	}
+}
+
+func TestMulticoreSchedule_ioBursts(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 4, IOBursts: []IOBurst{{After: 1, Duration: 2}}},
+		{ProcessID: "B", BurstDuration: 3},
+		{ProcessID: "C", BurstDuration: 3},
+	}
+	result, err := MulticoreSchedule(io.Discard, "two CPUs", processes, 2, fcfs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A leaves CPU 0 for its I/O after a tick, C takes it, and A is back at 3 for CPU 1.
+	want := [][]TimeSlice{
+		{{PID: "A", Start: 0, Stop: 1}, {PID: "C", Start: 1, Stop: 4}},
+		{{PID: "B", Start: 0, Stop: 3}, {PID: "A", Start: 3, Stop: 6}},
+	}
+	if diff := cmp.Diff(want, result.CPUs); diff != "" {
+		t.Errorf(diff)
+	}
+	if diff := cmp.Diff([]Window{{Start: 1, Stop: 3}}, result.Processes[0].IO); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestMulticoreSchedule_errors(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: "A", BurstDuration: 1}}
+	if _, err := MulticoreSchedule(io.Discard, "none", processes, 0, fcfs, 0); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("0 CPUs: error = %v, want %v", err, ErrInvalidArgs)
+	}
+	if _, err := MulticoreSchedule(io.Discard, "priority", processes, 2, sjfp, 0); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Errorf("sjfp: error = %v, want %v", err, ErrUnknownAlgorithm)
+	}
+}
+
+func TestMulticoreSchedule_affinity(t *testing.T) {
+	t.Parallel()
+	// A and B are pinned to CPU 1, so CPU 0 idles once C is done while B waits.
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 4, Affinity: 0b10},
+		{ProcessID: "B", BurstDuration: 2, Affinity: 0b10},
+		{ProcessID: "C", BurstDuration: 2},
+	}
+	want := [][]TimeSlice{
+		{{PID: "C", Start: 0, Stop: 2}},
+		{{PID: "A", Start: 0, Stop: 4}, {PID: "B", Start: 4, Stop: 6}},
+	}
+	tests := []struct {
+		name     string
+		schedule func(w io.Writer) (ScheduleResult, error)
+	}{
+		{
+			name: "global",
+			schedule: func(w io.Writer) (ScheduleResult, error) {
+				return MulticoreSchedule(w, "pinned", processes, 2, fcfs, 0)
+			},
+		},
+		{
+			name: "partitioned",
+			// CPU 0 can't steal B.
+			schedule: func(w io.Writer) (ScheduleResult, error) {
+				return PartitionedSchedule(w, "pinned", processes, 2, fcfs, 0, 1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			got, err := tt.schedule(&w)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got.CPUs); diff != "" {
+				t.Errorf(diff)
+			}
+			// waits of 0, 4 and 0 pinned; 0, 0 and 2 unpinned.
+			if got.UnpinnedWait == nil || *got.UnpinnedWait >= got.AverageWait {
+				t.Errorf("UnpinnedWait = %v, want less than %.2f", got.UnpinnedWait, got.AverageWait)
+			}
+			if !strings.Contains(w.String(), "Average wait without affinity: 0.67") {
+				t.Errorf("output is missing the unpinned wait:\n%s", w.String())
+			}
+		})
+	}
+}
+
+func TestMulticoreSchedule_affinityNowhere(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: "A", BurstDuration: 1, Affinity: 0b100}}
+	if _, err := MulticoreSchedule(io.Discard, "nowhere", processes, 2, fcfs, 0); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("MulticoreSchedule() error = %v, want %v", err, ErrInvalidArgs)
+	}
+	if _, err := PackProcesses(processes, 2, PackFirstFit); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("PackProcesses() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func TestPartitionedSchedule(t *testing.T) {
+	t.Parallel()
+	// placed alternately: A, C and E on CPU 0, B and D on CPU 1, which runs out of work at 2.
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 3},
+		{ProcessID: "B", BurstDuration: 1},
+		{ProcessID: "C", BurstDuration: 3},
+		{ProcessID: "D", BurstDuration: 1},
+		{ProcessID: "E", BurstDuration: 3},
+	}
+	unstolen := [][]TimeSlice{
+		{
+			{PID: "A", Start: 0, Stop: 1}, {PID: "C", Start: 1, Stop: 2}, {PID: "E", Start: 2, Stop: 3},
+			{PID: "A", Start: 3, Stop: 4}, {PID: "C", Start: 4, Stop: 5}, {PID: "E", Start: 5, Stop: 6},
+			{PID: "A", Start: 6, Stop: 7}, {PID: "C", Start: 7, Stop: 8}, {PID: "E", Start: 8, Stop: 9},
+		},
+		{{PID: "B", Start: 0, Stop: 1}, {PID: "D", Start: 1, Stop: 2}},
+	}
+	// CPU 1 steals C at 2 and A at 4, both preempted on CPU 0.
+	stolen := [][]TimeSlice{
+		{
+			{PID: "A", Start: 0, Stop: 1}, {PID: "C", Start: 1, Stop: 2}, {PID: "E", Start: 2, Stop: 3},
+			{PID: "A", Start: 3, Stop: 4}, {PID: "E", Start: 4, Stop: 6},
+		},
+		{{PID: "B", Start: 0, Stop: 1}, {PID: "D", Start: 1, Stop: 2}, {PID: "C", Start: 2, Stop: 4}, {PID: "A", Start: 4, Stop: 5}},
+	}
+	tests := []struct {
+		name           string
+		steal          int
+		want           [][]TimeSlice
+		wantSteals     string
+		wantMigrations int
+	}{
+		{name: "no stealing", steal: 0, want: unstolen, wantSteals: "Work steals: 0"},
+		{name: "steal from any queue", steal: 1, want: stolen, wantSteals: "Work steals: 2", wantMigrations: 2},
+		{
+			name:  "steal from two waiting",
+			steal: 2,
+			// at 4 CPU 0 has dispatched E and only A is left waiting.
+			want: [][]TimeSlice{
+				{
+					{PID: "A", Start: 0, Stop: 1}, {PID: "C", Start: 1, Stop: 2}, {PID: "E", Start: 2, Stop: 3},
+					{PID: "A", Start: 3, Stop: 4}, {PID: "E", Start: 4, Stop: 5}, {PID: "A", Start: 5, Stop: 6},
+					{PID: "E", Start: 6, Stop: 7},
+				},
+				{{PID: "B", Start: 0, Stop: 1}, {PID: "D", Start: 1, Stop: 2}, {PID: "C", Start: 2, Stop: 4}},
+			},
+			wantSteals:     "Work steals: 1",
+			wantMigrations: 1,
+		},
+		// CPU 0 never has three processes waiting while CPU 1 idles.
+		{name: "steal from three waiting", steal: 3, want: unstolen, wantSteals: "Work steals: 0"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			got, err := PartitionedSchedule(&w, "partitioned", processes, 2, rr, 1, tt.steal)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got.CPUs); diff != "" {
+				t.Errorf(diff)
+			}
+			if m := Migrations(got); m != tt.wantMigrations {
+				t.Errorf("Migrations() = %d, want %d", m, tt.wantMigrations)
+			}
+			if !strings.Contains(w.String(), tt.wantSteals) {
+				t.Errorf("output is missing %q:\n%s", tt.wantSteals, w.String())
+			}
+		})
+	}
+}
+
+func TestPartitionedSchedule_errors(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: "A", BurstDuration: 1}}
+	if _, err := PartitionedSchedule(io.Discard, "negative", processes, 2, fcfs, 0, -1); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("steal -1: error = %v, want %v", err, ErrInvalidArgs)
+	}
+	if _, err := PartitionedSchedule(io.Discard, "none", processes, 0, fcfs, 0, 1); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("0 CPUs: error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func TestPackProcesses(t *testing.T) {
+	t.Parallel()
+	// three CPUs of capacity 4: C fits on CPU 0 and, tighter, on CPU 1; D fits nowhere.
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 2},
+		{ProcessID: "B", BurstDuration: 3},
+		{ProcessID: "C", BurstDuration: 1},
+		{ProcessID: "D", BurstDuration: 6},
+	}
+	tests := []struct {
+		strategy string
+		want     map[string]int
+		wantErr  error
+	}{
+		{strategy: PackFirstFit, want: map[string]int{"A": 0, "B": 1, "C": 0, "D": 2}},
+		{strategy: PackBestFit, want: map[string]int{"A": 0, "B": 1, "C": 1, "D": 2}},
+		{strategy: PackWorstFit, want: map[string]int{"A": 0, "B": 1, "C": 2, "D": 2}},
+		{strategy: "next-fit", wantErr: ErrInvalidArgs},
+	}
+	pinned := slices.Clone(processes)
+	pinned[2].Affinity = 0b100
+	if got, _ := PackProcesses(pinned, 3, PackBestFit); got["C"] != 2 {
+		t.Errorf("PackProcesses() put C pinned to CPU 2 on CPU %d", got["C"])
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.strategy, func(t *testing.T) {
+			t.Parallel()
+			got, err := PackProcesses(processes, 3, tt.strategy)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PackProcesses() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestPackedSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 5},
+		{ProcessID: "B", BurstDuration: 4},
+		{ProcessID: "C", BurstDuration: 3},
+		{ProcessID: "D", BurstDuration: 3},
+		{ProcessID: "E", BurstDuration: 1},
+	}
+	var w bytes.Buffer
+	got, err := PackedSchedule(&w, "first-fit", processes, 2, fcfs, 0, PackFirstFit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]TimeSlice{
+		{{PID: "A", Start: 0, Stop: 5}, {PID: "C", Start: 5, Stop: 8}},
+		{{PID: "B", Start: 0, Stop: 4}, {PID: "D", Start: 4, Stop: 7}, {PID: "E", Start: 7, Stop: 8}},
+	}
+	if diff := cmp.Diff(want, got.CPUs); diff != "" {
+		t.Errorf(diff)
+	}
+	for _, want := range []string{"CPU 0: A, C (burst 8)", "CPU 1: B, D, E (burst 8)"} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output is missing %q:\n%s", want, w.String())
+		}
+	}
+}
+
+func TestGangSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 4, Group: "g"},
+		{ProcessID: "B", BurstDuration: 4, Group: "g"},
+		{ProcessID: "C", BurstDuration: 6},
+		{ProcessID: "D", BurstDuration: 2},
+	}
+	result, err := GangSchedule(io.Discard, "gang", processes, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the gang and C take the first slot, D then fits ahead of the gang in the second but C
+	// doesn't, and C runs alone in the rest.
+	want := [][]TimeSlice{
+		{{PID: "A", Start: 0, Stop: 2}, {PID: "D", Start: 2, Stop: 4}},
+		{{PID: "B", Start: 0, Stop: 2}, {PID: "A", Start: 2, Stop: 4}},
+		{{PID: "C", Start: 0, Stop: 2}, {PID: "B", Start: 2, Stop: 4}, {PID: "C", Start: 4, Stop: 8}},
+	}
+	if diff := cmp.Diff(want, result.CPUs); diff != "" {
+		t.Errorf(diff)
+	}
+
+	tests := []struct {
+		name      string
+		processes []Process
+		cpus      int
+		wantErr   error
+	}{
+		{name: "gang larger than the CPUs", processes: processes, cpus: 1, wantErr: ErrInvalidArgs},
+		{
+			name:      "pinned gang",
+			processes: []Process{{ProcessID: "A", BurstDuration: 4, Group: "g", Affinity: 0b1}},
+			cpus:      2,
+			wantErr:   ErrUnsupportedOption,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := GangSchedule(io.Discard, "gang", tt.processes, tt.cpus, 2); !errors.Is(err, tt.wantErr) {
+				t.Errorf("GangSchedule() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHeterogeneousSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 8},
+		{ProcessID: "B", BurstDuration: 2},
+		{ProcessID: "C", BurstDuration: 3},
+		{ProcessID: "D", ArrivalTime: 6, BurstDuration: 1},
+	}
+	var w bytes.Buffer
+	got, err := HeterogeneousSchedule(&w, "big.LITTLE", processes, []int64{2, 1}, fcfs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A, longer than the median 2, takes the fast CPU 0 and completes in 4 ticks; B and D, no
+	// longer, take the slow CPU 1 even when CPU 0 is idle, and C has only CPU 1 left at 2.
+	want := [][]TimeSlice{
+		{{PID: "A", Start: 0, Stop: 4}},
+		{{PID: "B", Start: 0, Stop: 2}, {PID: "C", Start: 2, Stop: 5}, {PID: "D", Start: 6, Stop: 7}},
+	}
+	if diff := cmp.Diff(want, got.CPUs); diff != "" {
+		t.Errorf(diff)
+	}
+	if diff := cmp.Diff([]int64{8, 6}, got.CPUWork); diff != "" {
+		t.Errorf(diff)
+	}
+	// only C waited, 2 ticks, though A ran 4 ticks of its burst of 8.
+	if got.AverageWait != 0.5 {
+		t.Errorf("AverageWait = %.2f, want 0.50", got.AverageWait)
+	}
+	if !strings.Contains(w.String(), "CPU 0 work done: 8") || !strings.Contains(w.String(), "CPU 1 work done: 6") {
+		t.Errorf("output is missing the work done:\n%s", w.String())
+	}
+
+	// on CPUs of equal speed it's MulticoreSchedule.
+	processes = exponentialWorkload(200, 4, 8, 8)
+	got, err = HeterogeneousSchedule(io.Discard, "equal", processes, []int64{1, 1, 1}, rr, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := MulticoreSchedule(io.Discard, "equal", processes, 3, rr, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(legacy, got, cmpopts.IgnoreFields(ScheduleResult{}, "CPUWork")); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestHeterogeneousSchedule_errors(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: "A", BurstDuration: 1}}
+	for _, speeds := range [][]int64{nil, {2, 0}} {
+		if _, err := HeterogeneousSchedule(io.Discard, "bad", processes, speeds, fcfs, 0); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("speeds %v: error = %v, want %v", speeds, err, ErrInvalidArgs)
+		}
+	}
+}
+
+func TestCPUUtilization(t *testing.T) {
+	t.Parallel()
+	single := newScheduleResult("single", []Process{{ProcessID: "A", BurstDuration: 2}, {ProcessID: "B", ArrivalTime: 4, BurstDuration: 1}},
+		[]TimeSlice{{PID: "A", Start: 0, Stop: 2}, {PID: "B", Start: 4, Stop: 5}})
+	if diff := cmp.Diff([]float64{0.6}, CPUUtilization(single)); diff != "" {
+		t.Errorf(diff)
+	}
+	if got := LoadImbalance(single); got != 0 {
+		t.Errorf("LoadImbalance() = %v, want 0", got)
+	}
+}
@@ -0,0 +1,232 @@
+package main
+
+import (
+	"io"
+	"slices"
+)
+
+// QueueLevel is one queue of MultilevelQueueSchedule: the processes of a Class, scheduled
+// round-robin with Quantum or, with a Quantum of 0, first-come, first-serve.
+type QueueLevel struct {
+	Class   string
+	Quantum int64
+}
+
+// MultilevelQueueSchedule is a fixed multilevel queue: every process is queued in the level of
+// its Class, and the levels have strict priority in the order given, so a process only runs while
+// every earlier level is empty and an arrival in an earlier level preempts it at once. A preempted
+// process stays at the head of its queue and gets a fresh quantum when it runs again. Processes of
+// a class no level names are queued in the last level.
+func MultilevelQueueSchedule(w io.Writer, title string, processes []Process, levels []QueueLevel) ScheduleResult {
+	quanta := make([]int64, len(levels))
+	for i, level := range levels {
+		quanta[i] = level.Quantum
+	}
+	result := multilevelSchedule(title, processes, quanta, func(p Process) int {
+		if i := slices.IndexFunc(levels, func(l QueueLevel) bool { return l.Class == p.Class }); i >= 0 {
+			return i
+		}
+		return len(levels) - 1
+	})
+	outputResult(w, result)
+
+	return result
+}
+
+// PriorityRRSchedule is round-robin within priority levels: the processes of each Priority take
+// turns for defaultQuantum, but only while no more important process (with a lower number) is
+// ready, and a more important arrival preempts at once. It's a MultilevelQueueSchedule with a
+// round-robin level per priority.
+func PriorityRRSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	var priorities []int64
+	for _, p := range processes {
+		priorities = append(priorities, p.Priority)
+	}
+	slices.Sort(priorities)
+	priorities = slices.Compact(priorities)
+	quanta := make([]int64, len(priorities))
+	for i := range quanta {
+		quanta[i] = defaultQuantum
+	}
+	result := multilevelSchedule(title, processes, quanta, func(p Process) int {
+		level, _ := slices.BinarySearch(priorities, p.Priority)
+		return level
+	})
+	outputResult(w, result)
+
+	return result
+}
+
+// ClassBackground is the Class of the processes TwoQueueSchedule queues in the background.
+const ClassBackground = "background"
+
+// TwoQueueSchedule is the classic foreground/background multilevel queue: interactive processes
+// take turns round-robin for defaultQuantum in the foreground queue, and processes of
+// ClassBackground run first-come, first-serve in the background queue. While both queues have work
+// they split the CPU, the foreground getting foregroundPercent of those ticks: each goes to the
+// foreground unless it already had its part of them. A queue with no work leaves all the CPU to the
+// other. A foreground process interrupted by the background keeps its place and the rest of its
+// quantum. Percentages outside 0 to 100 are clamped.
+func TwoQueueSchedule(w io.Writer, title string, processes []Process, foregroundPercent int64) ScheduleResult {
+	foregroundPercent = min(max(foregroundPercent, 0), 100)
+	var (
+		foreground, background []string
+		// current is the foreground process running its quantum, for ranFor; rotate is set when its
+		// quantum is up.
+		current string
+		ranFor  int64
+		rotate  bool
+		// shared is whether both queues had work at the last pick, and foregroundRan and
+		// backgroundRan count the ticks the queues got while they did.
+		shared                       bool
+		foregroundRan, backgroundRan int64
+	)
+	pick := func(ready []Process, _ int64) int {
+		if rotate {
+			// processes that arrived during the quantum queue ahead of the preempted one.
+			foreground = append(slices.DeleteFunc(foreground, func(id string) bool { return id == current }), current)
+			rotate, ranFor = false, 0
+		}
+		first := func(queue []string) int {
+			for _, id := range queue {
+				if i := readyIndex(ready, id); i >= 0 {
+					return i
+				}
+			}
+			return -1
+		}
+		fore, back := first(foreground), first(background)
+		shared = fore >= 0 && back >= 0
+		if fore >= 0 && (!shared || foregroundRan*100 < foregroundPercent*(foregroundRan+backgroundRan+1)) {
+			return fore
+		}
+		return back
+	}
+	ran := func(p Process, start, stop int64) {
+		switch {
+		case shared && p.Class != ClassBackground:
+			foregroundRan += stop - start
+		case shared:
+			backgroundRan += stop - start
+		}
+		if p.Class == ClassBackground {
+			return
+		}
+		if p.ProcessID != current {
+			current, ranFor = p.ProcessID, 0
+		}
+		ranFor += stop - start
+		rotate = p.BurstDuration > 0 && ranFor == defaultQuantum
+	}
+	left := func(p Process, _ int64) {
+		foreground = slices.DeleteFunc(foreground, func(id string) bool { return id == p.ProcessID })
+		background = slices.DeleteFunc(background, func(id string) bool { return id == p.ProcessID })
+		if p.ProcessID == current {
+			current, ranFor, rotate = "", 0, false
+		}
+	}
+
+	result, _ := Simulate(processes, pick, SimOptions{
+		Title:      title,
+		Preemptive: true,
+		KeepPlace:  true,
+		Arrived: func(p Process, _ int64) {
+			if p.Class == ClassBackground {
+				background = append(background, p.ProcessID)
+			} else {
+				foreground = append(foreground, p.ProcessID)
+			}
+		},
+		Ran:  ran,
+		Left: left,
+	})
+	outputResult(w, result)
+
+	return result
+}
+
+// FBSchedule is multilevel feedback with quanta doubling: a process starts in level 0 and drops a
+// level after each full quantum it runs, level i having a quantum of 2^i. The first process of the
+// most important non-empty level runs next, so short processes complete in the top levels while
+// long ones sink and run in ever longer quanta. Arrivals don't preempt the running process.
+func FBSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	// a process's level is how many quanta it has been dispatched for.
+	dispatched := make(map[string]int, len(processes))
+	result, _ := Simulate(processes, func(ready []Process, _ int64) int {
+		next := 0
+		for i, p := range ready {
+			if dispatched[p.ProcessID] < dispatched[ready[next].ProcessID] {
+				next = i
+			}
+		}
+		dispatched[ready[next].ProcessID]++
+		return next
+	}, SimOptions{
+		Title:     title,
+		QuantumOf: func(p Process) int64 { return 1 << (dispatched[p.ProcessID] - 1) },
+	})
+	outputResult(w, result)
+
+	return result
+}
+
+// multilevelSchedule runs processes through strict priority queues, one per quantum, the first
+// the most important: levelOf says which queue a process is in, and the queue's quantum is how long
+// its processes run in turn, or to completion for 0.
+func multilevelSchedule(title string, processes []Process, quanta []int64, levelOf func(Process) int) ScheduleResult {
+	var (
+		queues = make([][]string, len(quanta))
+		levels = make(map[string]int, len(processes))
+		// current is the process running its quantum, for ranFor; rotate is set when its quantum
+		// is up.
+		current string
+		ranFor  int64
+		rotate  bool
+	)
+	pick := func(ready []Process, _ int64) int {
+		if rotate {
+			// processes that arrived during the quantum queue ahead of the preempted one.
+			level := levels[current]
+			queues[level] = append(slices.DeleteFunc(queues[level], func(id string) bool { return id == current }), current)
+			rotate, ranFor = false, 0
+		}
+		for _, queue := range queues {
+			for _, id := range queue {
+				if i := readyIndex(ready, id); i >= 0 {
+					return i
+				}
+			}
+		}
+		return 0
+	}
+	ran := func(p Process, start, stop int64) {
+		if p.ProcessID != current {
+			// a preempted process gets a fresh quantum.
+			current, ranFor = p.ProcessID, 0
+		}
+		ranFor += stop - start
+		quantum := quanta[levels[p.ProcessID]]
+		rotate = p.BurstDuration > 0 && quantum > 0 && ranFor == quantum
+	}
+	left := func(p Process, _ int64) {
+		level := levels[p.ProcessID]
+		queues[level] = slices.DeleteFunc(queues[level], func(id string) bool { return id == p.ProcessID })
+		if p.ProcessID == current {
+			current, ranFor, rotate = "", 0, false
+		}
+	}
+
+	result, _ := Simulate(processes, pick, SimOptions{
+		Title:      title,
+		Preemptive: true,
+		KeepPlace:  true,
+		Arrived: func(p Process, _ int64) {
+			level := levelOf(p)
+			levels[p.ProcessID] = level
+			queues[level] = append(queues[level], p.ProcessID)
+		},
+		Ran:  ran,
+		Left: left,
+	})
+	return result
+}
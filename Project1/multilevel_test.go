@@ -0,0 +1,186 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMultilevelQueueSchedule(t *testing.T) {
+	t.Parallel()
+	classless := exponentialWorkload(200, 10, 8, 2)
+	tests := []struct {
+		name      string
+		processes []Process
+		levels    []QueueLevel
+		want      []TimeSlice
+		sameAs    func(io.Writer, string, []Process) ScheduleResult
+	}{
+		{
+			// the interactive arrival preempts batch B at once; system S preempts interactive I.
+			name: "strict priority between classes",
+			processes: []Process{
+				{ProcessID: "B", ArrivalTime: 0, BurstDuration: 6, Class: "batch"},
+				{ProcessID: "I1", ArrivalTime: 2, BurstDuration: 3, Class: "interactive"},
+				{ProcessID: "I2", ArrivalTime: 2, BurstDuration: 2, Class: "interactive"},
+				{ProcessID: "S", ArrivalTime: 3, BurstDuration: 1, Class: "system"},
+			},
+			levels: []QueueLevel{{Class: "system"}, {Class: "interactive", Quantum: 2}, {Class: "batch"}},
+			want: []TimeSlice{
+				{PID: "B", Start: 0, Stop: 2},
+				{PID: "I1", Start: 2, Stop: 3},
+				{PID: "S", Start: 3, Stop: 4},
+				{PID: "I1", Start: 4, Stop: 6},
+				{PID: "I2", Start: 6, Stop: 8},
+				{PID: "B", Start: 8, Stop: 12},
+			},
+		},
+		{
+			name: "unlisted class in the last level",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 3},
+				{ProcessID: "B", ArrivalTime: 1, BurstDuration: 2, Class: "system"},
+			},
+			levels: []QueueLevel{{Class: "system"}, {Class: "batch"}},
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 1},
+				{PID: "B", Start: 1, Stop: 3},
+				{PID: "A", Start: 3, Stop: 5},
+			},
+		},
+		{
+			name:      "one round-robin level",
+			processes: classless,
+			levels:    []QueueLevel{{Quantum: defaultQuantum}},
+			sameAs:    RRSchedule,
+		},
+		{
+			name:      "one first-come, first-serve level",
+			processes: classless,
+			levels:    []QueueLevel{{}},
+			sameAs:    FCFSSchedule,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := MultilevelQueueSchedule(io.Discard, "mlq", tt.processes, tt.levels)
+			if tt.sameAs != nil {
+				if diff := cmp.Diff(tt.sameAs(io.Discard, "mlq", tt.processes), result); diff != "" {
+					t.Errorf(diff)
+				}
+				return
+			}
+			if diff := cmp.Diff(tt.want, result.Gantt); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestPriorityRRSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 6, Priority: 2},
+		{ProcessID: "B", ArrivalTime: 0, BurstDuration: 6, Priority: 2},
+		{ProcessID: "C", ArrivalTime: 5, BurstDuration: 2, Priority: 1},
+		{ProcessID: "D", ArrivalTime: 1, BurstDuration: 2, Priority: 3},
+	}
+	result := PriorityRRSchedule(io.Discard, "priority rr", processes)
+	// A and B take turns; C preempts B, which resumes with a fresh quantum. D waits for both levels.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 4},
+		{PID: "B", Start: 4, Stop: 5},
+		{PID: "C", Start: 5, Stop: 7},
+		{PID: "B", Start: 7, Stop: 11},
+		{PID: "A", Start: 11, Stop: 13},
+		{PID: "B", Start: 13, Stop: 14},
+		{PID: "D", Start: 14, Stop: 16},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestFBSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 7},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 1},
+	}
+	result := FBSchedule(io.Discard, "fb", processes)
+	// C arrives in level 0 and runs ahead of A and B in level 1; A runs its level 2 quantum of 4 last.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 1},
+		{PID: "B", Start: 1, Stop: 2},
+		{PID: "C", Start: 2, Stop: 3},
+		{PID: "A", Start: 3, Stop: 5},
+		{PID: "B", Start: 5, Stop: 7},
+		{PID: "A", Start: 7, Stop: 11},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestTwoQueueSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "F1", ArrivalTime: 0, BurstDuration: 6},
+		{ProcessID: "F2", ArrivalTime: 0, BurstDuration: 6, Class: "interactive"},
+		{ProcessID: "B", ArrivalTime: 0, BurstDuration: 5, Class: ClassBackground},
+	}
+	tests := []struct {
+		name    string
+		percent int64
+		want    []TimeSlice
+	}{
+		{
+			// B gets every fifth tick until the foreground runs out of work.
+			name:    "80/20",
+			percent: 80,
+			want: []TimeSlice{
+				{PID: "F1", Start: 0, Stop: 4},
+				{PID: "B", Start: 4, Stop: 5},
+				{PID: "F2", Start: 5, Stop: 9},
+				{PID: "B", Start: 9, Stop: 10},
+				{PID: "F1", Start: 10, Stop: 12},
+				{PID: "F2", Start: 12, Stop: 14},
+				{PID: "B", Start: 14, Stop: 17},
+			},
+		},
+		{
+			name:    "strict foreground",
+			percent: 150,
+			want: []TimeSlice{
+				{PID: "F1", Start: 0, Stop: 4},
+				{PID: "F2", Start: 4, Stop: 8},
+				{PID: "F1", Start: 8, Stop: 10},
+				{PID: "F2", Start: 10, Stop: 12},
+				{PID: "B", Start: 12, Stop: 17},
+			},
+		},
+		{
+			name: "strict background",
+			want: []TimeSlice{
+				{PID: "B", Start: 0, Stop: 5},
+				{PID: "F1", Start: 5, Stop: 9},
+				{PID: "F2", Start: 9, Stop: 13},
+				{PID: "F1", Start: 13, Stop: 15},
+				{PID: "F2", Start: 15, Stop: 17},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.want, TwoQueueSchedule(io.Discard, "two queues", processes, tt.percent).Gantt); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// Burst noise distributions for -noise-dist.
+const (
+	NoiseUniform  = "uniform"
+	NoiseGaussian = "gaussian"
+)
+
+// PerturbBursts estimates every process' burst with relative error noise, seeded so the same
+// seed always gives the same estimates. A uniform error is drawn from ±noise, a gaussian one
+// has noise as its standard deviation. Estimates are never below 1.
+func PerturbBursts(processes []Process, noise float64, dist string, seed uint64) (map[string]int64, error) {
+	if noise < 0 {
+		return nil, fmt.Errorf("%w: noise %g is negative", ErrInvalidArgs, noise)
+	}
+	rng := rand.New(rand.NewPCG(seed, seed))
+	var draw func() float64
+	switch dist {
+	case NoiseUniform:
+		draw = func() float64 { return noise * (2*rng.Float64() - 1) }
+	case NoiseGaussian:
+		draw = func() float64 { return noise * rng.NormFloat64() }
+	default:
+		return nil, fmt.Errorf("%w: unknown noise distribution %q", ErrInvalidArgs, dist)
+	}
+
+	estimates := make(map[string]int64, len(processes))
+	for _, p := range processes {
+		estimate := math.Round(float64(p.BurstDuration) * (1 + draw()))
+		estimates[p.ProcessID] = max(int64(estimate), 1)
+	}
+
+	return estimates, nil
+}
+
+// WithBurstEstimates has SJF order processes by these estimated bursts instead of the true ones,
+// modeling imperfect burst prediction. Processes still run for their true burst; ones missing
+// from estimates are estimated exactly.
+func WithBurstEstimates(estimates map[string]int64) ScheduleOption {
+	return func(cfg *scheduleConfig) {
+		cfg.estimates = estimates
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// noisyWorkload is a batch of distinct bursts where ordering mistakes cost wait.
+func noisyWorkload() []Process {
+	processes := make([]Process, 12)
+	for i := range processes {
+		processes[i] = Process{ProcessID: fmt.Sprintf("P%d", i), ArrivalTime: int64(i % 3), BurstDuration: int64(12 - i)}
+	}
+	return processes
+}
+
+func TestPerturbBursts(t *testing.T) {
+	t.Parallel()
+	processes := noisyWorkload()
+	for _, dist := range []string{NoiseUniform, NoiseGaussian} {
+		first, err := PerturbBursts(processes, 0.2, dist, 7)
+		if err != nil {
+			t.Fatalf("%s: PerturbBursts() error = %v", dist, err)
+		}
+		second, _ := PerturbBursts(processes, 0.2, dist, 7)
+		if diff := cmp.Diff(first, second); diff != "" {
+			t.Errorf("%s: same seed gave different estimates: %s", dist, diff)
+		}
+		for _, p := range processes {
+			if dist == NoiseUniform && (float64(first[p.ProcessID]) < 0.8*float64(p.BurstDuration)-0.5 ||
+				float64(first[p.ProcessID]) > 1.2*float64(p.BurstDuration)+0.5) {
+				t.Errorf("estimate for %s = %d, outside ±20%% of %d", p.ProcessID, first[p.ProcessID], p.BurstDuration)
+			}
+		}
+	}
+
+	if _, err := PerturbBursts(processes, 0.2, "laplace", 7); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("unknown distribution error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func Test_runScheduler_noise(t *testing.T) {
+	t.Parallel()
+	processes := noisyWorkload()
+	opts := runOptions{noise: 0.5, noiseDist: NoiseUniform, seed: 3}
+	first, err := runScheduler(io.Discard, sjf, processes, opts)
+	if err != nil {
+		t.Fatalf("runScheduler() error = %v", err)
+	}
+	second, _ := runScheduler(io.Discard, sjf, processes, opts)
+	if diff := cmp.Diff(first, second); diff != "" {
+		t.Errorf("same seed gave different schedules: %s", diff)
+	}
+
+	// averaged over seeds, wait should only grow as the estimates get worse.
+	var previous float64
+	for _, noise := range []float64{0, 0.2, 0.5, 1} {
+		var total float64
+		for seed := uint64(1); seed <= 20; seed++ {
+			result, err := runScheduler(io.Discard, sjf, processes, runOptions{noise: noise, noiseDist: NoiseUniform, seed: seed})
+			if err != nil {
+				t.Fatalf("runScheduler() error = %v", err)
+			}
+			total += result.AverageWait
+		}
+		mean := total / 20
+		t.Logf("noise %g: mean average wait %.2f", noise, mean)
+		if mean < previous {
+			t.Errorf("noise %g: mean average wait %.2f dropped below %.2f", noise, mean, previous)
+		}
+		previous = mean
+	}
+}
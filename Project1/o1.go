@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+const (
+	// o1Levels is the number of priority levels of the O(1) scheduler: 0-99 real-time, 100-139
+	// normal, lower more important.
+	o1Levels = 140
+	// o1TickMillis is how many milliseconds of the O(1) scheduler's timeslices one tick stands for.
+	o1TickMillis = 25
+)
+
+// o1Priority is the static O(1) priority of p, whose Priority is its nice value: 120 + nice,
+// clamped to the normal levels 100 through 139.
+func o1Priority(p Process) int {
+	return 120 + int(min(max(p.Priority, -20), 19))
+}
+
+// o1Timeslice is the timeslice of a static priority in ticks, at least 1: (140 - priority) x 20ms
+// above nice 0 and (140 - priority) x 5ms from it, so 800ms for nice -20, 100ms for nice 0 and 5ms
+// for nice 19.
+func o1Timeslice(priority int) int64 {
+	millis := (o1Levels - priority) * 5
+	if priority < 120 {
+		millis = (o1Levels - priority) * 20
+	}
+	return max(int64(millis/o1TickMillis), 1)
+}
+
+// O1Schedule emulates the Linux 2.6 O(1) scheduler. Runnable processes sit in an active array of
+// o1Levels FIFO queues, one per priority, and the first process of the most important non-empty
+// queue runs. A process that uses up its timeslice, which is longer the more important it is,
+// moves to the same queue of the expired array with a fresh one; when the active array runs out,
+// the two arrays swap. So a less important process still runs once per round, where strict
+// priority would starve it. Priority is the nice value (see o1Priority). A more important arrival
+// preempts the running process, which keeps its place and the rest of its timeslice. It writes how
+// many times the arrays swapped. A suspended process keeps its place but is passed over, and once
+// no process of the active array can run the arrays swap. The interactivity bonus and real-time
+// policies aren't modelled.
+func O1Schedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	var (
+		swaps           int
+		active, expired [o1Levels][]string
+		timeslice       = make(map[string]int64, len(processes))
+	)
+	for _, p := range processes {
+		timeslice[p.ProcessID] = o1Timeslice(o1Priority(p))
+	}
+	// first is the index among ready of the first process of the most important level of queues
+	// it's in, or -1.
+	first := func(queues *[o1Levels][]string, ready []Process) int {
+		for _, queue := range queues {
+			for _, id := range queue {
+				if i := readyIndex(ready, id); i >= 0 {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+	pick := func(ready []Process, _ int64) int {
+		if i := first(&active, ready); i >= 0 {
+			return i
+		}
+		// the suspended processes left in the active array stay in it, ahead of the expired ones.
+		for level := range active {
+			active[level] = append(active[level], expired[level]...)
+		}
+		expired = [o1Levels][]string{}
+		swaps++
+		return first(&active, ready)
+	}
+	remove := func(queue []string, id string) []string {
+		return slices.DeleteFunc(queue, func(q string) bool { return q == id })
+	}
+
+	result, _ := Simulate(processes, pick, SimOptions{
+		Title:      title,
+		Preemptive: true,
+		KeepPlace:  true,
+		Arrived: func(p Process, _ int64) {
+			active[o1Priority(p)] = append(active[o1Priority(p)], p.ProcessID)
+		},
+		Ran: func(p Process, start, stop int64) {
+			timeslice[p.ProcessID] -= stop - start
+			if p.BurstDuration > 0 && timeslice[p.ProcessID] == 0 {
+				level := o1Priority(p)
+				timeslice[p.ProcessID] = o1Timeslice(level)
+				active[level] = remove(active[level], p.ProcessID)
+				expired[level] = append(expired[level], p.ProcessID)
+			}
+		},
+		Left: func(p Process, _ int64) {
+			level := o1Priority(p)
+			active[level], expired[level] = remove(active[level], p.ProcessID), remove(expired[level], p.ProcessID)
+		},
+	})
+	result.Notes = []string{fmt.Sprintf("Active/expired swaps: %d", swaps)}
+	outputResult(w, result)
+
+	return result
+}
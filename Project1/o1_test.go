@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestO1Schedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []TimeSlice
+		wantSwaps string
+	}{
+		{
+			// A and B get timeslices of 4 and then expire, so C at nice 10 runs its slice of 2 every
+			// round instead of waiting for them to complete.
+			name: "expired array",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 10},
+				{ProcessID: "B", ArrivalTime: 0, BurstDuration: 6},
+				{ProcessID: "C", ArrivalTime: 0, BurstDuration: 3, Priority: 10},
+			},
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 4},
+				{PID: "B", Start: 4, Stop: 8},
+				{PID: "C", Start: 8, Stop: 10},
+				{PID: "A", Start: 10, Stop: 14},
+				{PID: "B", Start: 14, Stop: 16},
+				{PID: "C", Start: 16, Stop: 17},
+				{PID: "A", Start: 17, Stop: 19},
+			},
+			wantSwaps: "Active/expired swaps: 2\n",
+		},
+		{
+			// D at nice -5 preempts A, which then runs out the 3 ticks left of its timeslice.
+			name: "preemption",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 6},
+				{ProcessID: "D", ArrivalTime: 1, BurstDuration: 2, Priority: -5},
+			},
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 1},
+				{PID: "D", Start: 1, Stop: 3},
+				{PID: "A", Start: 3, Stop: 8},
+			},
+			wantSwaps: "Active/expired swaps: 1\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			if diff := cmp.Diff(tt.want, O1Schedule(&w, "o1", tt.processes).Gantt); diff != "" {
+				t.Errorf(diff)
+			}
+			if !strings.Contains(w.String(), tt.wantSwaps) {
+				t.Errorf("output is missing %q:\n%s", tt.wantSwaps, w.String())
+			}
+		})
+	}
+}
+
+func Test_o1Timeslice(t *testing.T) {
+	t.Parallel()
+	for nice, want := range map[int64]int64{-20: 32, -5: 20, 0: 4, 5: 3, 19: 1, 40: 1} {
+		if got := o1Timeslice(o1Priority(Process{Priority: nice})); got != want {
+			t.Errorf("timeslice at nice %d = %d, want %d", nice, got, want)
+		}
+	}
+}
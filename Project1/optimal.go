@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+)
+
+// OptimalWait is the offline-optimal average wait of processes, knowing every burst in advance:
+// preemptive is that of SRTF, SJFSchedule, which no single-CPU schedule beats, and nonPreemptive
+// that of non-preemptive SJF, the usual yardstick for schedulers that never preempt, though not
+// strictly optimal once arrivals are staggered.
+func OptimalWait(processes []Process) (preemptive, nonPreemptive float64) {
+	preemptive = SJFSchedule(io.Discard, "optimal", processes).AverageWait
+	nonPreemptive = SJFNonPreemptiveSchedule(io.Discard, "optimal", processes).AverageWait
+
+	return preemptive, nonPreemptive
+}
+
+// PercentOfOptimal is wait as a percentage of the optimal wait: 100 when it's optimal, and +Inf
+// when the optimum is no wait at all but wait isn't.
+func PercentOfOptimal(wait, optimal float64) float64 {
+	if optimal == 0 {
+		if wait == 0 {
+			return 100
+		}
+		return math.Inf(1)
+	}
+	return 100 * wait / optimal
+}
+
+// runOptimal runs every scheduler over processes with opts and writes its average wait as a
+// percentage of the preemptive optimum, and of the non-preemptive SJF baseline the schedulers that
+// never preempt are better held to. Unless opts sets the quantum, the schedulers with one run at
+// every power of two up to the longest burst. The multicore schedulers run on several CPUs, so they
+// can beat the single-CPU optimum. A scheduler that can't run the processes is listed after the
+// table with why. It returns how many runs it made.
+func runOptimal(w io.Writer, processes []Process, opts runOptions) int {
+	preemptive, nonPreemptive := OptimalWait(processes)
+	percent := func(wait, optimal float64) string {
+		if p := PercentOfOptimal(wait, optimal); !math.IsInf(p, 1) {
+			return fmt.Sprintf("%.1f%%", p)
+		}
+		return "-"
+	}
+	quanta := []int64{0}
+	if opts.quantum.ticks == 0 && !opts.quantum.auto {
+		quanta = quanta[:0]
+		var longest int64
+		for _, p := range processes {
+			longest = max(longest, p.BurstDuration)
+		}
+		for quantum := int64(1); quantum == 1 || quantum <= longest; quantum *= 2 {
+			quanta = append(quanta, quantum)
+		}
+	}
+
+	var (
+		rows   [][]string
+		failed []string
+		runs   int
+	)
+	for _, spec := range schedulerSpecs {
+		for _, quantum := range quanta {
+			options, quantumColumn := opts, "-"
+			if takesQuantum(spec.scheduler) {
+				if quantum > 0 {
+					options.quantum = quantumFlag{ticks: quantum}
+				}
+				quantumColumn = strconv.FormatInt(options.resolveQuantum(processes), 10)
+			} else if quantum != quanta[0] {
+				continue
+			}
+			result, err := runScheduler(io.Discard, spec.scheduler, processes, options)
+			if err != nil {
+				failed = append(failed, fmt.Sprintf("%s (%v)", spec.scheduler.Name(), err))
+				break
+			}
+			runs++
+			rows = append(rows, []string{
+				result.Title, quantumColumn, fmt.Sprintf("%.2f", result.AverageWait),
+				percent(result.AverageWait, preemptive), percent(result.AverageWait, nonPreemptive),
+			})
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "Offline-optimal average wait: %.2f (preemptive SRTF), %.2f (non-preemptive SJF)\n", preemptive, nonPreemptive)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Quantum", "Average wait", "Of optimal", "Of non-preemptive SJF"})
+	// the titles are long, but the table reads better a row a line.
+	table.SetAutoWrapText(false)
+	table.AppendBulk(rows)
+	table.Render()
+	if len(failed) > 0 {
+		_, _ = fmt.Fprintf(w, "Not run: %s\n", strings.Join(failed, ", "))
+	}
+
+	return runs
+}
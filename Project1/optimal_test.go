@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestOptimalWait(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 9},
+		{ProcessID: "D", ArrivalTime: 3, BurstDuration: 5},
+	}
+	// waits of 9, 0, 15 and 2 preempting; 0, 7, 15 and 9 not.
+	preemptive, nonPreemptive := OptimalWait(processes)
+	if preemptive != 6.5 || nonPreemptive != 7.75 {
+		t.Errorf("OptimalWait() = %.2f, %.2f, want 6.50, 7.75", preemptive, nonPreemptive)
+	}
+
+	var w bytes.Buffer
+	runs := runOptimal(&w, processes, defaultRunOptions())
+	// every scheduler ran, those with a quantum at 1, 2, 4 and 8.
+	if want := len(schedulerSpecs) + 3*7; runs != want {
+		t.Errorf("runOptimal() = %d runs, want %d", runs, want)
+	}
+	for _, want := range []string{
+		"Offline-optimal average wait: 6.50 (preemptive SRTF), 7.75 (non-preemptive SJF)",
+		"| Shortest-job-first ",
+		" | -       |         6.50 | 100.0%     | 83.9%                 |",
+		// waits of 0, 7, 10 and 18.
+		"| First-come, first-serve ",
+		" | -       |         8.75 | 134.6%     | 112.9%                |",
+		"| Non-preemptive shortest-job-first ",
+		" | -       |         7.75 | 119.2%     | 100.0%                |",
+		"| Round-robin (quantum 8) ",
+		" |       8 |         9.75 | 150.0%     | 125.8%                |",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output is missing %q:\n%s", want, w.String())
+		}
+	}
+
+	// a process pinned to CPU 1 only runs on the five multicore schedulers.
+	processes[0].Affinity = 1 << 1
+	w.Reset()
+	if runs := runOptimal(&w, processes, defaultRunOptions()); runs != 5*4 {
+		t.Errorf("runOptimal() = %d runs, want %d", runs, 5*4)
+	}
+	if want := "Not run: fcfs (unsupported option: process \"A\" may not run on CPU 0"; !strings.Contains(w.String(), want) {
+		t.Errorf("output is missing %q:\n%s", want, w.String())
+	}
+}
+
+func TestPercentOfOptimal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		wait, optimal float64
+		want          float64
+	}{
+		{name: "optimal", wait: 2, optimal: 2, want: 100},
+		{name: "worse", wait: 3, optimal: 2, want: 150},
+		{name: "no wait", wait: 0, optimal: 0, want: 100},
+		{name: "any wait against none", wait: 1, optimal: 0, want: math.Inf(1)},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := PercentOfOptimal(tt.wait, tt.optimal); got != tt.want {
+				t.Errorf("PercentOfOptimal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// schedulerSpec declares a scheduler's command line flag and the tuning options it honours.
+type schedulerSpec struct {
+	scheduler Scheduler
+	usage     string
+	options   []string
+}
+
+// schedulerSpecs are the schedulers selectable on the command line, in help order, followed by
+// the registered algorithms.
+var schedulerSpecs = []schedulerSpec{
+	{scheduler: fcfs, usage: "First-come, first-serve scheduling", options: []string{"mm1"}},
+	{scheduler: sjf, usage: "Shortest-job-first scheduling", options: []string{"noise", "noise-dist", "seed"}},
+	{scheduler: sjfp, usage: "Shortest-job-first with priority scheduling", options: []string{"decay", "priority-floor"}},
+	{scheduler: rr, usage: "Round-robin scheduling", options: []string{"quantum", "percentile"}},
+}
+
+// tuningOptions are the flags that only some schedulers honour.
+var tuningOptions = []string{
+	"quantum", "percentile", "noise", "noise-dist", "seed", "mm1", "decay", "priority-floor",
+	"priority-ceiling", "age", "boost", "srr-a", "srr-b", "min-run", "wait-bound", "quantum-formula",
+	"foreground", "levels", "classify-threshold", "classify-verbose", "cpus", "policy", "steal", "pack",
+	"speeds",
+}
+
+// specFor returns the declaration of a scheduler.
+func specFor(scheduler Scheduler) (schedulerSpec, error) {
+	for _, spec := range schedulerSpecs {
+		if spec.scheduler == scheduler {
+			return spec, nil
+		}
+	}
+	return schedulerSpec{}, fmt.Errorf("%w: %v", ErrUnknownAlgorithm, scheduler)
+}
+
+// checkSchedulerOptions rejects tuning options among set that the scheduler would silently ignore.
+func checkSchedulerOptions(scheduler Scheduler, set []string) error {
+	spec, err := specFor(scheduler)
+	if err != nil {
+		return err
+	}
+	var unsupported []string
+	for _, name := range set {
+		if slices.Contains(tuningOptions, name) && !slices.Contains(spec.options, name) {
+			unsupported = append(unsupported, "-"+name)
+		}
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("%w: %s does not support %s", ErrUnsupportedOption, scheduler.Name(), strings.Join(unsupported, ", "))
+	}
+
+	return nil
+}
+
+// severalCPUs reports whether the scheduler runs on several CPUs, as those taking -cpus or -speeds
+// do.
+func (spec schedulerSpec) severalCPUs() bool {
+	return slices.Contains(spec.options, "cpus") || slices.Contains(spec.options, "speeds")
+}
+
+// takesQuantum reports whether scheduler honours -quantum.
+func takesQuantum(scheduler Scheduler) bool {
+	spec, err := specFor(scheduler)
+	return err == nil && slices.Contains(spec.options, "quantum")
+}
+
+// optionValue is the value of the tuning option of that flag name in o, with the quantum resolved
+// for processes.
+func (o runOptions) optionValue(name string, processes []Process) string {
+	switch name {
+	case "quantum":
+		return strconv.FormatInt(o.resolveQuantum(processes), 10)
+	case "percentile":
+		return strconv.FormatFloat(o.percentile, 'g', -1, 64)
+	case "noise":
+		return strconv.FormatFloat(o.noise, 'g', -1, 64)
+	case "noise-dist":
+		return o.noiseDist
+	case "seed":
+		return strconv.FormatUint(o.seed, 10)
+	case "decay":
+		return strconv.FormatInt(o.decay, 10)
+	case "age":
+		return strconv.FormatInt(o.age, 10)
+	case "priority-floor":
+		return formatPriorityLimit(o.priorityFloor)
+	case "priority-ceiling":
+		return formatPriorityLimit(o.priorityCeiling)
+	case "boost":
+		return strconv.FormatInt(o.boost, 10)
+	case "srr-a":
+		return strconv.FormatInt(o.srrA, 10)
+	case "srr-b":
+		return strconv.FormatInt(o.srrB, 10)
+	case "min-run":
+		return strconv.FormatInt(o.minRun, 10)
+	case "wait-bound":
+		return strconv.FormatInt(o.waitBound, 10)
+	case "quantum-formula":
+		return o.formula
+	case "foreground":
+		return strconv.FormatInt(o.foreground, 10)
+	case "levels":
+		return o.levels.String()
+	case "classify-threshold":
+		return strconv.FormatInt(o.classify, 10)
+	case "classify-verbose":
+		return strconv.FormatBool(o.classifyVerbose)
+	case "cpus":
+		return strconv.Itoa(o.cpus)
+	case "policy":
+		return o.policy.String()
+	case "steal":
+		return strconv.Itoa(o.steal)
+	case "pack":
+		return o.pack
+	case "speeds":
+		return o.speeds.String()
+	}
+	return ""
+}
+
+// schedulerHelp lists every scheduler with the tuning options it supports.
+func schedulerHelp() string {
+	var sb strings.Builder
+	sb.WriteString("Schedulers and their options:\n")
+	for _, spec := range schedulerSpecs {
+		options := "none"
+		if len(spec.options) > 0 {
+			options = "-" + strings.Join(spec.options, ", -")
+		}
+		_, _ = fmt.Fprintf(&sb, "  -%s\toptions: %s\n", spec.scheduler.Name(), options)
+	}
+
+	return sb.String()
+}
+
+// formatPriorityLimit is a -priority-floor or -priority-ceiling, "none" if unset.
+func formatPriorityLimit(limit *int64) string {
+	if limit == nil {
+		return "none"
+	}
+	return strconv.FormatInt(*limit, 10)
+}
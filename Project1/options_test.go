@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_checkSchedulerOptions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		scheduler Scheduler
+		set       []string
+		wantErr   error
+	}{
+		{name: "rr quantum", scheduler: rr, set: []string{"quantum", "percentile"}},
+		{name: "fcfs without options", scheduler: fcfs, set: []string{"debug", "strict"}},
+		{name: "fcfs quantum", scheduler: fcfs, set: []string{"quantum"}, wantErr: ErrUnsupportedOption},
+		{name: "sjf percentile", scheduler: sjf, set: []string{"debug", "percentile"}, wantErr: ErrUnsupportedOption},
+		{name: "unknown scheduler", scheduler: Scheduler(99), wantErr: ErrUnknownAlgorithm},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := checkSchedulerOptions(tt.scheduler, tt.set)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkSchedulerOptions() error = %v, want %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrUnsupportedOption) && !strings.Contains(err.Error(), tt.scheduler.String()) {
+				t.Errorf("error %q does not name the scheduler", err)
+			}
+		})
+	}
+}
+
+func Test_parseCLI_unsupportedOption(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		args         []string
+		wantErr      error
+		wantWarnings int
+	}{
+		{
+			name:    "error",
+			args:    []string{"-fcfs", "-quantum", "3", "example_processes.csv"},
+			wantErr: ErrUnsupportedOption,
+		},
+		{
+			name:         "warn",
+			args:         []string{"-fcfs", "-quantum", "3", "-warn-unsupported", "example_processes.csv"},
+			wantWarnings: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			flagSet := flag.NewFlagSet(tt.name, flag.ContinueOnError)
+			flagSet.SetOutput(io.Discard)
+			cfg, err := parseCLI(flagSet, tt.args)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseCLI() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(cfg.warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %v, want %d", cfg.warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func Test_schedulerHelp(t *testing.T) {
+	t.Parallel()
+	help := schedulerHelp()
+	lines := strings.Split(strings.TrimSpace(help), "\n")[1:]
+	if len(lines) != len(schedulerSpecs) {
+		t.Fatalf("help lists %d schedulers, want %d:\n%s", len(lines), len(schedulerSpecs), help)
+	}
+	for i, spec := range schedulerSpecs {
+		if !strings.HasPrefix(strings.TrimSpace(lines[i]), "-"+spec.scheduler.Name()+"\t") {
+			t.Errorf("line %d = %q, want scheduler %s", i, lines[i], spec.scheduler)
+		}
+		for _, option := range spec.options {
+			if !strings.Contains(lines[i], "-"+option) {
+				t.Errorf("line %d = %q, missing option -%s", i, lines[i], option)
+			}
+		}
+		if len(spec.options) == 0 && !strings.HasSuffix(lines[i], "options: none") {
+			t.Errorf("line %d = %q, want no options", i, lines[i])
+		}
+	}
+}
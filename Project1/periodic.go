@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+)
+
+// PeriodicTask releases a job of Execution ticks every Period ticks from time 0, each due by the
+// next release.
+type PeriodicTask struct {
+	ID        string
+	Period    int64
+	Execution int64
+	// Instances is how many jobs the task releases. 0 releases them through the hyperperiod.
+	Instances int
+}
+
+// Utilization is the share of the CPU tasks need: the sum of their Execution / Period.
+func Utilization(tasks []PeriodicTask) float64 {
+	var u float64
+	for _, task := range tasks {
+		u += float64(task.Execution) / float64(task.Period)
+	}
+	return u
+}
+
+// UtilizationBound is the Liu & Layland bound n(2^(1/n) - 1): rate-monotonic scheduling meets
+// every deadline of n tasks whose utilization is at most this.
+func UtilizationBound(n int) float64 {
+	return float64(n) * (math.Pow(2, 1/float64(n)) - 1)
+}
+
+// Hyperperiod is the least common multiple of the task periods, after which the releases repeat.
+// It fails when a period isn't positive or the least common multiple overflows an int64.
+func Hyperperiod(tasks []PeriodicTask) (int64, error) {
+	hyperperiod := int64(1)
+	for _, task := range tasks {
+		if task.Period <= 0 {
+			return 0, fmt.Errorf("%w: task %q has period %d", ErrInvalidArgs, task.ID, task.Period)
+		}
+		a, b := hyperperiod, task.Period
+		for b != 0 {
+			a, b = b, a%b
+		}
+		if hyperperiod/a > math.MaxInt64/task.Period {
+			return 0, fmt.Errorf("%w: the hyperperiod of the task periods overflows at task %q", ErrInvalidArgs, task.ID)
+		}
+		hyperperiod = hyperperiod / a * task.Period
+	}
+	return hyperperiod, nil
+}
+
+// periodicJobs expands tasks into one process per job: job k of task T is "T.k", released at
+// (k-1) * Period with its Period as priority and its next release as soft deadline.
+func periodicJobs(tasks []PeriodicTask) ([]Process, error) {
+	for _, task := range tasks {
+		if task.Period <= 0 {
+			return nil, fmt.Errorf("%w: task %q has period %d", ErrInvalidArgs, task.ID, task.Period)
+		}
+		if task.Instances < 0 {
+			return nil, fmt.Errorf("%w: task %q has %d instances", ErrInvalidArgs, task.ID, task.Instances)
+		}
+	}
+	var hyperperiod int64
+	if slices.ContainsFunc(tasks, func(task PeriodicTask) bool { return task.Instances == 0 }) {
+		var err error
+		if hyperperiod, err = Hyperperiod(tasks); err != nil {
+			return nil, err
+		}
+	}
+	var jobs []Process
+	for _, task := range tasks {
+		instances := int64(task.Instances)
+		if instances == 0 {
+			instances = hyperperiod / task.Period
+		}
+		for k := int64(1); k <= instances; k++ {
+			jobs = append(jobs, Process{
+				ProcessID:     fmt.Sprintf("%s.%d", task.ID, k),
+				ArrivalTime:   (k - 1) * task.Period,
+				BurstDuration: task.Execution,
+				Priority:      task.Period,
+				SoftDeadline:  k * task.Period,
+			})
+		}
+	}
+	if err := ValidateProcesses(jobs); err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// RMSchedule is rate-monotonic scheduling of periodic tasks: every job has its task's period as
+// priority, so the shorter the period the higher the priority, and a job preempts any running job
+// of a longer period. It checks the tasks' utilization against the Liu & Layland bound and writes
+// the schedule, the job releases and the deadlines missed. A job that misses its deadline still
+// runs to completion.
+func RMSchedule(w io.Writer, title string, tasks []PeriodicTask) (ScheduleResult, error) {
+	if len(tasks) == 0 {
+		return ScheduleResult{}, ErrNoProcesses
+	}
+	jobs, err := periodicJobs(tasks)
+	if err != nil {
+		return ScheduleResult{}, err
+	}
+
+	result := PreemptivePrioritySchedule(io.Discard, title, jobs)
+	result.Notes = noteLines(func(w io.Writer) {
+		outputReleases(w, jobs)
+		outputUtilization(w, tasks)
+	})
+	outputResult(w, result)
+
+	return result, nil
+}
+
+// outputReleases lists the jobs released at each release time.
+func outputReleases(w io.Writer, jobs []Process) {
+	_, _ = fmt.Fprintln(w, "Job releases")
+	releases := make(map[int64][]string)
+	var times []int64
+	for _, job := range jobs {
+		if _, ok := releases[job.ArrivalTime]; !ok {
+			times = append(times, job.ArrivalTime)
+		}
+		releases[job.ArrivalTime] = append(releases[job.ArrivalTime], job.ProcessID)
+	}
+	slices.Sort(times)
+	for _, t := range times {
+		_, _ = fmt.Fprintf(w, "%d: %s\n", t, strings.Join(releases[t], ", "))
+	}
+}
+
+// outputUtilization writes the tasks' utilization against the Liu & Layland bound and what it
+// guarantees.
+func outputUtilization(w io.Writer, tasks []PeriodicTask) {
+	u, bound := Utilization(tasks), UtilizationBound(len(tasks))
+	verdict := "schedulable"
+	switch {
+	case u > 1:
+		verdict = "overloaded, deadlines will be missed"
+	case u > bound:
+		verdict = "not guaranteed by the bound"
+	}
+	_, _ = fmt.Fprintf(w, "Utilization: %.2f (bound for %d tasks: %.2f), %s\n", u, len(tasks), bound, verdict)
+}
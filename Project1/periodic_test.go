@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRMSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		tasks      []PeriodicTask
+		want       []TimeSlice
+		wantOutput []string
+	}{
+		{
+			name:  "under the bound",
+			tasks: []PeriodicTask{{ID: "T1", Period: 2, Execution: 1}, {ID: "T2", Period: 4, Execution: 1}},
+			want: []TimeSlice{
+				{PID: "T1.1", Start: 0, Stop: 1},
+				{PID: "T2.1", Start: 1, Stop: 2},
+				{PID: "T1.2", Start: 2, Stop: 3},
+			},
+			wantOutput: []string{
+				"Job releases\n0: T1.1, T2.1\n2: T1.2\n",
+				"Utilization: 0.75 (bound for 2 tasks: 0.83), schedulable\n",
+			},
+		},
+		{
+			// T1's releases at 2 and 4 preempt T2, whose both jobs complete a tick late.
+			name:  "overloaded",
+			tasks: []PeriodicTask{{ID: "T1", Period: 2, Execution: 1}, {ID: "T2", Period: 3, Execution: 2}},
+			want: []TimeSlice{
+				{PID: "T1.1", Start: 0, Stop: 1},
+				{PID: "T2.1", Start: 1, Stop: 2},
+				{PID: "T1.2", Start: 2, Stop: 3},
+				{PID: "T2.1", Start: 3, Stop: 4},
+				{PID: "T1.3", Start: 4, Stop: 5},
+				{PID: "T2.2", Start: 5, Stop: 7},
+			},
+			wantOutput: []string{
+				"Missed deadlines: 2 (T2.1, T2.2), 2 late in total\n",
+				"Job releases\n0: T1.1, T2.1\n2: T1.2\n3: T2.2\n4: T1.3\n",
+				"Utilization: 1.17 (bound for 2 tasks: 0.83), overloaded, deadlines will be missed\n",
+			},
+		},
+		{
+			name:  "instances",
+			tasks: []PeriodicTask{{ID: "T1", Period: 3, Execution: 1, Instances: 1}, {ID: "T2", Period: 4, Execution: 2, Instances: 2}},
+			want: []TimeSlice{
+				{PID: "T1.1", Start: 0, Stop: 1},
+				{PID: "T2.1", Start: 1, Stop: 3},
+				{PID: "T2.2", Start: 4, Stop: 6},
+			},
+			wantOutput: []string{
+				"Job releases\n0: T1.1, T2.1\n4: T2.2\n",
+				"Utilization: 0.83 (bound for 2 tasks: 0.83), not guaranteed by the bound\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			result, err := RMSchedule(&w, "rm", tt.tasks)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, result.Gantt); diff != "" {
+				t.Errorf(diff)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("output is missing %q:\n%s", want, w.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRMSchedule_errors(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		tasks   []PeriodicTask
+		wantErr error
+	}{
+		{name: "no tasks", wantErr: ErrNoProcesses},
+		{name: "zero period", tasks: []PeriodicTask{{ID: "T1", Execution: 1}}, wantErr: ErrInvalidArgs},
+		{
+			name:    "hyperperiod overflow",
+			tasks:   []PeriodicTask{{ID: "T1", Period: 1 << 40, Execution: 1}, {ID: "T2", Period: 1<<40 - 1, Execution: 1}},
+			wantErr: ErrInvalidArgs,
+		},
+		{name: "negative instances", tasks: []PeriodicTask{{ID: "T1", Period: 2, Execution: 1, Instances: -1}}, wantErr: ErrInvalidArgs},
+		{name: "zero execution", tasks: []PeriodicTask{{ID: "T1", Period: 2}}, wantErr: ErrNegativeBurst},
+		{
+			name:    "duplicate ID",
+			tasks:   []PeriodicTask{{ID: "T1", Period: 2, Execution: 1}, {ID: "T1", Period: 4, Execution: 1}},
+			wantErr: ErrDuplicateID,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := RMSchedule(io.Discard, "rm", tt.tasks); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHyperperiod(t *testing.T) {
+	t.Parallel()
+	tasks := []PeriodicTask{{Period: 4}, {Period: 6}, {Period: 10}}
+	if got, err := Hyperperiod(tasks); err != nil || got != 60 {
+		t.Errorf("Hyperperiod() = %d, %v, want 60", got, err)
+	}
+	overflowing := []PeriodicTask{{Period: 1 << 40}, {Period: 1<<40 - 1}}
+	if _, err := Hyperperiod(overflowing); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("Hyperperiod() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// plannedRun is one scheduler run a command line or batch line asks for, with its input
+// loaded and validated and its options resolved, but not yet simulated.
+type plannedRun struct {
+	scheduler Scheduler
+	input     string
+	digest    []byte
+	processes []Process
+	options   runOptions
+	outputs   []string
+}
+
+// batchErrors is every bad line planBatch found, one error each.
+type batchErrors []error
+
+func (errs batchErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (errs batchErrors) Unwrap() []error { return errs }
+
+// planBatch plans every line of a batch script without running any. Unlike RunBatch it
+// carries on past a bad line, so the error, a batchErrors, reports every line that would fail.
+func planBatch(r io.Reader) ([]plannedRun, error) {
+	var (
+		plans []plannedRun
+		errs  batchErrors
+	)
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			errs = append(errs, fmt.Errorf("%w: batch line %d: expected \"<scheduler> <file>\", got %q", ErrInvalidArgs, lineNo, line))
+			continue
+		}
+		plan, err := planBatchLine(fields[0], fields[1])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("batch line %d: %w", lineNo, err))
+			continue
+		}
+		plans = append(plans, plan)
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return plans, errs
+	}
+
+	return plans, nil
+}
+
+func planBatchFile(name string) ([]plannedRun, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: error opening batch file", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return planBatch(f)
+}
+
+// planBatchLine loads and validates the process file of a batch line. Batch lines always
+// run with the default options and write to stdout.
+func planBatchLine(name, file string) (plannedRun, error) {
+	scheduler, err := parseScheduler(name)
+	if err != nil {
+		return plannedRun{}, err
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return plannedRun{}, fmt.Errorf("%w: error opening data file", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	digest := sha256.New()
+	processes, err := loadProcesses(io.TeeReader(f, digest))
+	if err != nil {
+		return plannedRun{}, err
+	}
+	if err := ValidateProcesses(processes); err != nil {
+		return plannedRun{}, err
+	}
+
+	return plannedRun{
+		scheduler: scheduler,
+		input:     file,
+		digest:    digest.Sum(nil),
+		processes: processes,
+		outputs:   []string{"stdout"},
+	}, nil
+}
+
+// plan is the run cfg asks for of scheduler over processes.
+func (cfg cliConfig) plan(scheduler Scheduler, processes []Process) plannedRun {
+	outputs := []string{"stdout"}
+	if cfg.savePath != "" {
+		outputs = append(outputs, "result "+cfg.savePath)
+	}
+	if cfg.eventsPath != "" {
+		outputs = append(outputs, "events "+cfg.eventsPath)
+	}
+	return plannedRun{
+		scheduler: scheduler,
+		input:     cfg.inputName,
+		processes: processes,
+		options:   cfg.options,
+		outputs:   outputs,
+	}
+}
+
+// effectiveOptions describes the options the run's scheduler will use, with the quantum
+// resolved if it's picked automatically.
+func (p plannedRun) effectiveOptions() []string {
+	var opts []string
+	switch p.scheduler {
+	case sjf:
+		if p.options.noise > 0 {
+			opts = append(opts, fmt.Sprintf("noise=%g noise-dist=%s seed=%d", p.options.noise, p.options.noiseDist, p.options.seed))
+		}
+	case sjfp:
+		if p.options.decay > 0 {
+			opts = append(opts, fmt.Sprintf("decay=%d", p.options.decay))
+			if p.options.priorityFloor != nil {
+				opts = append(opts, fmt.Sprintf("priority-floor=%d", *p.options.priorityFloor))
+			}
+		}
+	case rr:
+		quantum := fmt.Sprintf("quantum=%d", p.options.resolveQuantum(p.processes))
+		if p.options.quantum.auto {
+			quantum += fmt.Sprintf(" (auto at p%g)", p.options.percentile)
+		}
+		opts = append(opts, quantum)
+	default:
+		if _, ok := registered(p.scheduler); !ok {
+			break
+		}
+		// a registered algorithm runs with every tuning option it honours.
+		spec, _ := specFor(p.scheduler)
+		for _, option := range spec.options {
+			if option == "percentile" && !p.options.quantum.auto {
+				continue
+			}
+			opts = append(opts, option+"="+p.options.optionValue(option, p.processes))
+		}
+	}
+	if p.options.pauseAt != nil {
+		opts = append(opts, fmt.Sprintf("pause-at=%d", *p.options.pauseAt))
+	}
+	if p.options.resume != nil {
+		opts = append(opts, fmt.Sprintf("resume-at=%d", p.options.resume.Time))
+	}
+	if p.options.queue {
+		opts = append(opts, "queue")
+	}
+	return opts
+}
+
+// outputPlan prints what each planned run would do, one numbered line per run.
+func outputPlan(w io.Writer, plans []plannedRun) {
+	_, _ = fmt.Fprintln(w, "Dry run, nothing simulated")
+	for i, p := range plans {
+		options := "default options"
+		if opts := p.effectiveOptions(); len(opts) > 0 {
+			options = strings.Join(opts, " ")
+		}
+		_, _ = fmt.Fprintf(w, "%d. %s %s (%d processes), %s -> %s\n",
+			i+1, p.scheduler.Name(), p.input, len(p.processes), options, strings.Join(p.outputs, ", "))
+	}
+}
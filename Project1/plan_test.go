@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPlanBatch(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	small := filepath.Join(dir, "small.csv")
+	large := filepath.Join(dir, "large.csv")
+	for name, data := range map[string]string{
+		small: "ProcessID,Burst Duration,Arrival Time,Priority\nP0,5,0,2\nP1,9,3,1\n",
+		large: "ProcessID,Burst Duration,Arrival Time,Priority\nP0,5,0,2\nP1,9,3,1\nP2,6,6,3\nP3,2,7,1\n",
+	} {
+		if err := os.WriteFile(name, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		batch    string
+		wantOut  string
+		wantErrs []string
+	}{
+		{
+			name:  "two files, three schedulers",
+			batch: "fcfs " + small + "\nsjfp " + large + "\n# tuned later\nrr " + large + "\n",
+			wantOut: "Dry run, nothing simulated\n" +
+				"1. fcfs " + small + " (2 processes), default options -> stdout\n" +
+				"2. sjfp " + large + " (4 processes), default options -> stdout\n" +
+				"3. rr " + large + " (4 processes), quantum=4 -> stdout\n",
+		},
+		{
+			name:  "every bad line",
+			batch: "fcfs " + small + "\nsjf " + filepath.Join(dir, "missing.csv") + "\nnonesuch " + small + "\nrr\n",
+			wantErrs: []string{
+				"batch line 2: ",
+				"batch line 3: ",
+				"batch line 4: ",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			plans, err := planBatch(strings.NewReader(tt.batch))
+			for _, want := range tt.wantErrs {
+				if err == nil || !strings.Contains(err.Error(), want) {
+					t.Errorf("error = %v, want it to report %q", err, want)
+				}
+			}
+			if len(tt.wantErrs) == 0 && err != nil {
+				t.Fatalf("planBatch() error = %v", err)
+			}
+			if !errors.Is(err, os.ErrNotExist) && len(tt.wantErrs) > 0 {
+				t.Errorf("error = %v, want it to wrap %v", err, os.ErrNotExist)
+			}
+			if len(tt.wantErrs) > 0 {
+				return
+			}
+
+			var w bytes.Buffer
+			outputPlan(&w, plans)
+			if diff := cmp.Diff(tt.wantOut, w.String()); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestCLIConfig_plan(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", BurstDuration: 2},
+		{ProcessID: "P1", BurstDuration: 10},
+	}
+	cfg := cliConfig{inputName: "procs.csv", savePath: "out/result.json", eventsPath: "out/events.csv"}
+	cfg.options.quantum.auto, cfg.options.percentile = true, 50
+	cfg.options.queue = true
+
+	var w bytes.Buffer
+	outputPlan(&w, []plannedRun{cfg.plan(rr, processes), cfg.plan(fcfs, processes)})
+	want := "Dry run, nothing simulated\n" +
+		"1. rr procs.csv (2 processes), quantum=2 (auto at p50) queue -> stdout, result out/result.json, events out/events.csv\n" +
+		"2. fcfs procs.csv (2 processes), queue -> stdout, result out/result.json, events out/events.csv\n"
+	if diff := cmp.Diff(want, w.String()); diff != "" {
+		t.Errorf(diff)
+	}
+}
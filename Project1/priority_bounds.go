@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// PriorityOrder says which direction of priority numbers is more important.
+type PriorityOrder int
+
+const (
+	// LowerIsHigher treats smaller numbers as more important, like the priority heap and Unix nice.
+	LowerIsHigher PriorityOrder = iota
+	// HigherIsHigher treats larger numbers as more important.
+	HigherIsHigher
+)
+
+// PriorityBounds keeps effective priorities between a floor (least important allowed) and a
+// ceiling (most important allowed), so adjustments such as aging can't drift into reserved
+// levels or wrap around int64.
+type PriorityBounds struct {
+	Floor   int64
+	Ceiling int64
+	Order   PriorityOrder
+}
+
+// UnboundedPriorities allows every int64 priority; arithmetic still saturates instead of wrapping.
+var UnboundedPriorities = PriorityBounds{Floor: math.MaxInt64, Ceiling: math.MinInt64, Order: LowerIsHigher}
+
+// Validate reports whether the floor is actually less important than the ceiling for the order.
+func (b PriorityBounds) Validate() error {
+	if b.moreImportant(b.Floor, b.Ceiling) {
+		return fmt.Errorf("%w: priority floor %d is more important than ceiling %d", ErrInvalidArgs, b.Floor, b.Ceiling)
+	}
+	return nil
+}
+
+// Clamp limits a priority to the bounds.
+func (b PriorityBounds) Clamp(priority int64) int64 {
+	if b.moreImportant(priority, b.Ceiling) {
+		return b.Ceiling
+	}
+	if b.moreImportant(b.Floor, priority) {
+		return b.Floor
+	}
+	return priority
+}
+
+// Boost makes priority more important by steps, stopping at the ceiling.
+// It reports whether the result is at the ceiling.
+func (b PriorityBounds) Boost(priority, steps int64) (int64, bool) {
+	boosted := b.Clamp(saturatingAdd(priority, b.towardCeiling(steps)))
+	return boosted, boosted == b.Ceiling
+}
+
+// Demote makes priority less important by steps, stopping at the floor.
+func (b PriorityBounds) Demote(priority, steps int64) int64 {
+	return b.Clamp(saturatingAdd(priority, -b.towardCeiling(steps)))
+}
+
+// moreImportant reports whether priority x is strictly more important than y.
+func (b PriorityBounds) moreImportant(x, y int64) bool {
+	if b.Order == HigherIsHigher {
+		return x > y
+	}
+	return x < y
+}
+
+// towardCeiling signs steps so adding it moves a priority toward the ceiling.
+func (b PriorityBounds) towardCeiling(steps int64) int64 {
+	if b.Order == HigherIsHigher {
+		return steps
+	}
+	if steps == math.MinInt64 {
+		return math.MaxInt64
+	}
+	return -steps
+}
+
+// saturatingAdd adds two int64s, sticking at the limits instead of overflowing.
+func saturatingAdd(a, b int64) int64 {
+	sum := a + b
+	switch {
+	case a > 0 && b > 0 && sum < 0:
+		return math.MaxInt64
+	case a < 0 && b < 0 && sum >= 0:
+		return math.MinInt64
+	default:
+		return sum
+	}
+}
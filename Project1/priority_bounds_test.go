@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPriorityBounds_Boost(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		bounds PriorityBounds
+		start  int64
+		step   int64
+	}{
+		{
+			name:   "lower is higher",
+			bounds: PriorityBounds{Floor: 139, Ceiling: 100, Order: LowerIsHigher},
+			start:  139,
+			step:   3,
+		},
+		{
+			name:   "higher is higher",
+			bounds: PriorityBounds{Floor: 0, Ceiling: 99, Order: HigherIsHigher},
+			start:  0,
+			step:   7,
+		},
+		{
+			name:   "unbounded saturates",
+			bounds: UnboundedPriorities,
+			start:  0,
+			step:   math.MaxInt64 / 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.bounds.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			// a process starving for a very long time keeps getting boosted.
+			priority, hit := tt.start, false
+			for tick := 0; tick < 10_000; tick++ {
+				priority, hit = tt.bounds.Boost(priority, tt.step)
+				if tt.bounds.moreImportant(priority, tt.bounds.Ceiling) {
+					t.Fatalf("tick %d: priority %d passed ceiling %d", tick, priority, tt.bounds.Ceiling)
+				}
+			}
+			if !hit || priority != tt.bounds.Ceiling {
+				t.Errorf("priority = %d (ceiling hit %v), want ceiling %d", priority, hit, tt.bounds.Ceiling)
+			}
+		})
+	}
+}
+
+func TestPriorityBounds_Demote(t *testing.T) {
+	t.Parallel()
+	bounds := PriorityBounds{Floor: 139, Ceiling: 100, Order: LowerIsHigher}
+	if got := bounds.Demote(120, 5); got != 125 {
+		t.Errorf("Demote() = %d, want 125", got)
+	}
+	if got := bounds.Demote(138, 5); got != 139 {
+		t.Errorf("Demote() = %d, want floor 139", got)
+	}
+	if got, hit := bounds.Boost(120, 5); got != 115 || hit {
+		t.Errorf("Boost() = %d, %v, want 115, false", got, hit)
+	}
+}
+
+func TestPriorityBounds_Validate(t *testing.T) {
+	t.Parallel()
+	inverted := PriorityBounds{Floor: 100, Ceiling: 139, Order: LowerIsHigher}
+	if err := inverted.Validate(); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("Validate() error = %v, want %v", err, ErrInvalidArgs)
+	}
+	inverted.Order = HigherIsHigher
+	if err := inverted.Validate(); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+}
+
+func Test_saturatingAdd(t *testing.T) {
+	t.Parallel()
+	if got := saturatingAdd(math.MaxInt64-1, 10); got != math.MaxInt64 {
+		t.Errorf("saturatingAdd() = %d, want MaxInt64", got)
+	}
+	if got := saturatingAdd(math.MinInt64+1, -10); got != math.MinInt64 {
+		t.Errorf("saturatingAdd() = %d, want MinInt64", got)
+	}
+	if got := saturatingAdd(3, -5); got != -2 {
+		t.Errorf("saturatingAdd() = %d, want -2", got)
+	}
+}
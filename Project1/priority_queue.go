@@ -0,0 +1,57 @@
+package main
+
+import "cmp"
+
+// Item is an entry in a PriorityQueue.
+type Item struct {
+	Value    any
+	Priority int64
+	index    int
+	// seq is set on the item's first Push, so equal priorities pop first in, first out.
+	seq uint64
+}
+
+// PriorityQueue implements heap.Interface on a pointer; lower Priority values pop first, and
+// equal ones in the order their items were first pushed onto it.
+type PriorityQueue struct {
+	items []*Item
+	// pushed numbers the items first pushed onto this queue.
+	pushed uint64
+}
+
+func (pq *PriorityQueue) Len() int { return len(pq.items) }
+
+func (pq *PriorityQueue) Less(i, j int) bool {
+	return compareItems(pq.items[i], pq.items[j]) < 0
+}
+
+func compareItems(a, b *Item) int {
+	return cmp.Or(cmp.Compare(a.Priority, b.Priority), cmp.Compare(a.seq, b.seq))
+}
+
+func (pq *PriorityQueue) Swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
+
+// Push appends x, an *Item. An item pushed back after being popped keeps its place
+// among equal priorities.
+func (pq *PriorityQueue) Push(x any) {
+	item := x.(*Item)
+	if item.seq == 0 {
+		pq.pushed++
+		item.seq = pq.pushed
+	}
+	item.index = len(pq.items)
+	pq.items = append(pq.items, item)
+}
+
+func (pq *PriorityQueue) Pop() any {
+	n := len(pq.items)
+	item := pq.items[n-1]
+	pq.items[n-1] = nil
+	item.index = -1
+	pq.items = pq.items[:n-1]
+	return item
+}
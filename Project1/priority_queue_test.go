@@ -0,0 +1,64 @@
+package main
+
+import (
+	"container/heap"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPriorityQueue_FIFO(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		items []*Item
+		want  []string
+	}{
+		{
+			name: "equal priorities",
+			items: []*Item{
+				{Value: "a", Priority: 3}, {Value: "b", Priority: 3}, {Value: "c", Priority: 3},
+				{Value: "d", Priority: 3}, {Value: "e", Priority: 3}, {Value: "f", Priority: 3},
+			},
+			want: []string{"a", "b", "c", "d", "e", "f"},
+		},
+		{
+			name: "mixed priorities",
+			items: []*Item{
+				{Value: "low1", Priority: 9}, {Value: "high1", Priority: 1}, {Value: "low2", Priority: 9},
+				{Value: "mid", Priority: 5}, {Value: "high2", Priority: 1}, {Value: "low3", Priority: 9},
+			},
+			want: []string{"high1", "high2", "mid", "low1", "low2", "low3"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var pq PriorityQueue
+			for _, item := range tt.items {
+				heap.Push(&pq, item)
+			}
+			var got []string
+			for pq.Len() > 0 {
+				got = append(got, heap.Pop(&pq).(*Item).Value.(string))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestPriorityQueue_PushBackKeepsPlace(t *testing.T) {
+	t.Parallel()
+	var pq PriorityQueue
+	for _, v := range []string{"a", "b", "c"} {
+		heap.Push(&pq, &Item{Value: v, Priority: 1})
+	}
+	// a is popped and pushed back, as a scheduler does with the process it ran.
+	heap.Push(&pq, heap.Pop(&pq))
+	if got := heap.Pop(&pq).(*Item).Value; got != "a" {
+		t.Errorf("first pop = %v, want a back at the front of its priority", got)
+	}
+}
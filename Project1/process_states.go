@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ProcessState is what a process is doing during a StateInterval.
+type ProcessState string
+
+const (
+	StateReady     ProcessState = "ready"
+	StateRunning   ProcessState = "running"
+	StateSuspended ProcessState = "suspended"
+	StateThrottled ProcessState = "throttled"
+	StateBlocked   ProcessState = "blocked"
+)
+
+// StateInterval is the half-open interval [Start, Stop) a process spends in State.
+type StateInterval struct {
+	Start int64        `json:"start"`
+	Stop  int64        `json:"stop"`
+	State ProcessState `json:"state"`
+}
+
+// StateTimeline replays the events of result into each process' state history. Running
+// intervals come from dispatches and the events that take the process off the CPU again, and the
+// time in between is ready or, inside a suspension, I/O or throttled window, suspended, blocked
+// or throttled. The intervals of a process tile its lifetime (see lifetime) and neighbouring
+// intervals never share a state.
+func StateTimeline(result ScheduleResult) map[string][]StateInterval {
+	running := make(map[string][]StateInterval, len(result.Processes))
+	dispatched := make(map[string]int64)
+	ReplayEvents(result, func(e Event) {
+		switch e.Kind {
+		case EventDispatch:
+			dispatched[e.PID] = e.Time
+		case EventPreempt, EventComplete, EventKill, EventLimit, EventBlock:
+			// a process also blocks and is killed off the CPU.
+			if start, ok := dispatched[e.PID]; ok {
+				running[e.PID] = append(running[e.PID], StateInterval{Start: start, Stop: e.Time, State: StateRunning})
+				delete(dispatched, e.PID)
+			}
+		}
+	})
+
+	makespan := Makespan(result.Gantt)
+	timeline := make(map[string][]StateInterval, len(result.Processes))
+	for _, p := range result.Processes {
+		start, end := p.lifetime(makespan)
+		var intervals []StateInterval
+		for _, r := range running[p.ProcessID] {
+			intervals = p.appendOffCPU(intervals, start, r.Start)
+			intervals = appendStateInterval(intervals, r)
+			start = r.Stop
+		}
+		timeline[p.ProcessID] = p.appendOffCPU(intervals, start, end)
+	}
+
+	return timeline
+}
+
+// lifetime is the span a process is in the system: from arrival to completion, to the kill
+// time of an aborted process, to when a process reached its CPU limit, or to the makespan of a
+// schedule it didn't finish in.
+func (p ProcessResult) lifetime(makespan int64) (start, end int64) {
+	end = makespan
+	switch {
+	case p.Remaining == 0:
+		end = p.Completion
+	case p.Limited:
+		end = p.Terminated
+	case p.Aborted:
+		end = min(end, p.KillAt)
+	}
+	start = max(p.ArrivalTime, 0)
+	return start, max(start, end)
+}
+
+// appendOffCPU appends [from, to) as ready time, split wherever p is suspended, blocked on I/O or
+// throttled.
+func (p ProcessResult) appendOffCPU(intervals []StateInterval, from, to int64) []StateInterval {
+	if to <= from {
+		return intervals
+	}
+	bounds := []int64{from, to}
+	for _, w := range slices.Concat(p.Suspensions, p.IO, p.Throttled) {
+		for _, t := range []int64{w.Start, w.Stop} {
+			if from < t && t < to {
+				bounds = append(bounds, t)
+			}
+		}
+	}
+	slices.Sort(bounds)
+	bounds = slices.Compact(bounds)
+
+	for i := 0; i+1 < len(bounds); i++ {
+		state := StateReady
+		switch {
+		case p.suspendedAt(bounds[i]):
+			state = StateSuspended
+		case slices.ContainsFunc(p.IO, func(w Window) bool { return w.Start <= bounds[i] && bounds[i] < w.Stop }):
+			state = StateBlocked
+		case slices.ContainsFunc(p.Throttled, func(w Window) bool { return w.Start <= bounds[i] && bounds[i] < w.Stop }):
+			state = StateThrottled
+		}
+		intervals = appendStateInterval(intervals, StateInterval{Start: bounds[i], Stop: bounds[i+1], State: state})
+	}
+	return intervals
+}
+
+// appendStateInterval adds an interval, extending the last one when it continues the same state.
+func appendStateInterval(intervals []StateInterval, interval StateInterval) []StateInterval {
+	if n := len(intervals); n > 0 && intervals[n-1].State == interval.State && intervals[n-1].Stop == interval.Start {
+		intervals[n-1].Stop = interval.Stop
+		return intervals
+	}
+	return append(intervals, interval)
+}
+
+// VerifyStateTimeline checks that every process' intervals tile its lifetime in result, with no
+// gaps, overlaps or empty intervals.
+func VerifyStateTimeline(result ScheduleResult, timeline map[string][]StateInterval) error {
+	makespan := Makespan(result.Gantt)
+	for _, p := range result.Processes {
+		start, end := p.lifetime(makespan)
+		reached := start
+		for _, interval := range timeline[p.ProcessID] {
+			if interval.Start != reached || interval.Stop <= interval.Start {
+				return fmt.Errorf("%w: %s is %s over [%d,%d) but its history reached %d",
+					ErrStateTimeline, p.ProcessID, interval.State, interval.Start, interval.Stop, reached)
+			}
+			reached = interval.Stop
+		}
+		if reached != end {
+			return fmt.Errorf("%w: %s's history ends at %d, not %d", ErrStateTimeline, p.ProcessID, reached, end)
+		}
+	}
+	return nil
+}
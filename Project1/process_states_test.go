@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStateTimeline(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 7, Suspensions: []Window{{Start: 2, Stop: 5}}},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 5, KillAt: 9},
+	}
+	result := RRSchedule(io.Discard, "rr", processes)
+
+	want := map[string][]StateInterval{
+		"A": {
+			{Start: 0, Stop: 2, State: StateRunning},
+			{Start: 2, Stop: 5, State: StateSuspended},
+			{Start: 5, Stop: 9, State: StateReady},
+			{Start: 9, Stop: 14, State: StateRunning},
+		},
+		"B": {
+			{Start: 1, Stop: 2, State: StateReady},
+			{Start: 2, Stop: 5, State: StateRunning},
+		},
+		"C": {
+			{Start: 2, Stop: 5, State: StateReady},
+			{Start: 5, Stop: 9, State: StateRunning},
+		},
+	}
+	got := StateTimeline(result)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("StateTimeline() mismatch (-want +got):\n%s", diff)
+	}
+	if err := VerifyStateTimeline(result, got); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVerifyStateTimeline(t *testing.T) {
+	t.Parallel()
+	workloads := map[string][]Process{
+		"exponential": exponentialWorkload(100, 4, 3, 11),
+		"kills and suspensions": {
+			{ProcessID: "A", ArrivalTime: 0, BurstDuration: 7, Priority: 3, Suspensions: []Window{{Start: 3, Stop: 6}, {Start: 5, Stop: 8}}},
+			{ProcessID: "B", ArrivalTime: 1, BurstDuration: 3, Priority: 1, Suspensions: []Window{{Start: 0, Stop: 4}}},
+			{ProcessID: "C", ArrivalTime: 2, BurstDuration: 5, Priority: 3, KillAt: 4},
+			{ProcessID: "D", ArrivalTime: 30, BurstDuration: 2, Priority: 2, KillAt: 31},
+		},
+	}
+	for name, processes := range workloads {
+		for _, result := range []ScheduleResult{
+			FCFSSchedule(io.Discard, "fcfs", processes),
+			SJFSchedule(io.Discard, "sjf", processes),
+			SJFPrioritySchedule(io.Discard, "sjfp", processes),
+			RRSchedule(io.Discard, "rr", processes),
+			RRQuantumScheduleWith(io.Discard, "rr affinity", processes, 2, WithCacheAffinity(0.2, 1)),
+		} {
+			result := result
+			t.Run(name+"/"+result.Title, func(t *testing.T) {
+				t.Parallel()
+				timeline := StateTimeline(result)
+				if err := VerifyStateTimeline(result, timeline); err != nil {
+					t.Fatal(err)
+				}
+
+				// the running intervals account for every slice of the gantt.
+				ran := make(map[string]int64)
+				for _, slice := range result.Gantt {
+					ran[slice.PID] += slice.Stop - slice.Start
+				}
+				for pid, intervals := range timeline {
+					for _, interval := range intervals {
+						if interval.State == StateRunning {
+							ran[pid] -= interval.Stop - interval.Start
+						}
+					}
+				}
+				for pid, left := range ran {
+					if left != 0 {
+						t.Errorf("%s ran %d more in the gantt than in its state history", pid, left)
+					}
+				}
+
+				// dropping an interval from the middle leaves a gap.
+				for pid, intervals := range timeline {
+					if len(intervals) < 3 {
+						continue
+					}
+					timeline[pid] = append(intervals[:1:1], intervals[2:]...)
+					if err := VerifyStateTimeline(result, timeline); !errors.Is(err, ErrStateTimeline) {
+						t.Errorf("VerifyStateTimeline() with a gap in %s error = %v, want %v", pid, err, ErrStateTimeline)
+					}
+					break
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/BurntSushi/toml"
+)
+
+// profileFile is the default -profile-file, the project config file in the current directory.
+const profileFile = ".schedsim.toml"
+
+// Profile is a named set of defaults from the profile file, selected with -profile, e.g.
+//
+//	[profiles.part2]
+//	quantum = 3
+//	algorithms = ["fcfs", "rr"]
+//	output = "gantt-csv"
+//
+// Flags given on the command line override it.
+type Profile struct {
+	// Quantum is the round-robin quantum, unless -quantum is given.
+	Quantum int64
+	// Algorithms are the flag names of the schedulers to run when no scheduler flag is given.
+	Algorithms []string
+	// Output is the format results are written in, one of the Scenario outputs.
+	Output string
+}
+
+// LoadProfiles reads the profiles of a profile file, keyed by name. The file is TOML with a
+// [profiles.<name>] table per profile and nothing else.
+func LoadProfiles(r io.Reader) (map[string]Profile, error) {
+	var file struct {
+		Profiles map[string]struct {
+			Quantum    int64    `toml:"quantum"`
+			Algorithms []string `toml:"algorithms"`
+			Output     string   `toml:"output"`
+		} `toml:"profiles"`
+	}
+	meta, err := toml.NewDecoder(r).Decode(&file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading profiles: %v", ErrInvalidArgs, err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("%w: unknown profile key %q", ErrInvalidArgs, undecoded[0].String())
+	}
+
+	profiles := make(map[string]Profile, len(file.Profiles))
+	for name, profile := range file.Profiles {
+		if meta.IsDefined("profiles", name, "quantum") && profile.Quantum < 1 {
+			return nil, fmt.Errorf("%w: profile %q: quantum wants an integer of 1 or more, got %d", ErrInvalidArgs, name, profile.Quantum)
+		}
+		for _, algorithm := range profile.Algorithms {
+			if _, err := parseScheduler(algorithm); err != nil {
+				return nil, fmt.Errorf("%w: profile %q: %v", ErrInvalidArgs, name, err)
+			}
+		}
+		if meta.IsDefined("profiles", name, "output") {
+			if err := checkOutput(profile.Output); err != nil {
+				return nil, fmt.Errorf("profile %q: %w", name, err)
+			}
+		}
+		profiles[name] = Profile(profile)
+	}
+
+	return profiles, nil
+}
+
+// loadProfile reads the profile called name from the profile file at path.
+func loadProfile(path, name string) (Profile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Profile{}, fmt.Errorf("%w: error opening profile file", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	profiles, err := LoadProfiles(f)
+	if err != nil {
+		return Profile{}, err
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%w: no profile %q in %s", ErrInvalidArgs, name, path)
+	}
+
+	return profile, nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadProfiles(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		file    string
+		want    map[string]Profile
+		wantErr error
+	}{
+		{
+			name: "profiles with comments",
+			file: `# assignment parts
+[profiles.part1]
+algorithms = ["fcfs", "sjf",] # trailing comma
+
+[profiles.part2]
+quantum = 3
+algorithms = ["rr"]
+output = "gantt-csv"
+`,
+			want: map[string]Profile{
+				"part1": {Algorithms: []string{"fcfs", "sjf"}},
+				"part2": {Quantum: 3, Algorithms: []string{"rr"}, Output: "gantt-csv"},
+			},
+		},
+		{
+			name: "literal strings and a multi-line array",
+			file: `[profiles.part1]
+algorithms = [
+	'fcfs', # first come
+	"sj\u0066",
+]
+output = 'text'
+`,
+			want: map[string]Profile{
+				"part1": {Algorithms: []string{"fcfs", "sjf"}, Output: "text"},
+			},
+		},
+		{
+			name: "empty",
+			file: "# nothing yet\n",
+			want: map[string]Profile{},
+		},
+		{
+			name:    "key outside a profile",
+			file:    "quantum = 3\n",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "other table",
+			file:    "[defaults]\nquantum = 3\n",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "profile defined twice",
+			file:    "[profiles.a]\n[profiles.a]\n",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "unknown key",
+			file:    "[profiles.a]\nformat = \"json\"\n",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "quantum less than 1",
+			file:    "[profiles.a]\nquantum = 0\n",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "unknown algorithm",
+			file:    "[profiles.a]\nalgorithms = [\"nonesuch\"]\n",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "algorithms not an array",
+			file:    "[profiles.a]\nalgorithms = \"fcfs\"]\n",
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:    "unknown output",
+			file:    "[profiles.a]\noutput = \"html\"\n",
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := LoadProfiles(strings.NewReader(tt.file))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("LoadProfiles() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestRunProfile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	profiles := path.Join(dir, ".schedsim.toml")
+	procs := path.Join(dir, "procs.csv")
+	if err := os.WriteFile(profiles, []byte("[profiles.both]\nquantum = 2\nalgorithms = [\"fcfs\", \"rr\"]\n\n[profiles.csv]\noutput = \"gantt-csv\"\n\n[profiles.json]\nalgorithms = [\"fcfs\", \"sjf\"]\noutput = \"json\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(procs, []byte("ProcessID,Burst Duration,Arrival Time,Priority\nP0,5,0,2\nP1,9,1,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantOut  []string
+	}{
+		{
+			name:    "profile schedulers",
+			args:    []string{"-profile", "both", "-profile-file", profiles, procs},
+			wantOut: []string{"== fcfs text ==", "== rr quantum 2 text ==", "Round-robin (quantum 2)"},
+		},
+		{
+			name:    "profile schedulers take the other options",
+			args:    []string{"-profile", "both", "-profile-file", profiles, "-unit", "ms", "-timeline", procs},
+			wantOut: []string{"Average wait: 2.00ms", "Timeline", "Algorithm: rr"},
+		},
+		{
+			name:     "profile schedulers with -save",
+			args:     []string{"-profile", "both", "-profile-file", profiles, "-save", path.Join(dir, "result.json"), procs},
+			wantCode: 1,
+			wantOut:  []string{"-save or -events cannot be combined with a profile's schedulers"},
+		},
+		{
+			name:    "-quantum overrides the profile",
+			args:    []string{"-profile", "both", "-profile-file", profiles, "-quantum", "3", procs},
+			wantOut: []string{"== rr quantum 3 text =="},
+		},
+		{
+			name:    "a scheduler flag overrides the profile schedulers",
+			args:    []string{"-profile", "both", "-profile-file", profiles, "-rr", procs},
+			wantOut: []string{"Round-robin (quantum 2)"},
+		},
+		{
+			name:    "profile output",
+			args:    []string{"-profile", "csv", "-profile-file", profiles, "-sjf", procs},
+			wantOut: []string{"PID,Start,Stop\nP0,0,5\n"},
+		},
+		{
+			name:    "profile json output",
+			args:    []string{"-profile", "json", "-profile-file", profiles, procs},
+			wantOut: []string{"[\n{\n  \"version\": 1,", "}\n,\n{\n", "}\n]\n"},
+		},
+		{
+			name:     "profile without schedulers",
+			args:     []string{"-profile", "csv", "-profile-file", profiles, procs},
+			wantCode: 1,
+			wantOut:  []string{"one scheduler flag must be set"},
+		},
+		{
+			name:     "unknown profile",
+			args:     []string{"-profile", "none", "-profile-file", profiles, "-fcfs", procs},
+			wantCode: 1,
+			wantOut:  []string{`no profile "none"`},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var stdout, stderr bytes.Buffer
+			if code := run("scheduler", tt.args, &stdout, &stderr); code != tt.wantCode {
+				t.Fatalf("run() = %d, want %d; stdout:\n%s\nstderr:\n%s", code, tt.wantCode, stdout.String(), stderr.String())
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("stdout is missing %q:\n%s", want, stdout.String())
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// defaultQuantumPercentile follows the rule of thumb that about 80% of bursts
+// should finish within a single quantum.
+const defaultQuantumPercentile = 80
+
+// BurstPercentile returns the nearest-rank p-th percentile (0-100) of the processes' burst durations.
+func BurstPercentile(processes []Process, p float64) int64 {
+	if len(processes) == 0 {
+		return 0
+	}
+	bursts := make([]int64, len(processes))
+	for i := range processes {
+		bursts[i] = processes[i].BurstDuration
+	}
+	slices.Sort(bursts)
+
+	p = math.Min(math.Max(p, 0), 100)
+	rank := int(math.Ceil(p / 100 * float64(len(bursts))))
+
+	return bursts[max(rank-1, 0)]
+}
+
+// AutoQuantum picks a round-robin quantum so that percentile% of the bursts complete within one quantum.
+// The quantum is always at least 1.
+func AutoQuantum(processes []Process, percentile float64) int64 {
+	return max(BurstPercentile(processes, percentile), 1)
+}
+
+// SuggestRRQuantum is a reasonable round-robin quantum for processes without sweeping every
+// quantum: the 80th percentile burst, by the rule of thumb that most bursts should finish within
+// one quantum while the longest still get preempted. It is at least 1.
+func SuggestRRQuantum(processes []Process) int64 {
+	return AutoQuantum(processes, defaultQuantumPercentile)
+}
+
+// Quantum formulas of DynamicRRSchedule.
+const (
+	QuantumMean   = "mean"
+	QuantumMedian = "median"
+)
+
+// DynamicRRSchedule is round-robin with a quantum recomputed every round from the bursts the ready
+// processes have left: their mean, rounded up, for QuantumMean, or their nearest-rank median for
+// QuantumMedian, at least 1. A round is as many dispatches as there were ready processes when it
+// started, so the quantum shrinks as the long processes wear down. Run alongside RRSchedule on the
+// same workload, it shows what a quantum fitted to the work buys over a fixed one.
+func DynamicRRSchedule(w io.Writer, title string, processes []Process, formula string) (ScheduleResult, error) {
+	var quantumOf func(ready []Process) int64
+	switch formula {
+	case QuantumMean:
+		quantumOf = func(ready []Process) int64 {
+			var sum int64
+			for _, p := range ready {
+				sum += p.BurstDuration
+			}
+			n := int64(len(ready))
+			return (sum + n - 1) / n
+		}
+	case QuantumMedian:
+		quantumOf = func(ready []Process) int64 { return BurstPercentile(ready, 50) }
+	default:
+		return ScheduleResult{}, fmt.Errorf("%w: unknown quantum formula %q", ErrInvalidArgs, formula)
+	}
+
+	var quantum int64
+	roundLeft := 0 // dispatches left in the current round
+	result, _ := Simulate(processes, func(ready []Process, _ int64) int {
+		if roundLeft == 0 {
+			quantum, roundLeft = quantumOf(ready), len(ready)
+		}
+		roundLeft--
+		return 0
+	}, SimOptions{
+		Title:     title,
+		QuantumOf: func(Process) int64 { return quantum },
+	})
+	outputResult(w, result)
+
+	return result, nil
+}
+
+// quantumFlag is the value of -quantum: either a fixed number of ticks or "auto".
+type quantumFlag struct {
+	ticks int64
+	auto  bool
+}
+
+func (q *quantumFlag) String() string {
+	if q.auto {
+		return "auto"
+	}
+	if q.ticks == 0 {
+		return strconv.FormatInt(defaultQuantum, 10)
+	}
+	return strconv.FormatInt(q.ticks, 10)
+}
+
+func (q *quantumFlag) Set(s string) error {
+	if s == "auto" {
+		q.auto, q.ticks = true, 0
+		return nil
+	}
+	ticks, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || ticks < 1 {
+		return fmt.Errorf("%w: quantum must be a positive number of ticks or \"auto\"", ErrInvalidArgs)
+	}
+	q.auto, q.ticks = false, ticks
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// burstProcesses builds processes arriving at 0 with the given bursts.
+func burstProcesses(bursts ...int64) []Process {
+	processes := make([]Process, len(bursts))
+	for i, b := range bursts {
+		processes[i] = Process{ProcessID: fmt.Sprintf("P%d", i), BurstDuration: b}
+	}
+	return processes
+}
+
+func TestBurstPercentile(t *testing.T) {
+	t.Parallel()
+	processes := burstProcesses(9, 1, 7, 3, 5, 2, 10, 4, 8, 6)
+	tests := []struct {
+		name string
+		p    float64
+		want int64
+	}{
+		{name: "p0", p: 0, want: 1},
+		{name: "p50", p: 50, want: 5},
+		{name: "p80", p: 80, want: 8},
+		{name: "p85", p: 85, want: 9},
+		{name: "p100", p: 100, want: 10},
+		{name: "clamped", p: 150, want: 10},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := BurstPercentile(processes, tt.p); got != tt.want {
+				t.Errorf("BurstPercentile() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutoQuantum(t *testing.T) {
+	t.Parallel()
+	if got := AutoQuantum(nil, defaultQuantumPercentile); got != 1 {
+		t.Errorf("AutoQuantum() on no processes = %d, want 1", got)
+	}
+	if got := AutoQuantum(burstProcesses(9, 1, 7, 3, 5, 2, 10, 4, 8, 6), defaultQuantumPercentile); got != 8 {
+		t.Errorf("AutoQuantum() = %d, want 8", got)
+	}
+}
+
+func TestSuggestRRQuantum(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      int64
+	}{
+		// sorted bursts 1..10: the 8th of 10 is the nearest-rank 80th percentile.
+		{name: "uniform", processes: burstProcesses(9, 1, 7, 3, 5, 2, 10, 4, 8, 6), want: 8},
+		// 4 of 5 bursts are short, so one long job doesn't drag the quantum up.
+		{name: "one long job", processes: burstProcesses(2, 3, 2, 40, 3), want: 3},
+		{name: "no processes", want: 1},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := SuggestRRQuantum(tt.processes); got != tt.want {
+				t.Errorf("SuggestRRQuantum() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_runScheduler_autoQuantum(t *testing.T) {
+	t.Parallel()
+	var q quantumFlag
+	if err := q.Set("auto"); err != nil {
+		t.Fatal(err)
+	}
+	var w bytes.Buffer
+	result, err := runScheduler(&w, rr, burstProcesses(9, 1, 7, 3, 5, 2, 10, 4, 8, 6), runOptions{
+		quantum:    q,
+		percentile: defaultQuantumPercentile,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Round-robin (quantum 8, auto-selected at p80)"; result.Title != want {
+		t.Errorf("title = %q, want %q", result.Title, want)
+	}
+	if !strings.Contains(w.String(), "auto-selected") {
+		t.Errorf("output doesn't label the quantum as auto-selected:\n%s", w.String())
+	}
+	// the 9 and 10 bursts are the only ones that need a second quantum.
+	if got := len(result.Gantt); got != 12 {
+		t.Errorf("gantt has %d slices, want 12: %v", got, result.Gantt)
+	}
+}
+
+func Test_quantumFlag(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		value   string
+		want    quantumFlag
+		wantErr error
+	}{
+		{name: "auto", value: "auto", want: quantumFlag{auto: true}},
+		{name: "ticks", value: "6", want: quantumFlag{ticks: 6}},
+		{name: "zero", value: "0", wantErr: ErrInvalidArgs},
+		{name: "garbage", value: "fast", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got quantumFlag
+			if err := got.Set(tt.value); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Set() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Set() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDynamicRRSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		formula   string
+		want      []TimeSlice
+		wantErr   error
+	}{
+		{
+			name:      "mean",
+			processes: burstProcesses(10, 2, 3),
+			formula:   QuantumMean,
+			// round 1: mean of 10, 2, 3 is 5; round 2: only P0 is left, with 5.
+			want: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 5},
+				{PID: "P1", Start: 5, Stop: 7},
+				{PID: "P2", Start: 7, Stop: 10},
+				{PID: "P0", Start: 10, Stop: 15},
+			},
+		},
+		{
+			name:      "median",
+			processes: burstProcesses(10, 2, 3),
+			formula:   QuantumMedian,
+			// round 1: median of 10, 2, 3 is 3; round 2: only P0 is left, with 7.
+			want: []TimeSlice{
+				{PID: "P0", Start: 0, Stop: 3},
+				{PID: "P1", Start: 3, Stop: 5},
+				{PID: "P2", Start: 5, Stop: 8},
+				{PID: "P0", Start: 8, Stop: 15},
+			},
+		},
+		{
+			name: "arrival waits for the next round",
+			processes: []Process{
+				{ProcessID: "A", BurstDuration: 4},
+				{ProcessID: "B", ArrivalTime: 1, BurstDuration: 1},
+			},
+			formula: QuantumMean,
+			// round 1 is A alone for 4; round 2 is B.
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 4},
+				{PID: "B", Start: 4, Stop: 5},
+			},
+		},
+		{
+			name:      "unknown formula",
+			processes: burstProcesses(1),
+			formula:   "mode",
+			wantErr:   ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := DynamicRRSchedule(io.Discard, "Dynamic RR", tt.processes, tt.formula)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DynamicRRSchedule() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got.Gantt); err == nil && diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
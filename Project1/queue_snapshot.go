@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// QueueSnapshot is the ready queue a process was dispatched from, in queue order. The dispatched
+// process is included, along with any queued process that was suspended at the time.
+type QueueSnapshot struct {
+	Time int64    `json:"time"`
+	PIDs []string `json:"pids"`
+}
+
+// WithQueueSnapshots records the ready queue at every dispatch in ScheduleResult.ReadyQueueSnapshots.
+// A process that keeps the CPU from one tick to the next isn't dispatched again.
+func WithQueueSnapshots() ScheduleOption {
+	return func(cfg *scheduleConfig) {
+		cfg.queueSnapshots = true
+	}
+}
+
+// snapshot appends the ready queue at t to snapshots when they are being recorded.
+func (cfg scheduleConfig) snapshot(snapshots []QueueSnapshot, t int64, pids []string) []QueueSnapshot {
+	if !cfg.queueSnapshots {
+		return snapshots
+	}
+	return append(snapshots, QueueSnapshot{Time: t, PIDs: pids})
+}
+
+// OutputQueueSnapshots writes the ready queue at each dispatch, front first.
+func OutputQueueSnapshots(w io.Writer, snapshots []QueueSnapshot) {
+	_, _ = fmt.Fprintln(w, "Ready queue")
+	width := len(fmt.Sprint(snapshots[len(snapshots)-1].Time))
+	for _, s := range snapshots {
+		_, _ = fmt.Fprintf(w, "%*d: %s\n", width, s.Time, strings.Join(s.PIDs, " "))
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithQueueSnapshots(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "1", ArrivalTime: 0, BurstDuration: 10, Priority: 2},
+		{ProcessID: "2", ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+		{ProcessID: "3", ArrivalTime: 2, BurstDuration: 2, Priority: 3},
+		{ProcessID: "4", ArrivalTime: 3, BurstDuration: 1, Priority: 4},
+		{ProcessID: "5", ArrivalTime: 4, BurstDuration: 5, Priority: 2},
+	}
+	tests := []struct {
+		name   string
+		result ScheduleResult
+		at     int64
+		want   []string
+	}{
+		{
+			// processes that arrived during 1's first slice queue ahead of it.
+			name:   "rr after the first quantum",
+			result: RRQuantumScheduleWith(io.Discard, "rr", processes, 4, WithQueueSnapshots()),
+			at:     4,
+			want:   []string{"2", "3", "4", "5", "1"},
+		},
+		{
+			name:   "rr requeued behind 5",
+			result: RRQuantumScheduleWith(io.Discard, "rr", processes, 4, WithQueueSnapshots()),
+			at:     12,
+			want:   []string{"1", "5"},
+		},
+		{
+			name:   "fcfs in arrival order",
+			result: FCFSScheduleWith(io.Discard, "fcfs", processes, WithQueueSnapshots()),
+			at:     10,
+			want:   []string{"2", "3", "4", "5"},
+		},
+		{
+			name:   "sjf by remaining burst",
+			result: SJFScheduleWith(io.Discard, "sjf", processes, WithQueueSnapshots()),
+			at:     1,
+			want:   []string{"2", "1"},
+		},
+		{
+			name:   "priority in pop order",
+			result: SJFPriorityScheduleWith(io.Discard, "sjfp", processes, WithQueueSnapshots()),
+			at:     11,
+			want:   []string{"5", "3", "4"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, s := range tt.result.ReadyQueueSnapshots {
+				if s.Time == tt.at {
+					got = s.PIDs
+				}
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("snapshot at %d mismatch (-want +got):\n%s", tt.at, diff)
+			}
+		})
+	}
+
+	if got := RRSchedule(io.Discard, "rr", processes).ReadyQueueSnapshots; got != nil {
+		t.Errorf("snapshots recorded without WithQueueSnapshots: %v", got)
+	}
+}
+
+func TestOutputQueueSnapshots(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	OutputQueueSnapshots(&w, []QueueSnapshot{
+		{Time: 0, PIDs: []string{"A"}},
+		{Time: 12, PIDs: []string{"B", "A"}},
+	})
+	want := "Ready queue\n 0: A\n12: B A\n\n"
+	if diff := cmp.Diff(want, w.String()); diff != "" {
+		t.Errorf("OutputQueueSnapshots() mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"io"
+	"slices"
+)
+
+// The real-time Classes of POSIXSchedule, after SCHED_FIFO and SCHED_RR. Every other Class is a
+// normal process.
+const (
+	ClassFIFO = "rt-fifo"
+	ClassRR   = "rt-rr"
+)
+
+// realTime reports whether p is in a real-time Class.
+func realTime(p Process) bool {
+	return p.Class == ClassFIFO || p.Class == ClassRR
+}
+
+// POSIXSchedule dispatches mixed real-time and normal processes with POSIX semantics. A real-time
+// process always runs before, and preempts, a normal one, and among real-time processes the highest
+// priority (the lowest Priority number) runs, preempting the running process only for a strictly
+// higher one. ClassFIFO processes of equal priority run in arrival order, each until it completes;
+// ClassRR ones take turns for defaultQuantum. Normal processes take turns round-robin for
+// defaultQuantum, whatever their priority, whenever no real-time process is ready. A preempted
+// process, or a suspended one, goes back to the head of its queue with the rest of its quantum.
+func POSIXSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	var (
+		// rt and normal are the queues of every runnable process but current.
+		rt      []Process
+		normal  []Process
+		current *Process
+		// requeue is set when current used up its quantum.
+		requeue bool
+		quantum = make(map[string]int64, len(processes))
+	)
+	for _, p := range processes {
+		quantum[p.ProcessID] = defaultQuantum
+	}
+	// queueOf is the queue p belongs in.
+	queueOf := func(p Process) *[]Process {
+		if realTime(p) {
+			return &rt
+		}
+		return &normal
+	}
+	// highest is the index in rt of the first ready real-time process of the highest priority, or -1.
+	highest := func(ready []Process) int {
+		best := -1
+		for i, p := range rt {
+			if readyIndex(ready, p.ProcessID) >= 0 && (best < 0 || p.Priority < rt[best].Priority) {
+				best = i
+			}
+		}
+		return best
+	}
+	pick := func(ready []Process, _ int64) int {
+		if requeue {
+			// processes that arrived during the quantum queue ahead of the preempted one.
+			queue := queueOf(*current)
+			*queue, current, requeue = append(*queue, *current), nil, false
+		}
+		if current != nil {
+			i, best := readyIndex(ready, current.ProcessID), highest(ready)
+			if i >= 0 && (best < 0 || realTime(*current) && rt[best].Priority >= current.Priority) {
+				return i
+			}
+			// preempted to the head of its queue, ahead of its equals.
+			queue := queueOf(*current)
+			*queue, current = slices.Insert(*queue, 0, *current), nil
+		}
+		if best := highest(ready); best >= 0 {
+			p := rt[best]
+			current, rt = &p, slices.Delete(rt, best, best+1)
+		} else {
+			next := slices.IndexFunc(normal, func(p Process) bool { return readyIndex(ready, p.ProcessID) >= 0 })
+			p := normal[next]
+			current, normal = &p, slices.Delete(normal, next, next+1)
+		}
+		return readyIndex(ready, current.ProcessID)
+	}
+
+	result, _ := Simulate(processes, pick, SimOptions{
+		Title:      title,
+		Preemptive: true,
+		KeepPlace:  true,
+		Arrived: func(p Process, _ int64) {
+			queue := queueOf(p)
+			*queue = append(*queue, p)
+		},
+		Ran: func(p Process, start, stop int64) {
+			if p.Class == ClassFIFO {
+				return
+			}
+			quantum[p.ProcessID] -= stop - start
+			if p.BurstDuration > 0 && quantum[p.ProcessID] == 0 {
+				quantum[p.ProcessID], requeue = defaultQuantum, true
+			}
+		},
+		Left: func(p Process, _ int64) {
+			queue := queueOf(p)
+			*queue = slices.DeleteFunc(*queue, func(q Process) bool { return q.ProcessID == p.ProcessID })
+			if current != nil && current.ProcessID == p.ProcessID {
+				current, requeue = nil, false
+			}
+		},
+	})
+	outputResult(w, result)
+
+	return result
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPOSIXSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "N1", BurstDuration: 6, Class: "normal"},
+		{ProcessID: "N2", BurstDuration: 2, Priority: 1},
+		{ProcessID: "F1", ArrivalTime: 1, BurstDuration: 3, Priority: 2, Class: ClassFIFO},
+		{ProcessID: "R1", ArrivalTime: 2, BurstDuration: 2, Priority: 1, Class: ClassRR},
+		{ProcessID: "R2", ArrivalTime: 2, BurstDuration: 5, Priority: 1, Class: ClassRR},
+	}
+	got := POSIXSchedule(io.Discard, "posix", processes)
+	// F1 preempts N1 and is preempted by the higher R1 and R2, which take turns; N1 then resumes
+	// with the 3 ticks left of its quantum.
+	want := []TimeSlice{
+		{PID: "N1", Start: 0, Stop: 1},
+		{PID: "F1", Start: 1, Stop: 2},
+		{PID: "R1", Start: 2, Stop: 4},
+		{PID: "R2", Start: 4, Stop: 9},
+		{PID: "F1", Start: 9, Stop: 11},
+		{PID: "N1", Start: 11, Stop: 14},
+		{PID: "N2", Start: 14, Stop: 16},
+		{PID: "N1", Start: 16, Stop: 18},
+	}
+	if diff := cmp.Diff(want, got.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestPOSIXSchedule_fifoRunsToCompletion(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "F1", BurstDuration: 6, Priority: 1, Class: ClassFIFO},
+		{ProcessID: "F2", BurstDuration: 2, Priority: 1, Class: ClassFIFO},
+		{ProcessID: "R1", BurstDuration: 6, Priority: 1, Class: ClassRR},
+	}
+	got := POSIXSchedule(io.Discard, "posix", processes)
+	// equal priorities: F1 and F2 run out in turn, R1 alone runs quantum after quantum.
+	want := []TimeSlice{
+		{PID: "F1", Start: 0, Stop: 6},
+		{PID: "F2", Start: 6, Stop: 8},
+		{PID: "R1", Start: 8, Stop: 14},
+	}
+	if diff := cmp.Diff(want, got.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
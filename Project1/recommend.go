@@ -0,0 +1,77 @@
+package main
+
+import (
+	"io"
+)
+
+// Objectives understood by Recommend.
+const (
+	ObjectiveWait       = "wait"
+	ObjectiveTurnaround = "turnaround"
+	ObjectiveThroughput = "throughput"
+	ObjectiveFairness   = "fairness"
+)
+
+// Recommend runs every single-CPU scheduler over processes with its default options and returns
+// the one that best meets objective:
+//
+//   - "wait" and "turnaround" minimize the average;
+//   - "throughput" maximizes completions per unit of time;
+//   - "fairness" maximizes Jain's fairness index over each process' slowdown (turnaround / burst).
+//
+// Ties go to the scheduler listed first (fcfs, sjf, sjfp, rr, then the registered algorithms), so
+// the simplest algorithm wins when nothing beats it. Every built-in scheduler is work-conserving,
+// so throughput usually ties. An unknown objective returns an empty algorithm.
+func Recommend(processes []Process, objective string) (algorithm string, result ScheduleResult) {
+	var score func(ScheduleResult) float64
+	switch objective {
+	case ObjectiveWait:
+		score = func(r ScheduleResult) float64 { return -r.AverageWait }
+	case ObjectiveTurnaround:
+		score = func(r ScheduleResult) float64 { return -r.AverageTurnaround }
+	case ObjectiveThroughput:
+		score = func(r ScheduleResult) float64 { return r.Throughput }
+	case ObjectiveFairness:
+		score = slowdownFairness
+	default:
+		return "", ScheduleResult{}
+	}
+
+	var best float64
+	for _, spec := range schedulerSpecs {
+		if spec.severalCPUs() {
+			// more CPUs would beat any single-CPU scheduler.
+			continue
+		}
+		r, err := runScheduler(io.Discard, spec.scheduler, processes, defaultRunOptions())
+		if err != nil {
+			continue
+		}
+		if s := score(r); algorithm == "" || s > best {
+			algorithm, result, best = spec.scheduler.Name(), r, s
+		}
+	}
+
+	return algorithm, result
+}
+
+// slowdownFairness is Jain's fairness index over the slowdown of every completed process:
+// 1 when all processes are slowed equally, approaching 1/n as one process takes all the delay.
+func slowdownFairness(r ScheduleResult) float64 {
+	var sum, squares float64
+	var n int
+	for _, p := range r.Processes {
+		if p.Remaining > 0 || p.BurstDuration <= 0 {
+			continue
+		}
+		slowdown := float64(p.Turnaround) / float64(p.BurstDuration)
+		sum += slowdown
+		squares += slowdown * slowdown
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+
+	return sum * sum / (float64(n) * squares)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestRecommend(t *testing.T) {
+	t.Parallel()
+	// a long job ahead of two short ones: preempting for the short jobs cuts wait and turnaround.
+	shortBehindLong := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 10, Priority: 1},
+		{ProcessID: "P1", ArrivalTime: 1, BurstDuration: 1, Priority: 2},
+		{ProcessID: "P2", ArrivalTime: 1, BurstDuration: 1, Priority: 3},
+	}
+	// equal jobs arriving together: only round-robin spreads the delay evenly, and SETF, which is
+	// round-robin a tick at a time here, most evenly.
+	equalJobs := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 10, Priority: 1},
+		{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 10, Priority: 2},
+		{ProcessID: "P2", ArrivalTime: 0, BurstDuration: 10, Priority: 3},
+	}
+	tests := []struct {
+		name      string
+		processes []Process
+		objective string
+		want      string
+	}{
+		{name: "wait", processes: shortBehindLong, objective: ObjectiveWait, want: "sjf"},
+		{name: "turnaround", processes: shortBehindLong, objective: ObjectiveTurnaround, want: "sjf"},
+		// every scheduler finishes at the same time, so the tie goes to fcfs.
+		{name: "throughput", processes: shortBehindLong, objective: ObjectiveThroughput, want: "fcfs"},
+		{name: "fairness", processes: equalJobs, objective: ObjectiveFairness, want: "setf"},
+		{name: "unknown objective", processes: equalJobs, objective: "latency", want: ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, result := Recommend(tt.processes, tt.objective)
+			if got != tt.want {
+				t.Errorf("Recommend() = %q, want %q", got, tt.want)
+			}
+			if got != "" && len(result.Processes) != len(tt.processes) {
+				t.Errorf("result has %d processes, want %d", len(result.Processes), len(tt.processes))
+			}
+		})
+	}
+}
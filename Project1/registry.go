@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+)
+
+// Workload is what an Algorithm schedules: the processes, and the tuning options they're
+// scheduled with.
+type Workload struct {
+	Processes []Process
+	Options   runOptions
+}
+
+// Algorithm is a scheduling algorithm added with RegisterAlgorithm, so it can be written in a file
+// of its own without touching the built-in Schedulers. The name Scheduler is taken by those.
+type Algorithm interface {
+	// Name is the algorithm's command line flag and batch script name, e.g. "lifo".
+	Name() string
+	// Schedule runs the workload's processes, failing on a workload the algorithm can't run. It
+	// writes nothing: the result is written as the built-in schedulers' are.
+	Schedule(workload Workload) (ScheduleResult, error)
+}
+
+// algorithms are the registered Algorithms, the Scheduler after rr being the first.
+var algorithms []Algorithm
+
+// RegisterAlgorithm adds a to the schedulers selectable on the command line, with usage as its
+// flag help and options the tuning options it honours, and to those -optimal, scenarios, profiles
+// and Recommend run. It's meant to be called from an init function, and panics if a's name is
+// empty or already taken, as flag does for a redefined flag.
+func RegisterAlgorithm(a Algorithm, usage string, options ...string) {
+	if a.Name() == "" {
+		panic("scheduler: algorithm without a name")
+	}
+	if _, err := parseScheduler(a.Name()); err == nil {
+		panic(fmt.Sprintf("scheduler: algorithm %q registered twice", a.Name()))
+	}
+	algorithms = append(algorithms, a)
+	schedulerSpecs = append(schedulerSpecs, schedulerSpec{scheduler: rr + Scheduler(len(algorithms)), usage: usage, options: options})
+}
+
+// registered is the Algorithm s stands for, if it's a registered one.
+func registered(s Scheduler) (Algorithm, bool) {
+	if s <= rr || int(s-rr) > len(algorithms) {
+		return nil, false
+	}
+	return algorithms[s-rr-1], true
+}
+
+// Name is the command line name of s: its String for a built-in Scheduler, its Algorithm's Name
+// for a registered one.
+func (s Scheduler) Name() string {
+	if a, ok := registered(s); ok {
+		return a.Name()
+	}
+	return s.String()
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+// lifo runs the last arrived process to completion first.
+type lifo struct{}
+
+func (lifo) Name() string { return "lifo" }
+
+func (lifo) Schedule(workload Workload) (ScheduleResult, error) {
+	return Simulate(workload.Processes, func(ready []Process, _ int64) int { return len(ready) - 1 }, SimOptions{Title: "Last-in, first-out"})
+}
+
+// TestRegisterAlgorithm isn't parallel: it registers lifo for its own duration only, before any
+// parallel test reads the registry.
+func TestRegisterAlgorithm(t *testing.T) {
+	specs, registeredAlgorithms := schedulerSpecs, algorithms
+	t.Cleanup(func() { schedulerSpecs, algorithms = specs, registeredAlgorithms })
+	RegisterAlgorithm(lifo{}, "Last-in, first-out scheduling")
+
+	scheduler, err := parseScheduler("lifo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scheduler.Name() != "lifo" {
+		t.Errorf("Name() = %q, want %q", scheduler.Name(), "lifo")
+	}
+	if help := schedulerHelp(); !strings.Contains(help, "-lifo\toptions: none") {
+		t.Errorf("help is missing lifo:\n%s", help)
+	}
+
+	procs := path.Join(t.TempDir(), "procs.csv")
+	if err := os.WriteFile(procs, []byte("ProcessID,Burst Duration,Arrival Time,Priority\nP0,5,0,2\nP1,9,0,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if code := run("scheduler", []string{"-lifo", procs}, &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, want 0; stderr:\n%s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "Last-in, first-out") || !strings.Contains(stdout.String(), "Average wait: 4.50") {
+		t.Errorf("output is missing the lifo schedule:\n%s", stdout.String())
+	}
+	stdout.Reset()
+	if code := run("scheduler", []string{"-lifo", "-quantum", "2", procs}, &stdout, io.Discard); code != 1 ||
+		!strings.Contains(stdout.String(), "unsupported option: lifo does not support -quantum") {
+		t.Errorf("-quantum with lifo: run() = %d, stdout:\n%s", code, stdout.String())
+	}
+	if _, err := runScheduler(io.Discard, scheduler, nil, runOptions{queue: true}); !errors.Is(err, ErrUnsupportedOption) {
+		t.Errorf("-queue with lifo: error = %v, want %v", err, ErrUnsupportedOption)
+	}
+
+	stdout.Reset()
+	if code := run("scheduler", []string{"-diff", "fcfs,lifo", procs}, &stdout, io.Discard); code != 0 || !strings.Contains(stdout.String(), "lifo") {
+		t.Errorf("-diff fcfs,lifo: run() = %d, stdout:\n%s", code, stdout.String())
+	}
+
+	for _, name := range []string{"lifo", "rr", ""} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("registering %q didn't panic", name)
+				}
+			}()
+			RegisterAlgorithm(named(name), "clash")
+		}()
+	}
+}
+
+// named is an Algorithm that only has a name.
+type named string
+
+func (n named) Name() string { return string(n) }
+
+func (named) Schedule(Workload) (ScheduleResult, error) { return ScheduleResult{}, nil }
+
+func TestAlgorithms(t *testing.T) {
+	t.Parallel()
+	procs := path.Join(t.TempDir(), "procs.csv")
+	if err := os.WriteFile(procs, []byte("ProcessID,Burst Duration,Arrival Time,Priority,Class,Group\nP0,5,0,2,interactive,g\nP1,9,1,1,batch,g\nP2,3,4,3,,\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, spec := range schedulerSpecs {
+		spec := spec
+		t.Run(spec.scheduler.Name(), func(t *testing.T) {
+			t.Parallel()
+			var stdout, stderr bytes.Buffer
+			if code := run("scheduler", []string{"-" + spec.scheduler.Name(), procs}, &stdout, &stderr); code != 0 {
+				t.Fatalf("run() = %d, want 0; stdout:\n%s\nstderr:\n%s", code, stdout.String(), stderr.String())
+			}
+			if !strings.Contains(stdout.String(), "Algorithm: "+spec.scheduler.Name()) {
+				t.Errorf("output is missing the manifest:\n%s", stdout.String())
+			}
+		})
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		wantErr string
+	}{
+		{name: "tuning option", args: []string{"-multicore", "-cpus", "3", "-policy", "fcfs"}, want: "Multicore first-come, first-serve (3 CPUs)"},
+		{name: "quantum", args: []string{"-srtf-quantum", "-quantum", "2"}, want: "Shortest-remaining-time-first (quantum 2)"},
+		{name: "levels", args: []string{"-mlq", "-levels", "batch:2,interactive:0"}, want: "Multilevel queue (batch:2,interactive:0)"},
+		{name: "speeds", args: []string{"-heterogeneous", "-speeds", "3,1,1"}, want: "Heterogeneous round-robin (3 CPUs, quantum 4)"},
+		{name: "dry run", args: []string{"-dry-run", "-partitioned", "-steal", "2"}, want: "steal=2 cpus=2 policy=rr quantum=4 -> stdout"},
+		{name: "unsupported option", args: []string{"-lottery", "-quantum", "2"}, wantErr: "unsupported option: lottery does not support -quantum"},
+		{name: "bad policy", args: []string{"-multicore", "-policy", "sjfp"}, wantErr: `policy "sjfp" is not fcfs, sjf or rr`},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var stdout bytes.Buffer
+			code := run("scheduler", append(tt.args, procs), &stdout, io.Discard)
+			if tt.wantErr != "" {
+				if code != 1 || !strings.Contains(stdout.String(), tt.wantErr) {
+					t.Errorf("run() = %d, want 1 and %q; stdout:\n%s", code, tt.wantErr, stdout.String())
+				}
+				return
+			}
+			if code != 0 || !strings.Contains(stdout.String(), tt.want) {
+				t.Errorf("run() = %d, want 0 and %q; stdout:\n%s", code, tt.want, stdout.String())
+			}
+		})
+	}
+}
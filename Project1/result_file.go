@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// resultFileVersion is bumped whenever ScheduleResult changes incompatibly.
+const resultFileVersion = 1
+
+var ErrIncompatibleResult = errors.New("incompatible result file")
+
+// resultFile is the on-disk envelope of a saved ScheduleResult.
+type resultFile struct {
+	Version int            `json:"version"`
+	Result  ScheduleResult `json:"result"`
+}
+
+// SaveResult writes result as versioned JSON so it can be re-rendered later without re-simulating.
+func SaveResult(w io.Writer, result ScheduleResult) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(resultFile{Version: resultFileVersion, Result: result}); err != nil {
+		return fmt.Errorf("%w: encoding result", err)
+	}
+
+	return nil
+}
+
+// LoadResult reads a result written by SaveResult, rejecting files from an incompatible version.
+// The gantt of a result with several CPUs holds slices that run at once, so only the gantt of
+// each CPU is canonicalized.
+func LoadResult(r io.Reader) (ScheduleResult, error) {
+	var f resultFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return ScheduleResult{}, fmt.Errorf("%w: decoding result", err)
+	}
+	if f.Version != resultFileVersion {
+		return ScheduleResult{}, fmt.Errorf("%w: version %d, want %d", ErrIncompatibleResult, f.Version, resultFileVersion)
+	}
+	if f.Result.CPUs != nil {
+		for cpu, gantt := range f.Result.CPUs {
+			gantt, err := CanonicalizeGantt(gantt)
+			if err != nil {
+				return ScheduleResult{}, fmt.Errorf("%w: CPU %d: %w", ErrIncompatibleResult, cpu, err)
+			}
+			f.Result.CPUs[cpu] = gantt
+		}
+		return f.Result, nil
+	}
+	gantt, err := CanonicalizeGantt(f.Result.Gantt)
+	if err != nil {
+		return ScheduleResult{}, fmt.Errorf("%w: %w", ErrIncompatibleResult, err)
+	}
+	f.Result.Gantt = gantt
+
+	return f.Result, nil
+}
+
+func saveResultFile(name string, result ScheduleResult) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return fmt.Errorf("%w: error creating result file", err)
+	}
+	if err := SaveResult(f, result); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+func loadResultFile(name string) (ScheduleResult, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return ScheduleResult{}, fmt.Errorf("%w: error opening result file", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return LoadResult(f)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSaveLoadResult(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: "P2", ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	tests := []struct {
+		name     string
+		schedule func(io.Writer, string, []Process) ScheduleResult
+	}{
+		{name: "fcfs", schedule: FCFSSchedule},
+		{name: "sjf", schedule: SJFSchedule},
+		{name: "sjfp", schedule: SJFPrioritySchedule},
+		{name: "rr", schedule: RRSchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var direct bytes.Buffer
+			result := tt.schedule(&direct, tt.name, processes)
+
+			file := filepath.Join(t.TempDir(), "result.json")
+			if err := saveResultFile(file, result); err != nil {
+				t.Fatalf("saveResultFile() error = %v", err)
+			}
+			loaded, err := loadResultFile(file)
+			if err != nil {
+				t.Fatalf("loadResultFile() error = %v", err)
+			}
+			if diff := cmp.Diff(result, loaded); diff != "" {
+				t.Errorf(diff)
+			}
+
+			var rendered bytes.Buffer
+			outputResult(&rendered, loaded)
+			if diff := cmp.Diff(direct.String(), rendered.String()); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestSaveLoadResult_cpus(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4, Group: "g"},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 1, Group: "g"},
+		{ProcessID: "C", ArrivalTime: 1, BurstDuration: 3},
+	}
+	tests := []struct {
+		name     string
+		schedule func() (ScheduleResult, error)
+	}{
+		{
+			name: "multicore",
+			schedule: func() (ScheduleResult, error) {
+				return MulticoreSchedule(io.Discard, "multicore", processes, 2, rr, 2)
+			},
+		},
+		{
+			name: "gang",
+			schedule: func() (ScheduleResult, error) {
+				return GangSchedule(io.Discard, "gang", processes, 2, 2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := tt.schedule()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			file := filepath.Join(t.TempDir(), "result.json")
+			if err := saveResultFile(file, result); err != nil {
+				t.Fatalf("saveResultFile() error = %v", err)
+			}
+			loaded, err := loadResultFile(file)
+			if err != nil {
+				t.Fatalf("loadResultFile() error = %v", err)
+			}
+			if diff := cmp.Diff(result, loaded); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestLoadResult(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{
+			name:    "incompatible version",
+			input:   `{"version": 999, "result": {"title": "future"}}`,
+			wantErr: ErrIncompatibleResult,
+		},
+		{
+			name:    "missing version",
+			input:   `{"result": {"title": "old"}}`,
+			wantErr: ErrIncompatibleResult,
+		},
+		{
+			name:  "current version",
+			input: `{"version": 1, "result": {"title": "now"}}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := LoadResult(strings.NewReader(tt.input))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
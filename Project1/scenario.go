@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of a Scenario.
+const (
+	ScenarioText     = "text"
+	ScenarioJSON     = "json"
+	ScenarioGanttCSV = "gantt-csv"
+)
+
+// Scenario is a whole experiment in one YAML document, run with -scenario: the processes, the
+// schedulers to run them through, the round-robin quanta and the output formats. For example:
+//
+//	input: procs.csv
+//	schedulers: [fcfs, rr]
+//	quanta: [2, 4]
+//	outputs: [text, gantt-csv]
+type Scenario struct {
+	// Input is a CSV or JSON process file, relative to the scenario file. Processes lists the
+	// processes inline instead, with the fields of the JSON form.
+	Input     string    `yaml:"input"`
+	Processes yaml.Node `yaml:"processes"`
+	// Schedulers are the flag names of the schedulers to run, every one if empty.
+	Schedulers []string `yaml:"schedulers"`
+	// Quanta are the quanta to run rr and the other schedulers with a quantum with, once each;
+	// defaultQuantum if empty.
+	Quanta []int64 `yaml:"quanta"`
+	// Outputs are the formats every run is written in, ScenarioText if empty. ScenarioJSON
+	// writes every run as one JSON array, so it's the only output when given.
+	Outputs []string `yaml:"outputs"`
+}
+
+// scenarioRun is one run of a Scenario.
+type scenarioRun struct {
+	scheduler Scheduler
+	options   runOptions
+}
+
+// RunScenario runs the scenario read from r over its processes, writing a titled section per run
+// in each of its outputs to w, or a JSON array of every run. Each run has a Manifest. dir is where
+// Input is relative to. It returns how many runs it made and how many processes each simulated.
+func RunScenario(r io.Reader, dir string, w io.Writer) (runs, processCount int, err error) {
+	var scenario Scenario
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&scenario); err != nil {
+		return 0, 0, fmt.Errorf("%w: reading scenario: %v", ErrInvalidArgs, err)
+	}
+	processes, input, err := scenario.loadProcesses(dir)
+	if err != nil {
+		return 0, 0, err
+	}
+	runs, err = scenario.run(w, processes, input)
+
+	return runs, len(processes), err
+}
+
+// run runs processes through every run of the scenario, writing each in every output to w, and
+// returns how many runs it made. input is what the processes were read from, for the manifests.
+func (s Scenario) run(w io.Writer, processes []Process, input scenarioInput) (runs int, err error) {
+	planned, err := s.runs()
+	if err != nil {
+		return 0, err
+	}
+	outputs := s.Outputs
+	if len(outputs) == 0 {
+		outputs = []string{ScenarioText}
+	}
+	for _, output := range outputs {
+		if err := checkOutput(output); err != nil {
+			return 0, err
+		}
+	}
+	if slices.Contains(outputs, ScenarioJSON) && len(outputs) > 1 {
+		return 0, fmt.Errorf("%w: the %s output can't be combined with others", ErrInvalidArgs, ScenarioJSON)
+	}
+
+	if outputs[0] == ScenarioJSON {
+		var results []resultFile
+		for _, run := range planned {
+			result, err := run.run(processes, input)
+			if err != nil {
+				return runs, err
+			}
+			results = append(results, resultFile{Version: resultFileVersion, Result: result})
+			runs++
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return runs, enc.Encode(results)
+	}
+	for _, run := range planned {
+		result, err := run.run(processes, input)
+		if err != nil {
+			return runs, err
+		}
+		name := run.scheduler.Name()
+		if takesQuantum(run.scheduler) {
+			name = fmt.Sprintf("%s quantum %d", name, run.options.quantum.ticks)
+		}
+		for _, output := range outputs {
+			_, _ = fmt.Fprintf(w, "== %s %s ==\n", name, output)
+			if err := writeOutput(w, result, output); err != nil {
+				return runs, err
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+		runs++
+	}
+
+	return runs, nil
+}
+
+// run runs processes through the run's scheduler, with a manifest of input and its options.
+func (run scenarioRun) run(processes []Process, input scenarioInput) (ScheduleResult, error) {
+	result, err := runScheduler(io.Discard, run.scheduler, processes, run.options)
+	if err != nil {
+		return result, err
+	}
+	options := make(map[string]string)
+	spec, _ := specFor(run.scheduler)
+	for _, option := range spec.options {
+		if value := run.options.optionValue(option, processes); value != "" {
+			options[option] = value
+		}
+	}
+	result.Manifest = &Manifest{
+		Input:       input.name,
+		InputSHA256: hex.EncodeToString(input.digest),
+		Algorithm:   run.scheduler.Name(),
+		Options:     options,
+		Build:       buildVersion(),
+	}
+
+	return result, nil
+}
+
+// checkOutput checks output is one of the Scenario output formats.
+func checkOutput(output string) error {
+	if !slices.Contains([]string{ScenarioText, ScenarioJSON, ScenarioGanttCSV}, output) {
+		return fmt.Errorf("%w: unknown output %q", ErrInvalidArgs, output)
+	}
+	return nil
+}
+
+// writeOutput writes result to w in the output format.
+func writeOutput(w io.Writer, result ScheduleResult, output string) error {
+	switch output {
+	case ScenarioJSON:
+		return SaveResult(w, result)
+	case ScenarioGanttCSV:
+		OutputGanttCSV(w, result.Gantt)
+	default:
+		outputResult(w, result)
+	}
+	return nil
+}
+
+// scenarioInput is what the processes of a scenario were read from: its Input file, or the JSON
+// form of its inline processes.
+type scenarioInput struct {
+	name   string
+	digest []byte
+}
+
+// loadProcesses reads the scenario's processes, from its Input file relative to dir or inline,
+// and validates them.
+func (s Scenario) loadProcesses(dir string) ([]Process, scenarioInput, error) {
+	inline := !s.Processes.IsZero()
+	if (s.Input == "") == !inline {
+		return nil, scenarioInput{}, fmt.Errorf("%w: a scenario needs either an input file or inline processes", ErrInvalidArgs)
+	}
+	digest := sha256.New()
+	input := scenarioInput{name: s.Input}
+
+	var processes []Process
+	if inline {
+		// inline processes take the JSON field names, so they're decoded as JSON would be.
+		var raw any
+		if err := s.Processes.Decode(&raw); err != nil {
+			return nil, scenarioInput{}, fmt.Errorf("%w: reading scenario processes: %v", ErrInvalidArgs, err)
+		}
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return nil, scenarioInput{}, fmt.Errorf("%w: reading scenario processes: %v", ErrInvalidArgs, err)
+		}
+		if !bytes.HasPrefix(data, []byte("[")) {
+			return nil, scenarioInput{}, fmt.Errorf("%w: scenario processes must be a list", ErrInvalidArgs)
+		}
+		input.name = "inline processes"
+		_, _ = digest.Write(data)
+		if processes, err = loadProcessesJSON(data); err != nil {
+			return nil, scenarioInput{}, err
+		}
+	} else {
+		name := s.Input
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(dir, name)
+		}
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, scenarioInput{}, fmt.Errorf("%w: error opening data file", err)
+		}
+		defer func() { _ = f.Close() }()
+		if processes, err = loadProcesses(io.TeeReader(f, digest)); err != nil {
+			return nil, scenarioInput{}, err
+		}
+	}
+	if err := ValidateProcesses(processes); err != nil {
+		return nil, scenarioInput{}, err
+	}
+	input.digest = digest.Sum(nil)
+
+	return processes, input, nil
+}
+
+// runs are the runs the scenario asks for, in its order: every scheduler once, and those with a
+// quantum once per quantum.
+func (s Scenario) runs() ([]scenarioRun, error) {
+	var schedulers []Scheduler
+	for _, name := range s.Schedulers {
+		scheduler, err := parseScheduler(name)
+		if err != nil {
+			return nil, err
+		}
+		schedulers = append(schedulers, scheduler)
+	}
+	if len(schedulers) == 0 {
+		for _, spec := range schedulerSpecs {
+			schedulers = append(schedulers, spec.scheduler)
+		}
+	}
+	quanta := s.Quanta
+	if len(quanta) == 0 {
+		quanta = []int64{defaultQuantum}
+	}
+	for _, quantum := range quanta {
+		if quantum < 1 {
+			return nil, fmt.Errorf("%w: scenario quantum %d is less than 1", ErrInvalidArgs, quantum)
+		}
+	}
+
+	var runs []scenarioRun
+	for _, scheduler := range schedulers {
+		if !takesQuantum(scheduler) {
+			runs = append(runs, scenarioRun{scheduler: scheduler, options: defaultRunOptions()})
+			continue
+		}
+		for _, quantum := range quanta {
+			options := defaultRunOptions()
+			options.quantum = quantumFlag{ticks: quantum}
+			runs = append(runs, scenarioRun{scheduler: scheduler, options: options})
+		}
+	}
+
+	return runs, nil
+}
+
+func runScenarioFile(w io.Writer, name string, summary *runSummary) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("%w: error opening scenario file", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	runs, processes, err := RunScenario(f, filepath.Dir(name), w)
+	summary.schedulers, summary.processes = runs, runs*processes
+	return err
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+func TestRunScenario(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	if err := os.WriteFile(path.Join(dir, "procs.csv"), []byte("ProcessID,Burst Duration,Arrival Time,Priority\nP0,5,0,2\nP1,9,1,1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		scenario string
+		wantRuns int
+		wantOut  []string
+		wantErr  error
+	}{
+		{
+			name: "inline processes, every rr quantum",
+			scenario: `
+processes:
+  - {processID: P0, burstDuration: 5}
+  - {processID: P1, burstDuration: 9, arrivalTime: 1}
+schedulers: [fcfs, rr]
+quanta: [2, 4]
+`,
+			wantRuns: 3,
+			wantOut:  []string{"== fcfs text ==", "== rr quantum 2 text ==", "== rr quantum 4 text ==", "Average wait: 2.00"},
+		},
+		{
+			name: "input file, several outputs",
+			scenario: `
+input: procs.csv
+schedulers: [sjf]
+outputs: [text, gantt-csv]
+`,
+			wantRuns: 1,
+			wantOut: []string{
+				"== sjf text ==", "Manifest\nInput: procs.csv (sha256 ", "Algorithm: sjf\nOptions: noise=\"0\" noise-dist=\"uniform\" seed=\"1\"\n",
+				"== sjf gantt-csv ==", "P1",
+			},
+		},
+		{
+			name: "json output with others",
+			scenario: `
+input: procs.csv
+outputs: [json, text]
+`,
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name:     "every scheduler by default",
+			scenario: "input: procs.csv\n",
+			wantRuns: len(schedulerSpecs),
+			wantOut:  []string{"== fcfs text ==", "== rr quantum 4 text =="},
+		},
+		{
+			name:     "neither input nor processes",
+			scenario: "schedulers: [fcfs]\n",
+			wantErr:  ErrInvalidArgs,
+		},
+		{
+			name:     "both input and processes",
+			scenario: "input: procs.csv\nprocesses: [{processID: P0, burstDuration: 5}]\n",
+			wantErr:  ErrInvalidArgs,
+		},
+		{
+			name:     "unknown field",
+			scenario: "input: procs.csv\nalgorithms: [fcfs]\n",
+			wantErr:  ErrInvalidArgs,
+		},
+		{
+			name:     "unknown process field",
+			scenario: "processes: [{processID: P0, burst: 5}]\n",
+			wantErr:  ErrInvalidArgs,
+		},
+		{
+			name:     "unknown scheduler",
+			scenario: "input: procs.csv\nschedulers: [nonesuch]\n",
+			wantErr:  ErrUnknownAlgorithm,
+		},
+		{
+			name:     "quantum less than 1",
+			scenario: "input: procs.csv\nquanta: [0]\n",
+			wantErr:  ErrInvalidArgs,
+		},
+		{
+			name:     "unknown output",
+			scenario: "input: procs.csv\noutputs: [html]\n",
+			wantErr:  ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			runs, _, err := RunScenario(strings.NewReader(tt.scenario), dir, &out)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RunScenario() error = %v, want %v", err, tt.wantErr)
+			}
+			if runs != tt.wantRuns {
+				t.Errorf("RunScenario() runs = %d, want %d", runs, tt.wantRuns)
+			}
+			for _, want := range tt.wantOut {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output is missing %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRunScenario_json(t *testing.T) {
+	t.Parallel()
+	scenario := `
+processes:
+  - {processID: P0, burstDuration: 5}
+  - {processID: P1, burstDuration: 9, arrivalTime: 1}
+schedulers: [fcfs, rr]
+quanta: [2, 4]
+outputs: [json]
+`
+	var out bytes.Buffer
+	runs, _, err := RunScenario(strings.NewReader(scenario), t.TempDir(), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []resultFile
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, out.String())
+	}
+	if len(results) != runs || runs != 3 {
+		t.Fatalf("output has %d runs of %d, want 3", len(results), runs)
+	}
+	for _, f := range results {
+		if m := f.Result.Manifest; m == nil || m.Input != "inline processes" || m.InputSHA256 == "" {
+			t.Errorf("%s manifest = %+v", f.Result.Title, m)
+		}
+	}
+	if got := results[2].Result.Manifest.Options["quantum"]; got != "4" {
+		t.Errorf("last run quantum = %q, want 4", got)
+	}
+}
+
+func TestRunScenarioFlag(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	scenario := path.Join(dir, "scenario.yaml")
+	if err := os.WriteFile(scenario, []byte("processes: [{processID: P0, burstDuration: 5}]\nschedulers: [fcfs, sjf]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run("scheduler", []string{"-scenario", scenario}, &stdout, &stderr); code != 0 {
+		t.Fatalf("run() = %d, want 0; stderr:\n%s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "== sjf text ==") || !strings.Contains(stderr.String(), "RESULT algos=2 procs=2") {
+		t.Errorf("stdout:\n%s\nstderr:\n%s", stdout.String(), stderr.String())
+	}
+	stdout.Reset()
+	if code := run("scheduler", []string{"-scenario", scenario, "-fcfs"}, &stdout, io.Discard); code != 1 ||
+		!strings.Contains(stdout.String(), "cannot be combined with -scenario") {
+		t.Errorf("-scenario with -fcfs: run() = %d, stdout:\n%s", code, stdout.String())
+	}
+}
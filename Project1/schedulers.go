@@ -1,323 +1,908 @@
 package main
 
 import (
-	"container/heap"
+	"cmp"
+	"context"
 	"fmt"
 	"io"
-	"sort"
+	"maps"
+	"math"
+	"slices"
 )
 
 type (
 	Process struct {
-		ProcessID     string
-		ArrivalTime   int64
-		BurstDuration int64
-		Priority      int64
+		ProcessID     string `json:"processID"`
+		ArrivalTime   int64  `json:"arrivalTime"`
+		BurstDuration int64  `json:"burstDuration"`
+		Priority      int64  `json:"priority"`
+		// SoftDeadline is when the process should have completed by; finishing later is
+		// scored as tardiness but doesn't fail the schedule. EDFSchedule runs the earliest first.
+		// 0 means no soft deadline.
+		SoftDeadline int64 `json:"softDeadline,omitempty"`
+		// OriginalID is the ID from the input when ProcessID had to be renamed to be unique.
+		OriginalID string `json:"originalID,omitempty"`
+		// KillAt is when the process is aborted if it hasn't completed yet. 0 means never.
+		KillAt int64 `json:"killAt,omitempty"`
+		// Suspensions are windows during which the process may not run.
+		Suspensions []Window `json:"suspensions,omitempty"`
+		// CPULimit is the most CPU time the process may use, like a ulimit. A process that reaches
+		// it before completing is terminated. 0 means no limit.
+		CPULimit int64 `json:"cpuLimit,omitempty"`
+		// Class is the queue MultilevelQueueSchedule puts the process in, e.g. "system" or "batch".
+		Class string `json:"class,omitempty"`
+		// User owns the process; FairShareSchedule shares the CPU between users rather than processes.
+		User string `json:"user,omitempty"`
+		// Affinity is the mask of CPUs the multicore schedulers may run the process on, bit i for
+		// CPU i. 0 allows every CPU.
+		Affinity uint64 `json:"affinity,omitempty"`
+		// Quota and Period cap the process' CPU bandwidth like a cgroup's cpu.max: it may run at
+		// most Quota ticks in every window of Period ticks, counted from 0. 0 means no cap.
+		Quota  int64 `json:"quota,omitempty"`
+		Period int64 `json:"period,omitempty"`
+		// Group is the gang of cooperating processes, sharing a cache or talking to each other,
+		// that CoScheduleSchedule runs back to back.
+		Group string `json:"group,omitempty"`
+		// PreemptionThreshold is the priority PreemptionThresholdSchedule runs the process at once
+		// dispatched: only a process of strictly higher priority (a lower number) preempts it. 0
+		// means none, so the process keeps its Priority.
+		PreemptionThreshold int64 `json:"preemptionThreshold,omitempty"`
+		// IOBursts are the I/O the process blocks on as it runs, in order. Unlike a suspension, which
+		// is a window of wall-clock time, an I/O burst starts once the process has had so much CPU,
+		// whenever that is, and it leaves the CPU for the I/O.
+		IOBursts []IOBurst `json:"ioBursts,omitempty"`
 	}
 
 	TimeSlice struct {
-		PID   string
-		Start int64
-		Stop  int64
+		PID   string `json:"pid"`
+		Start int64  `json:"start"`
+		Stop  int64  `json:"stop"`
+	}
+
+	// ProcessResult is the timing of a single process once it has been scheduled.
+	ProcessResult struct {
+		Process
+		Wait       int64 `json:"wait"`
+		Turnaround int64 `json:"turnaround"`
+		Completion int64 `json:"completion"`
+		// Remaining is the burst left unrun when the schedule ended; 0 for every completed process.
+		Remaining int64 `json:"remaining"`
+		// Aborted is set when the process was killed before it completed.
+		Aborted bool `json:"aborted,omitempty"`
+		// Limited is set when the process was terminated for reaching its CPU limit, at Terminated.
+		Limited    bool  `json:"limited,omitempty"`
+		Terminated int64 `json:"terminated,omitempty"`
+		// Suspended is the time between arrival and completion spent in suspension windows.
+		// It is not counted as Wait.
+		Suspended int64 `json:"suspended,omitempty"`
+		// Throttled are the windows the process was held back at its Quota.
+		Throttled []Window `json:"throttled,omitempty"`
+		// IO are the windows the process was blocked on its IOBursts, and Blocked the time between
+		// arrival and completion they cover outside suspension windows. It is not counted as Wait.
+		IO      []Window `json:"io,omitempty"`
+		Blocked int64    `json:"blocked,omitempty"`
+	}
+
+	// ScheduleResult is everything a scheduler produces, independent of how it is rendered.
+	ScheduleResult struct {
+		Title             string          `json:"title"`
+		Gantt             []TimeSlice     `json:"gantt"`
+		Processes         []ProcessResult `json:"processes"`
+		AverageWait       float64         `json:"averageWait"`
+		AverageTurnaround float64         `json:"averageTurnaround"`
+		Throughput        float64         `json:"throughput"`
+		Makespan          int64           `json:"makespan"`
+		TotalTardiness    int64           `json:"totalTardiness"`
+		LateJobs          int             `json:"lateJobs"`
+		WastedCPU         int64           `json:"wastedCPU"`
+		ThroughputWindow  int64           `json:"throughputWindow"`
+		ThroughputSeries  []int           `json:"throughputSeries"`
+		Manifest          *Manifest       `json:"manifest,omitempty"`
+		// Incomplete lists the processes left out of the averages by CompletedOnly.
+		Incomplete []string `json:"incomplete,omitempty"`
+		// NoiseFreeWait is the average wait of the same run with exact burst estimates, set when
+		// the run used burst noise.
+		NoiseFreeWait *float64 `json:"noiseFreeWait,omitempty"`
+		// UnpinnedWait is the average wait of the same multicore run without CPU affinity, set
+		// when some process was pinned.
+		UnpinnedWait *float64 `json:"unpinnedWait,omitempty"`
+		// LimitKills counts the processes terminated at their CPU limit, which used LimitCPU between them.
+		LimitKills int   `json:"limitKills,omitempty"`
+		LimitCPU   int64 `json:"limitCPU,omitempty"`
+		// State is where a scheduler paused by WithPauseAt can resume from.
+		State *SimState `json:"state,omitempty"`
+		// ReadyQueueSnapshots is the ready queue at every dispatch, recorded with WithQueueSnapshots.
+		ReadyQueueSnapshots []QueueSnapshot `json:"readyQueueSnapshots,omitempty"`
+		// States is each process' state history (see StateTimeline), filled in for saved results.
+		States map[string][]StateInterval `json:"states,omitempty"`
+		// CPUs is the gantt of each CPU of a MulticoreSchedule, whose Gantt holds all their slices
+		// ordered by start. It's nil for the single-CPU schedulers.
+		CPUs [][]TimeSlice `json:"cpus,omitempty"`
+		// CPUWork is the burst each CPU of a HeterogeneousSchedule completed, which differs from
+		// its busy time when the CPUs run at different speeds.
+		CPUWork []int64 `json:"cpuWork,omitempty"`
+		// Notes are what a scheduler found on the way that the rest of the result doesn't hold,
+		// like the work steals of a PartitionedSchedule, a line each. They're written last.
+		Notes []string `json:"notes,omitempty"`
 	}
 )
 
+// String is the canonical form of p used in logs and test failures, for example
+// "P1{burst=5 arrival=0 prio=2}". A soft deadline, an original ID, a kill time, a CPU limit, a
+// class, a user, an affinity, a quota, a group, a preemption threshold and I/O bursts are appended
+// as " deadline=N", " was=ID", " kill=N", " limit=N", " class=C", " user=U", " cpus=0;2",
+// " quota=2/5", " group=G", " threshold=N" and " io=3:4;7:2" only when set, so the common form never
+// changes.
+func (p Process) String() string {
+	s := fmt.Sprintf("%s{burst=%d arrival=%d prio=%d", p.ProcessID, p.BurstDuration, p.ArrivalTime, p.Priority)
+	if p.SoftDeadline != 0 {
+		s += fmt.Sprintf(" deadline=%d", p.SoftDeadline)
+	}
+	if p.OriginalID != "" {
+		s += fmt.Sprintf(" was=%s", p.OriginalID)
+	}
+	if p.KillAt != 0 {
+		s += fmt.Sprintf(" kill=%d", p.KillAt)
+	}
+	if p.CPULimit != 0 {
+		s += fmt.Sprintf(" limit=%d", p.CPULimit)
+	}
+	if p.Class != "" {
+		s += fmt.Sprintf(" class=%s", p.Class)
+	}
+	if p.User != "" {
+		s += fmt.Sprintf(" user=%s", p.User)
+	}
+	if p.Affinity != 0 {
+		s += fmt.Sprintf(" cpus=%s", formatCPUList(p.Affinity))
+	}
+	if p.Quota != 0 {
+		s += fmt.Sprintf(" quota=%d/%d", p.Quota, p.Period)
+	}
+	if p.Group != "" {
+		s += fmt.Sprintf(" group=%s", p.Group)
+	}
+	if p.PreemptionThreshold != 0 {
+		s += fmt.Sprintf(" threshold=%d", p.PreemptionThreshold)
+	}
+	if len(p.IOBursts) > 0 {
+		s += fmt.Sprintf(" io=%s", formatIOBursts(p.IOBursts))
+	}
+	return s + "}"
+}
+
+// killedBy reports whether p has been aborted by time t.
+func (p Process) killedBy(t int64) bool {
+	return p.KillAt > 0 && t >= p.KillAt
+}
+
+// limitedBy reports whether p has used up its CPU limit after running for used.
+func (p Process) limitedBy(used int64) bool {
+	return p.CPULimit > 0 && used >= p.CPULimit
+}
+
+// cpuLeft is how long p may run, at most limit, after running for used.
+func (p Process) cpuLeft(used, limit int64) int64 {
+	if p.CPULimit > 0 {
+		return min(limit, p.CPULimit-used)
+	}
+	return limit
+}
+
+// String is the canonical half-open form of t, for example "P1[3,8)".
+func (t TimeSlice) String() string {
+	return fmt.Sprintf("%s[%d,%d)", t.PID, t.Start, t.Stop)
+}
+
+// defaultQuantum is the time quantum used by round-robin scheduling.
+const defaultQuantum int64 = 4
+
 //region Schedulers
 
+// ScheduleOption tunes how a preemptive scheduler makes its decisions.
+type ScheduleOption func(*scheduleConfig)
+
+type scheduleConfig struct {
+	decisionInterval int64
+	estimates        map[string]int64
+	warmFactor       float64
+	coldPenalty      int64
+	pauseAt          *int64
+	resume           *SimState
+	queueSnapshots   bool
+	decayPerTick     int64
+	priorityBounds   PriorityBounds
+	onProgress       func(done, total int)
+}
+
+func newScheduleConfig(opts ...ScheduleOption) scheduleConfig {
+	cfg := scheduleConfig{decisionInterval: 1, priorityBounds: UnboundedPriorities}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// WithDecisionInterval only lets the scheduler preempt the running process at multiples of d,
+// like a kernel that reschedules on timer interrupts. A process that completes still frees the
+// CPU at once. d of 1, the default, re-evaluates every time unit; d below 1 is treated as 1.
+func WithDecisionInterval(d int64) ScheduleOption {
+	return func(cfg *scheduleConfig) {
+		cfg.decisionInterval = max(d, 1)
+	}
+}
+
+// WithPriorityDecay demotes a process' effective priority (raises its number) by decayPerTick for
+// every tick it runs in the priority scheduler, so a CPU hog eventually yields to the processes
+// waiting behind it. The demotion lasts until the process completes.
+func WithPriorityDecay(decayPerTick int64) ScheduleOption {
+	return func(cfg *scheduleConfig) {
+		cfg.decayPerTick = max(decayPerTick, 0)
+	}
+}
+
+// WithPriorityBounds stops WithPriorityDecay at the floor of bounds. A process whose own priority
+// is already past the floor keeps it.
+func WithPriorityBounds(bounds PriorityBounds) ScheduleOption {
+	return func(cfg *scheduleConfig) {
+		cfg.priorityBounds = bounds
+	}
+}
+
+// WithProgress calls onProgress each time a process completes, with how many have completed so
+// far and how many there are, so a long simulation can show a progress bar. Killed, aborted and
+// CPU-limited processes count as completed, so done reaches total on a full run.
+func WithProgress(onProgress func(done, total int)) ScheduleOption {
+	return func(cfg *scheduleConfig) {
+		cfg.onProgress = onProgress
+	}
+}
+
+// progress calls the WithProgress callback once for each process completed since reported, in
+// order, and returns the new count.
+func (cfg scheduleConfig) progress(reported, completed, total int) int {
+	if cfg.onProgress != nil {
+		for done := reported + 1; done <= completed; done++ {
+			cfg.onProgress(done, total)
+		}
+	}
+	return completed
+}
+
+// decides reports whether a preemption decision is made at time t.
+func (cfg scheduleConfig) decides(t int64) bool {
+	return t%cfg.decisionInterval == 0
+}
+
+// estimatedRemaining is how much of p's burst the scheduler believes is left, given the true remaining.
+func (cfg scheduleConfig) estimatedRemaining(p Process, remaining int64) int64 {
+	estimate, ok := cfg.estimates[p.ProcessID]
+	if !ok {
+		return remaining
+	}
+	return remaining + estimate - p.BurstDuration
+}
+
 // FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
 // • an output writer
 // • a title for the chart
 // • a slice of processes
-func FCFSSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		serviceTime     int64
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		waitingTime     int64
-		schedule        = make([][]string, len(processes))
-		gantt           = make([]TimeSlice, 0)
-	)
-
-	for i := range processes {
-		if processes[i].ArrivalTime > 0 {
-			waitingTime = serviceTime - processes[i].ArrivalTime
-		}
-		totalWait += float64(waitingTime)
+func FCFSSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	result, _ := FCFSScheduleCtx(context.Background(), w, title, processes)
+	return result
+}
 
-		start := waitingTime + processes[i].ArrivalTime
+// FCFSScheduleCtx is FCFSSchedule that stops early when ctx is done, returning the partial
+// schedule so far along with ctx.Err(). Nothing is written to w for a partial schedule.
+func FCFSScheduleCtx(ctx context.Context, w io.Writer, title string, processes []Process) (ScheduleResult, error) {
+	return fcfsScheduleCtx(ctx, w, title, processes, newScheduleConfig())
+}
 
-		turnaround := processes[i].BurstDuration + waitingTime
-		totalTurnaround += float64(turnaround)
+// FCFSScheduleWith is FCFSSchedule tuned by opts. Only WithPauseAt, WithResume, WithQueueSnapshots,
+// WithProgress and WithCacheAffinity apply.
+func FCFSScheduleWith(w io.Writer, title string, processes []Process, opts ...ScheduleOption) ScheduleResult {
+	result, _ := fcfsScheduleCtx(context.Background(), w, title, processes, newScheduleConfig(opts...))
+	return result
+}
 
-		completion := processes[i].BurstDuration + processes[i].ArrivalTime + waitingTime
-		lastCompletion = float64(completion)
-
-		schedule[i] = []string{
-			fmt.Sprint(processes[i].ProcessID),
-			fmt.Sprint(processes[i].Priority),
-			fmt.Sprint(processes[i].BurstDuration),
-			fmt.Sprint(processes[i].ArrivalTime),
-			fmt.Sprint(waitingTime),
-			fmt.Sprint(turnaround),
-			fmt.Sprint(completion),
-		}
-		serviceTime += processes[i].BurstDuration
+func fcfsScheduleCtx(ctx context.Context, w io.Writer, title string, processes []Process, cfg scheduleConfig) (ScheduleResult, error) {
+	// the order is strict, so the CPU idles while the process at the head is suspended.
+	return simulateTo(ctx, w, processes, pickFirst, SimOptions{Title: title, KeepPlace: true, Hold: true}, cfg)
+}
 
-		gantt = append(gantt, TimeSlice{
-			PID:   processes[i].ProcessID,
-			Start: start,
-			Stop:  serviceTime,
-		})
+// simulateTo is simulate writing the result to w once the schedule is complete.
+func simulateTo(ctx context.Context, w io.Writer, processes []Process, pick func(ready []Process, now int64) int, opts SimOptions, cfg scheduleConfig) (ScheduleResult, error) {
+	result, err := simulate(ctx, processes, pick, opts, cfg)
+	if err == nil && result.State == nil {
+		outputResult(w, result)
 	}
+	return result, err
+}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+func SJFSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	return SJFCooldownSchedule(w, title, processes, 0)
+}
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+// SJFScheduleCtx is SJFSchedule that stops early when ctx is done, like FCFSScheduleCtx.
+func SJFScheduleCtx(ctx context.Context, w io.Writer, title string, processes []Process) (ScheduleResult, error) {
+	return SJFCooldownScheduleCtx(ctx, w, title, processes, 0)
 }
 
-func SJFSchedule(w io.Writer, title string, processes []Process) {
-	var (
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		currentTime     int64
-		waitingTime     = make(map[string]int64)
-		remainingTime   = make(map[string]int64)
-		completed       = make(map[string]bool)
-		readyQueue      = make([]Process, 0)
-		gantt           = make([]TimeSlice, 0)
-	)
+// SJFCooldownSchedule is SJFSchedule where a dispatched process runs for at least
+// minRunTicks before it can be preempted, cutting down on the context switches caused
+// by arrivals with nearly equal remaining times.
+// A nonzero cooldown can run a longer job while a shorter one waits, so the schedule
+// is no longer guaranteed to minimize average wait.
+func SJFCooldownSchedule(w io.Writer, title string, processes []Process, minRunTicks int64) ScheduleResult {
+	result, _ := SJFCooldownScheduleCtx(context.Background(), w, title, processes, minRunTicks)
+	return result
+}
 
-	for _, p := range processes {
-		remainingTime[p.ProcessID] = p.BurstDuration
-	}
+// SJFCooldownScheduleCtx is SJFCooldownSchedule that stops early when ctx is done, like FCFSScheduleCtx.
+func SJFCooldownScheduleCtx(ctx context.Context, w io.Writer, title string, processes []Process, minRunTicks int64) (ScheduleResult, error) {
+	return sjfScheduleCtx(ctx, w, title, processes, minRunTicks, newScheduleConfig())
+}
 
-	for len(completed) < len(processes) {
-		for i, p := range processes {
-			if p.ArrivalTime <= currentTime && !completed[p.ProcessID] {
-				readyQueue = append(readyQueue, processes[i])
-				delete(processes, i)
+// SJFScheduleWith is SJFSchedule tuned by opts.
+func SJFScheduleWith(w io.Writer, title string, processes []Process, opts ...ScheduleOption) ScheduleResult {
+	result, _ := sjfScheduleCtx(context.Background(), w, title, processes, 0, newScheduleConfig(opts...))
+	return result
+}
+
+func sjfScheduleCtx(ctx context.Context, w io.Writer, title string, processes []Process, minRunTicks int64, cfg scheduleConfig) (ScheduleResult, error) {
+	var running string
+	if cfg.resume != nil {
+		running = cfg.resume.Running
+	}
+	elapsed := elapsedOf(processes)
+	rank := func(a, b Process) int {
+		ea := cfg.estimatedRemaining(Process{ProcessID: a.ProcessID, BurstDuration: a.BurstDuration + elapsed(a)}, a.BurstDuration)
+		eb := cfg.estimatedRemaining(Process{ProcessID: b.ProcessID, BurstDuration: b.BurstDuration + elapsed(b)}, b.BurstDuration)
+		if ea == eb && cfg.coldPenalty > 0 {
+			// a cold tick makes no progress, so switching on a tie could go back and forth forever.
+			switch running {
+			case a.ProcessID:
+				return -1
+			case b.ProcessID:
+				return 1
 			}
 		}
-
-		if len(readyQueue) == 0 {
-			currentTime++
-			continue
+		return cmp.Compare(ea, eb)
+	}
+	pick := func(ready []Process, _ int64) int {
+		next := 0
+		for i, p := range ready {
+			if rank(p, ready[next]) < 0 {
+				next = i
+			}
 		}
+		running = ready[next].ProcessID
+		return next
+	}
 
-		sort.SliceStable(readyQueue, func(i, j int) bool {
-			return remainingTime[readyQueue[i].ProcessID] < remainingTime[readyQueue[j].ProcessID]
-		})
+	return simulateTo(ctx, w, processes, pick, SimOptions{
+		Title:          title,
+		Preemptive:     true,
+		MinRun:         minRunTicks,
+		PreemptedFirst: true,
+		Rank:           rank,
+	}, cfg)
+}
 
-		currentProcess := readyQueue[0]
-		readyQueue = readyQueue[1:]
+// SRTFSchedule is shortest-remaining-time-first, preemptive SJF under its usual name: it is
+// SJFSchedule, kept so the textbook name stays reachable.
+func SRTFSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	return SJFSchedule(w, title, processes)
+}
 
-		if _, ok := waitingTime[currentProcess.ProcessID]; !ok {
-			waitingTime[currentProcess.ProcessID] = currentTime - currentProcess.ArrivalTime
+// SETFSchedule is shortest elapsed time first, also known as foreground-background or least
+// attained service: every tick, the arrived process that has had the least CPU so far runs, the
+// earliest queued on a tie. New and interactive processes, which have had little CPU, so get in
+// ahead of long-running batch ones without any burst being known in advance, while processes of
+// equal service share the CPU a tick at a time.
+func SETFSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	elapsed := elapsedOf(processes)
+	pick := func(ready []Process, _ int64) int {
+		least := 0
+		for i, p := range ready {
+			if elapsed(p) < elapsed(ready[least]) {
+				least = i
+			}
 		}
+		return least
+	}
+	result, _ := Simulate(processes, pick, SimOptions{Title: title, Quantum: 1})
+	outputResult(w, result)
 
-		start := currentTime
-		currentTime++
-
-		gantt = append(gantt, TimeSlice{
-			PID:   currentProcess.ProcessID,
-			Start: start,
-			Stop:  currentTime,
-		})
-
-		remainingTime[currentProcess.ProcessID]--
-		if remainingTime[currentProcess.ProcessID] == 0 {
-			completed[currentProcess.ProcessID] = true
-			turnaround := currentTime - currentProcess.ArrivalTime
-			totalTurnaround += float64(turnaround)
-			totalWait += float64(waitingTime[currentProcess.ProcessID])
-			lastCompletion = float64(currentTime)
-			continue
+	return result
+}
+
+// PreemptivePrioritySchedule always runs the arrived process with the highest priority (the lowest
+// number), preempting the running process only when one of strictly higher priority arrives.
+// Equal priorities run in arrival order. It is SJFPrioritySchedule under the name the literature
+// uses.
+func PreemptivePrioritySchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	return SJFPrioritySchedule(w, title, processes)
+}
+
+// PreemptionThresholdSchedule is preemptive priority scheduling with preemption thresholds, as in
+// RTOSes: a process is dispatched by its Priority, but once running it takes on its
+// PreemptionThreshold, and only an arrival with a strictly higher priority (a lower number) than
+// that preempts it. Raising the thresholds of processes that don't need to preempt each other saves
+// context switches without delaying the more urgent ones. A process without a threshold, or with
+// one below its Priority, uses its Priority, which makes PreemptivePrioritySchedule. It writes the
+// number of context switches.
+func PreemptionThresholdSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	result := preemptiveSchedule(w, title, processes, preemptiveOptions{
+		rank: func(p Process, _ int64) int64 { return p.Priority },
+		threshold: func(p Process) int64 {
+			if p.PreemptionThreshold != 0 {
+				return min(p.PreemptionThreshold, p.Priority)
+			}
+			return p.Priority
+		},
+	})
+	_, _ = fmt.Fprintf(w, "Context switches: %d\n", ContextSwitches(result.Gantt))
+
+	return result
+}
+
+// EDFSchedule is earliest deadline first: it always runs the arrived process with the earliest
+// SoftDeadline, preempting the running process when one with an earlier deadline arrives. Processes
+// without a deadline run only when no process with one is ready, in arrival order. Deadlines are
+// soft, so a missed one doesn't stop the schedule; the summary lists them.
+func EDFSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	return preemptiveSchedule(w, title, processes, preemptiveOptions{rank: func(p Process, _ int64) int64 {
+		if p.SoftDeadline == 0 {
+			return math.MaxInt64
 		}
+		return p.SoftDeadline
+	}})
+}
 
-		for _, p := range processes {
-			if p.ArrivalTime <= currentTime && !completed[p.ProcessID] && p.BurstDuration < remainingTime[currentProcess.ProcessID] {
-				readyQueue = append(readyQueue, p)
-				delete(processes, p.ProcessID)
+// LRTFSchedule is longest remaining time first, the preemptive counterpart of longest job first: it
+// always runs the arrived process with the most burst left. The running process's burst left
+// shrinks as it runs, so the processes are re-ranked every tick, and the running process keeps the
+// CPU on a tie; other ties go to the earliest queued.
+func LRTFSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	return preemptiveSchedule(w, title, processes, preemptiveOptions{
+		rank:      func(_ Process, left int64) int64 { return -left },
+		everyTick: true,
+	})
+}
+
+// LLFSchedule is least laxity first: it always runs the arrived process with the least laxity,
+// SoftDeadline - now - remaining burst, the slack it has before it can no longer meet its deadline.
+// A waiting process's laxity shrinks every tick while the running one's holds, so the processes are
+// re-ranked every tick; the running process keeps the CPU on a tie, which avoids the thrashing
+// between equal laxities plain LLF suffers. Processes without a deadline run only when no process
+// with one is ready, in arrival order.
+func LLFSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	return preemptiveSchedule(w, title, processes, preemptiveOptions{
+		// now is the same for every process, so leaving it out orders them alike.
+		rank: func(p Process, left int64) int64 {
+			if p.SoftDeadline == 0 {
+				return math.MaxInt64
 			}
+			return p.SoftDeadline - left
+		},
+		everyTick: true,
+	})
+}
+
+// preemptiveOptions tune preemptiveSchedule.
+type preemptiveOptions struct {
+	// rank orders the arrived processes, lowest first, given how much of their burst is left.
+	rank func(p Process, left int64) int64
+	// threshold is what an arrival must rank strictly lower than to preempt the running process,
+	// its rank if nil.
+	threshold func(p Process) int64
+	// everyTick re-ranks every tick, for ranks that change as a process runs, rather than only at
+	// every arrival and completion.
+	everyTick bool
+}
+
+// preemptiveSchedule runs the arrived process ranked lowest by opts.rank, re-ranking at every
+// arrival and completion. An arrival only preempts the running process when it ranks strictly
+// lower than its threshold; other ties go to the earliest queued, and a preempted process keeps its
+// place.
+func preemptiveSchedule(w io.Writer, title string, processes []Process, opts preemptiveOptions) ScheduleResult {
+	var running string
+	rankOf := func(p Process) int64 { return opts.rank(p, p.BurstDuration) }
+	thresholdOf := rankOf
+	if opts.threshold != nil {
+		thresholdOf = opts.threshold
+	}
+	pick := func(ready []Process, _ int64) int {
+		// the lowest ranked, earliest queued on a tie, unless it ranks no lower than the running
+		// process's threshold.
+		next := 0
+		for i, p := range ready {
+			if rankOf(p) < rankOf(ready[next]) {
+				next = i
+			}
+		}
+		if i := readyIndex(ready, running); i >= 0 && rankOf(ready[next]) >= thresholdOf(ready[i]) {
+			next = i
 		}
+		running = ready[next].ProcessID
+		return next
 	}
+	result, _ := Simulate(processes, pick, SimOptions{Title: title, Preemptive: true, AtArrivals: !opts.everyTick, KeepPlace: true})
+	outputResult(w, result)
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
-
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return result
 }
 
-func SJFPrioritySchedule(w io.Writer, title string, processes []Process) {
+// SJFBoundedWaitSchedule is SJFSchedule with a bound on starvation: when a queued process has
+// waited more than bound ticks in all since it arrived, it's forcibly dispatched ahead of the
+// shortest and runs to completion. Of several such processes, the one that has waited longest goes
+// first, the earliest queued on a tie, but when the shortest process has waited too long itself it
+// simply runs. A bound of 0 or less never promotes, which makes SJFSchedule. It writes the number
+// of forced promotions.
+func SJFBoundedWaitSchedule(w io.Writer, title string, processes []Process, bound int64) ScheduleResult {
 	var (
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		currentTime     int64
-		waitingTime     = make(map[string]int64)
-		completed       = make(map[string]bool)
-		readyQueue      = make(PriorityQueue, 0)
-		gantt           = make([]TimeSlice, 0)
+		promotions int
+		// promoted is the process forcibly dispatched until it completes.
+		promoted string
+		elapsed  = elapsedOf(processes)
 	)
-
-	for len(completed) < len(processes) {
-		for i, p := range processes {
-			if p.ArrivalTime <= currentTime && !completed[p.ProcessID] {
-				heap.Push(&readyQueue, &Item{
-					Value:    p,
-					Priority: p.Priority,
-				})
-				delete(processes, i)
+	waited := func(p Process, now int64) int64 { return now - p.ArrivalTime - elapsed(p) }
+	pick := func(ready []Process, now int64) int {
+		if i := slices.IndexFunc(ready, func(p Process) bool { return p.ProcessID == promoted }); i >= 0 {
+			return i
+		}
+		shortest := pickShortest(ready, now)
+		if bound <= 0 {
+			return shortest
+		}
+		starving := -1
+		for i, p := range ready {
+			if waited(p, now) > bound && (starving < 0 || waited(p, now) > waited(ready[starving], now)) {
+				starving = i
 			}
 		}
-
-		if len(readyQueue) == 0 {
-			currentTime++
-			continue
+		if starving < 0 || waited(ready[shortest], now) > bound {
+			return shortest
 		}
+		promoted = ready[starving].ProcessID
+		promotions++
+		return starving
+	}
+	result, _ := Simulate(processes, pick, SimOptions{Title: title, Quantum: 1, PreemptedFirst: true})
+	result.Notes = []string{fmt.Sprintf("Forced promotions: %d", promotions)}
+	outputResult(w, result)
 
-		currentProcess := heap.Pop(&readyQueue).(*Item).Value.(Process)
+	return result
+}
 
-		if _, ok := waitingTime[currentProcess.ProcessID]; !ok {
-			waitingTime[currentProcess.ProcessID] = currentTime - currentProcess.ArrivalTime
-		}
+// SJFNonPreemptiveSchedule is non-preemptive SJF: whenever the CPU frees up, the arrived process
+// with the shortest burst runs to completion, the earliest queued on a tie. It renders like the
+// other schedulers so it can be compared with the preemptive SJFSchedule.
+func SJFNonPreemptiveSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	result, _ := Simulate(processes, pickShortest, SimOptions{Title: title})
+	outputResult(w, result)
 
-		start := currentTime
-		currentTime++
-
-		gantt = append(gantt, TimeSlice{
-			PID:   currentProcess.ProcessID,
-			Start: start,
-			Stop:  currentTime,
-		})
-
-		remainingTime := currentProcess.BurstDuration - 1
-		if remainingTime == 0 {
-			completed[currentProcess.ProcessID] = true
-			turnaround := currentTime - currentProcess.ArrivalTime
-			totalTurnaround += float64(turnaround)
-			totalWait += float64(waitingTime[currentProcess.ProcessID])
-			lastCompletion = float64(currentTime)
-			continue
-		}
+	return result
+}
+
+// SRTFQuantumSchedule is shortest-remaining-time-first re-evaluated only every quantum ticks: the
+// arrived process with the least burst left runs for up to a quantum, and only then does a shorter
+// arrival get the CPU, the running process keeping it on a tie. So quantum bounds the context
+// switches arrivals cause, at the cost of short processes waiting up to a quantum: 1 makes
+// SJFSchedule and 0 runs every process to completion as SJFNonPreemptiveSchedule. It writes the
+// number of context switches.
+func SRTFQuantumSchedule(w io.Writer, title string, processes []Process, quantum int64) ScheduleResult {
+	result, _ := Simulate(processes, pickShortest, SimOptions{Title: title, Quantum: max(quantum, 0), PreemptedFirst: true})
+	result.Notes = []string{fmt.Sprintf("Context switches: %d", ContextSwitches(result.Gantt))}
+	outputResult(w, result)
+
+	return result
+}
+
+// NonPreemptivePrioritySchedule dispatches the arrived process with the highest priority (the
+// lowest number) whenever the CPU frees up and runs it to completion. Equal priorities run in
+// arrival order.
+func NonPreemptivePrioritySchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	result, _ := Simulate(processes, pickHighestPriority, SimOptions{Title: title})
+	outputResult(w, result)
+
+	return result
+}
 
-		for _, p := range processes {
-			if p.ArrivalTime <= currentTime && !completed[p.ProcessID] && p.Priority < currentProcess.Priority {
-				heap.Push(&readyQueue, &Item{
-					Value:    p,
-					Priority: p.Priority,
-				})
-				delete(processes, p.ProcessID)
+// HRRNSchedule is highest response ratio next: whenever the CPU frees up, it dispatches the arrived
+// process with the highest (wait + burst) / burst and runs it to completion. Short processes go
+// first as in SJF, but a long process's ratio grows while it waits, so it can't starve. Equal
+// ratios run in arrival order.
+func HRRNSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	result, _ := Simulate(processes, pickHighestResponseRatio, SimOptions{Title: title})
+	outputResult(w, result)
+
+	return result
+}
+
+func SJFPrioritySchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	result, _ := SJFPriorityScheduleCtx(context.Background(), w, title, processes)
+	return result
+}
+
+// SJFPriorityScheduleCtx is SJFPrioritySchedule that stops early when ctx is done, like FCFSScheduleCtx.
+func SJFPriorityScheduleCtx(ctx context.Context, w io.Writer, title string, processes []Process) (ScheduleResult, error) {
+	return sjfPriorityScheduleCtx(ctx, w, title, processes, newScheduleConfig())
+}
+
+// SJFPriorityScheduleWith is SJFPrioritySchedule tuned by opts.
+func SJFPriorityScheduleWith(w io.Writer, title string, processes []Process, opts ...ScheduleOption) ScheduleResult {
+	result, _ := sjfPriorityScheduleCtx(context.Background(), w, title, processes, newScheduleConfig(opts...))
+	return result
+}
+
+func sjfPriorityScheduleCtx(ctx context.Context, w io.Writer, title string, processes []Process, cfg scheduleConfig) (ScheduleResult, error) {
+	// priority is the effective priority of every process whose priority has decayed.
+	priority := make(map[string]int64)
+	if cfg.resume != nil {
+		maps.Copy(priority, cfg.resume.Priorities)
+	}
+	effective := func(p Process) int64 {
+		if v, ok := priority[p.ProcessID]; ok {
+			return v
+		}
+		return p.Priority
+	}
+	rank := func(a, b Process) int { return cmp.Compare(effective(a), effective(b)) }
+	pick := func(ready []Process, _ int64) int {
+		next := 0
+		for i, p := range ready {
+			if rank(p, ready[next]) < 0 {
+				next = i
 			}
 		}
+		return next
+	}
+	ran := func(p Process, start, stop int64) {
+		if cfg.decayPerTick == 0 || p.BurstDuration == 0 {
+			return
+		}
+		bounds := cfg.priorityBounds
+		if bounds.moreImportant(bounds.Floor, effective(p)) {
+			return
+		}
+		for ; start < stop; start++ {
+			priority[p.ProcessID] = bounds.Demote(effective(p), cfg.decayPerTick)
+		}
+	}
 
-		heap.Push(&readyQueue, &Item{
-			Value:    currentProcess,
-			Priority: currentProcess.Priority,
-		})
+	// equal priorities keep the order they were queued in.
+	result, err := simulateTo(ctx, w, processes, pick, SimOptions{
+		Title:      title,
+		Preemptive: true,
+		KeepPlace:  true,
+		Ran:        ran,
+		Rank:       rank,
+	}, cfg)
+	if state := result.State; state != nil {
+		for _, id := range state.Queue {
+			if v, ok := priority[id]; ok {
+				if state.Priorities == nil {
+					state.Priorities = make(map[string]int64)
+				}
+				state.Priorities[id] = v
+			}
+		}
 	}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+	return result, err
+}
+
+func RRSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	return RRQuantumSchedule(w, title, processes, defaultQuantum)
+}
+
+// RRScheduleCtx is RRSchedule that stops early when ctx is done, like FCFSScheduleCtx.
+func RRScheduleCtx(ctx context.Context, w io.Writer, title string, processes []Process) (ScheduleResult, error) {
+	return RRQuantumScheduleCtx(ctx, w, title, processes, defaultQuantum)
+}
+
+// RRQuantumSchedule is RRSchedule with the given time quantum instead of defaultQuantum.
+func RRQuantumSchedule(w io.Writer, title string, processes []Process, timeQuantum int64) ScheduleResult {
+	result, _ := RRQuantumScheduleCtx(context.Background(), w, title, processes, timeQuantum)
+	return result
+}
+
+// RRQuantumScheduleCtx is RRQuantumSchedule that stops early when ctx is done, like FCFSScheduleCtx.
+func RRQuantumScheduleCtx(ctx context.Context, w io.Writer, title string, processes []Process, timeQuantum int64) (ScheduleResult, error) {
+	return rrScheduleCtx(ctx, w, title, processes, timeQuantum, newScheduleConfig())
+}
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+// WRRSchedule is weighted round-robin: each process's quantum is defaultQuantum times its weight,
+// its Priority, so processes get CPU in proportion to their weights while all are ready. A weight
+// below 1 counts as 1, and with every weight 1 it's RRSchedule.
+func WRRSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	result, _ := Simulate(processes, pickFirst, SimOptions{
+		Title:     title,
+		QuantumOf: func(p Process) int64 { return defaultQuantum * max(p.Priority, 1) },
+	})
+	outputResult(w, result)
+
+	return result
 }
 
-func RRSchedule(w io.Writer, title string, processes []Process) {
+// RRBoostSchedule is RRSchedule with a starvation boost: a queued process that has waited more than
+// threshold ticks in all since it arrived, or since it was last boosted, is promoted to the front
+// of the ready queue, ahead of the processes that would otherwise run first. Boosted processes run
+// in queue order. A threshold of 0 or less never boosts, which makes RRSchedule. It writes the
+// number of boosts, the times a process was promoted past another.
+func RRBoostSchedule(w io.Writer, title string, processes []Process, threshold int64) ScheduleResult {
 	var (
-		totalWait       float64
-		totalTurnaround float64
-		lastCompletion  float64
-		currentTime     int64
-		waitingTime     = make(map[string]int64)
-		remainingTime   = make(map[string]int64)
-		completed       = make(map[string]bool)
-		readyQueue      = make([]Process, 0)
-		gantt           = make([]TimeSlice, 0)
-		timeQuantum     = int64(4)
+		boosts  int
+		elapsed = elapsedOf(processes)
+		credits = make(map[string]int64, len(processes))
 	)
-
-	for _, p := range processes {
-		remainingTime[p.ProcessID] = p.BurstDuration
+	// waited is how long p has been queued since it arrived or was last boosted.
+	waited := func(p Process, now int64) int64 {
+		return now - p.ArrivalTime - elapsed(p) - credits[p.ProcessID]
 	}
-
-	for len(completed) < len(processes) {
-		for i, p := range processes {
-			if p.ArrivalTime <= currentTime && !completed[p.ProcessID] {
-				readyQueue = append(readyQueue, processes[i])
-				delete(processes, i)
-			}
+	pick := func(ready []Process, now int64) int {
+		if threshold <= 0 {
+			return 0
 		}
-
-		if len(readyQueue) == 0 {
-			currentTime++
-			continue
+		i := slices.IndexFunc(ready, func(p Process) bool { return waited(p, now) > threshold })
+		if i < 0 {
+			return 0
+		}
+		credits[ready[i].ProcessID] += waited(ready[i], now)
+		if i > 0 {
+			boosts++
 		}
+		return i
+	}
+	result, _ := Simulate(processes, pick, SimOptions{Title: title, Quantum: defaultQuantum})
+	result.Notes = []string{fmt.Sprintf("Starvation boosts: %d", boosts)}
+	outputResult(w, result)
 
-		currentProcess := readyQueue[0]
-		readyQueue = readyQueue[1:]
+	return result
+}
 
-		if _, ok := waitingTime[currentProcess.ProcessID]; !ok {
-			waitingTime[currentProcess.ProcessID] = currentTime - currentProcess.ArrivalTime
-		}
+// RRScheduleWith is RRSchedule tuned by opts.
+func RRScheduleWith(w io.Writer, title string, processes []Process, opts ...ScheduleOption) ScheduleResult {
+	return RRQuantumScheduleWith(w, title, processes, defaultQuantum, opts...)
+}
+
+// RRQuantumScheduleWith is RRQuantumSchedule tuned by opts.
+func RRQuantumScheduleWith(w io.Writer, title string, processes []Process, timeQuantum int64, opts ...ScheduleOption) ScheduleResult {
+	result, _ := rrScheduleCtx(context.Background(), w, title, processes, timeQuantum, newScheduleConfig(opts...))
+	return result
+}
+
+func rrScheduleCtx(ctx context.Context, w io.Writer, title string, processes []Process, timeQuantum int64, cfg scheduleConfig) (ScheduleResult, error) {
+	// processes that arrive during a slice queue ahead of the preempted one.
+	return simulateTo(ctx, w, processes, pickFirst, SimOptions{Title: title, Quantum: timeQuantum}, cfg)
+}
+
+//endregion
+
+// appendTimeSlice adds a slice to the gantt, extending the last slice instead
+// when the same process keeps running without interruption.
+func appendTimeSlice(gantt []TimeSlice, slice TimeSlice) []TimeSlice {
+	if n := len(gantt); n > 0 && gantt[n-1].PID == slice.PID && gantt[n-1].Stop == slice.Start {
+		gantt[n-1].Stop = slice.Stop
+		return gantt
+	}
+	return append(gantt, slice)
+}
 
-		start := currentTime
-		executionTime := min(currentProcess.BurstDuration, timeQuantum)
-		currentTime += executionTime
-		remainingTime[currentProcess.ProcessID] -= executionTime
-
-		gantt = append(gantt, TimeSlice{
-			PID:   currentProcess.ProcessID,
-			Start: start,
-			Stop:  currentTime,
-		})
-
-		if remainingTime[currentProcess.ProcessID] == 0 {
-			completed[currentProcess.ProcessID] = true
-			turnaround := currentTime - currentProcess.ArrivalTime
-			totalTurnaround += float64(turnaround)
-			totalWait += float64(waitingTime[currentProcess.ProcessID])
-			lastCompletion = float64(currentTime)
+// CanonicalizeGantt returns a copy of gantt sorted by Start, without zero-length slices and with
+// back-to-back slices of the same PID merged, so renderers and metrics can assume clean input.
+// Gaps are idle time and are kept; slices that overlap or stop before they start are an error.
+func CanonicalizeGantt(gantt []TimeSlice) ([]TimeSlice, error) {
+	if gantt == nil {
+		return nil, nil
+	}
+	sorted := slices.Clone(gantt)
+	slices.SortStableFunc(sorted, func(a, b TimeSlice) int { return cmp.Compare(a.Start, b.Start) })
+
+	canonical := make([]TimeSlice, 0, len(sorted))
+	for _, slice := range sorted {
+		switch {
+		case slice.Stop < slice.Start:
+			return nil, fmt.Errorf("%w: %v stops before it starts", ErrOverlappingSlices, slice)
+		case slice.Stop == slice.Start:
 			continue
+		case len(canonical) > 0 && slice.Start < canonical[len(canonical)-1].Stop:
+			return nil, fmt.Errorf("%w: %v and %v", ErrOverlappingSlices, canonical[len(canonical)-1], slice)
 		}
+		canonical = appendTimeSlice(canonical, slice)
+	}
+
+	return canonical, nil
+}
+
+// newScheduleResult derives per-process timing and the averages from a finished gantt.
+// A process completes at the stop of its last slice; its wait is whatever of its
+// turnaround wasn't spent running, suspended or blocked on I/O.
+func newScheduleResult(title string, processes []Process, gantt []TimeSlice) ScheduleResult {
+	return newScheduleResultOf(title, processes, gantt, nil)
+}
 
-		for _, p := range processes {
-			if p.ArrivalTime <= currentTime && !completed[p.ProcessID] {
-				readyQueue = append(readyQueue, p)
-				delete(processes, p.ProcessID)
+// newScheduleResultOf is newScheduleResult with the burst each process completed, for CPUs that
+// don't do a tick of burst every tick. With nil, a process completed as much as it ran.
+func newScheduleResultOf(title string, processes []Process, gantt []TimeSlice, completed map[string]int64) ScheduleResult {
+	completion := make(map[string]int64, len(processes))
+	ran := make(map[string]int64, len(processes))
+	for _, slice := range gantt {
+		completion[slice.PID] = max(completion[slice.PID], slice.Stop)
+		ran[slice.PID] += slice.Stop - slice.Start
+	}
+	if completed == nil {
+		completed = ran
+	}
+
+	var (
+		totalWait       float64
+		totalTurnaround float64
+		lastCompletion  int64
+		aborted         int
+		wasted          int64
+		limitKills      int
+		limitCPU        int64
+		rows            = make([]ProcessResult, len(processes))
+	)
+	for i, p := range processes {
+		rows[i] = ProcessResult{
+			Process:   p,
+			Remaining: p.BurstDuration - completed[p.ProcessID],
+			IO:        p.ioWindows(gantt),
+		}
+		if rows[i].Remaining > 0 {
+			if p.limitedBy(ran[p.ProcessID]) {
+				// terminated at its CPU limit: like a kill, it doesn't count toward the averages.
+				rows[i].Limited = true
+				rows[i].Terminated = completion[p.ProcessID]
+				limitKills++
+				limitCPU += ran[p.ProcessID]
+				continue
+			}
+			if p.KillAt > 0 {
+				// killed: the CPU time it got is wasted and it doesn't count toward the averages.
+				rows[i].Aborted = true
+				aborted++
+				wasted += ran[p.ProcessID]
 			}
+			// otherwise a partial schedule: the process hasn't completed, so it has no timing yet.
+			continue
 		}
-
-		readyQueue = append(readyQueue, currentProcess)
+		turnaround := completion[p.ProcessID] - p.ArrivalTime
+		rows[i].Suspended = p.suspendedBetween(p.ArrivalTime, completion[p.ProcessID])
+		rows[i].Blocked = coveredBetween(slices.Concat(p.Suspensions, rows[i].IO), p.ArrivalTime, completion[p.ProcessID]) - rows[i].Suspended
+		rows[i].Wait = turnaround - ran[p.ProcessID] - rows[i].Suspended - rows[i].Blocked
+		rows[i].Turnaround = turnaround
+		rows[i].Completion = completion[p.ProcessID]
+		totalWait += float64(rows[i].Wait)
+		totalTurnaround += float64(turnaround)
+		lastCompletion = max(lastCompletion, rows[i].Completion)
 	}
 
-	count := float64(len(processes))
-	aveWait := totalWait / count
-	aveTurnaround := totalTurnaround / count
-	aveThroughput := count / lastCompletion
+	result := ScheduleResult{
+		Title:     title,
+		Gantt:     gantt,
+		Processes: rows,
+		Makespan:  Makespan(gantt),
+		WastedCPU: wasted,
+	}
+	result.LimitKills, result.LimitCPU = limitKills, limitCPU
+	result.TotalTardiness, result.LateJobs = SoftDeadlineScore(rows)
+	if count := float64(len(processes) - aborted - limitKills); count > 0 {
+		result.AverageWait = totalWait / count
+		result.AverageTurnaround = totalTurnaround / count
+	}
+	if lastCompletion > 0 {
+		result.Throughput = float64(len(processes)-aborted-limitKills) / float64(lastCompletion)
+	}
+	result.ThroughputSeries, result.ThroughputWindow = ThroughputSeries(rows, 0)
 
-	outputTitle(w, title)
-	outputGantt(w, gantt)
-	outputSchedule(w, schedule, aveWait, aveTurnaround, aveThroughput)
+	return result
 }
 
-//endregion
-
 // Helper function to find minimum of two integers
 func min(a, b int64) int64 {
 	if a < b {
@@ -325,4 +910,3 @@ func min(a, b int64) int64 {
 	}
 	return b
 }
-
@@ -0,0 +1,981 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestSJFCooldownSchedule(t *testing.T) {
+	t.Parallel()
+	// every arrival is slightly shorter than what's running, so pure SRTF preempts each time.
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 8},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 6},
+		{ProcessID: "D", ArrivalTime: 3, BurstDuration: 4},
+	}
+
+	pure := SJFCooldownSchedule(io.Discard, "pure", processes, 0)
+	cooled := SJFCooldownSchedule(io.Discard, "cooled", processes, 3)
+
+	if got, want := len(cooled.Gantt), len(pure.Gantt); got >= want {
+		t.Errorf("cooldown context switches = %d, want fewer than %d", got-1, want-1)
+	}
+	if got := SJFSchedule(io.Discard, "pure", processes); len(got.Gantt) != len(pure.Gantt) {
+		t.Errorf("SJFSchedule gantt = %v, want %v", got.Gantt, pure.Gantt)
+	}
+	for _, r := range []ScheduleResult{pure, cooled} {
+		var ran int64
+		for _, slice := range r.Gantt {
+			ran += slice.Stop - slice.Start
+		}
+		if ran != 28 {
+			t.Errorf("%s ran for %d ticks, want 28", r.Title, ran)
+		}
+	}
+}
+
+func TestWithDecisionInterval(t *testing.T) {
+	t.Parallel()
+	// B arrives mid-interval and is shorter than what's left of A.
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 8, Priority: 2},
+		{ProcessID: "B", ArrivalTime: 2, BurstDuration: 2, Priority: 1},
+	}
+	staggered := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 10, Priority: 4},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 8, Priority: 3},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 6, Priority: 2},
+		{ProcessID: "D", ArrivalTime: 3, BurstDuration: 4, Priority: 1},
+	}
+	tests := []struct {
+		name      string
+		schedule  func(io.Writer, string, []Process, ...ScheduleOption) ScheduleResult
+		unchanged func(io.Writer, string, []Process) ScheduleResult
+	}{
+		{name: "sjf", schedule: SJFScheduleWith, unchanged: SJFSchedule},
+		{name: "sjfp", schedule: SJFPriorityScheduleWith, unchanged: SJFPrioritySchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for _, workload := range [][]Process{processes, staggered} {
+				every := tt.schedule(io.Discard, tt.name, workload, WithDecisionInterval(1))
+				if diff := cmp.Diff(tt.unchanged(io.Discard, tt.name, workload), every); diff != "" {
+					t.Errorf(diff)
+				}
+			}
+
+			// with an interval longer than A, the schedule is non-preemptive.
+			rare := tt.schedule(io.Discard, tt.name, processes, WithDecisionInterval(10))
+			want := []TimeSlice{{PID: "A", Start: 0, Stop: 8}, {PID: "B", Start: 8, Stop: 10}}
+			if diff := cmp.Diff(want, rare.Gantt); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestWithPriorityDecay(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "hog", ArrivalTime: 0, BurstDuration: 20, Priority: 1},
+		{ProcessID: "waiter", ArrivalTime: 0, BurstDuration: 3, Priority: 5},
+	}
+	if diff := cmp.Diff(SJFPrioritySchedule(io.Discard, "sjfp", processes),
+		SJFPriorityScheduleWith(io.Discard, "sjfp", processes, WithPriorityDecay(0))); diff != "" {
+		t.Errorf("no decay changed the schedule: %s", diff)
+	}
+
+	// the hog decays to the waiter's priority after 4 ticks and, first in line, wins that tie;
+	// then they take turns until the waiter completes.
+	decayed := SJFPriorityScheduleWith(io.Discard, "sjfp", processes, WithPriorityDecay(1))
+	want := []TimeSlice{
+		{PID: "hog", Start: 0, Stop: 5},
+		{PID: "waiter", Start: 5, Stop: 6},
+		{PID: "hog", Start: 6, Stop: 7},
+		{PID: "waiter", Start: 7, Stop: 8},
+		{PID: "hog", Start: 8, Stop: 9},
+		{PID: "waiter", Start: 9, Stop: 10},
+		{PID: "hog", Start: 10, Stop: 23},
+	}
+	if diff := cmp.Diff(want, decayed.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+
+	// at a floor of 3 the hog stays ahead of the waiter.
+	floored := SJFPriorityScheduleWith(io.Discard, "sjfp", processes, WithPriorityDecay(1),
+		WithPriorityBounds(PriorityBounds{Floor: 3, Ceiling: 0, Order: LowerIsHigher}))
+	want = []TimeSlice{
+		{PID: "hog", Start: 0, Stop: 20},
+		{PID: "waiter", Start: 20, Stop: 23},
+	}
+	if diff := cmp.Diff(want, floored.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestProcess_String(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		process Process
+		want    string
+	}{
+		{
+			name:    "plain",
+			process: Process{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+			want:    "P1{burst=5 arrival=0 prio=2}",
+		},
+		{
+			name:    "deadline and rename",
+			process: Process{ProcessID: "P1#2", ArrivalTime: 3, BurstDuration: 1, Priority: -1, SoftDeadline: 9, OriginalID: "P1"},
+			want:    "P1#2{burst=1 arrival=3 prio=-1 deadline=9 was=P1}",
+		},
+		{
+			name:    "class",
+			process: Process{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 5, Priority: 2, Class: "batch"},
+			want:    "P1{burst=5 arrival=0 prio=2 class=batch}",
+		},
+		{
+			name:    "user",
+			process: Process{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 5, Priority: 2, User: "ana"},
+			want:    "P1{burst=5 arrival=0 prio=2 user=ana}",
+		},
+		{
+			name:    "affinity",
+			process: Process{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 5, Priority: 2, Affinity: 0b101},
+			want:    "P1{burst=5 arrival=0 prio=2 cpus=0;2}",
+		},
+		{
+			name:    "quota",
+			process: Process{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 5, Priority: 2, Quota: 2, Period: 5},
+			want:    "P1{burst=5 arrival=0 prio=2 quota=2/5}",
+		},
+		{
+			name:    "group",
+			process: Process{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 5, Priority: 2, Group: "db"},
+			want:    "P1{burst=5 arrival=0 prio=2 group=db}",
+		},
+		{
+			name:    "I/O bursts",
+			process: Process{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 9, Priority: 2, IOBursts: []IOBurst{{After: 3, Duration: 4}, {After: 7, Duration: 2}}},
+			want:    "P1{burst=9 arrival=0 prio=2 io=3:4;7:2}",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.process.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeSlice_String(t *testing.T) {
+	t.Parallel()
+	if got, want := (TimeSlice{PID: "P1", Start: 3, Stop: 8}).String(), "P1[3,8)"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestKillAt(t *testing.T) {
+	t.Parallel()
+	// A is killed halfway through its burst; B and C survive.
+	processes, err := loadProcesses(strings.NewReader(`ProcessID,Burst Duration,Arrival Time,Priority,Kill At
+A,10,0,1,5
+B,2,1,2,
+C,2,2,3,
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if processes[0].KillAt != 5 || processes[1].KillAt != 0 {
+		t.Fatalf("loaded kill times = %d, %d, want 5, 0", processes[0].KillAt, processes[1].KillAt)
+	}
+	tests := []struct {
+		name     string
+		schedule func(io.Writer, string, []Process) ScheduleResult
+	}{
+		{name: "fcfs", schedule: FCFSSchedule},
+		{name: "sjf", schedule: SJFSchedule},
+		{name: "sjfp", schedule: SJFPrioritySchedule},
+		{name: "rr", schedule: RRSchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			result := tt.schedule(&w, tt.name, processes)
+
+			var ranA int64
+			for _, slice := range result.Gantt {
+				if slice.PID != "A" {
+					continue
+				}
+				if slice.Stop > 5 {
+					t.Errorf("A ran after it was killed: %v", slice)
+				}
+				ranA += slice.Stop - slice.Start
+			}
+			if ranA == 0 {
+				t.Errorf("A has no gantt slices: %v", result.Gantt)
+			}
+
+			a := result.Processes[0]
+			if !a.Aborted || a.BurstDuration-a.Remaining != ranA {
+				t.Errorf("A = %+v, want aborted after running %d", a, ranA)
+			}
+			if result.WastedCPU != ranA {
+				t.Errorf("WastedCPU = %d, want %d", result.WastedCPU, ranA)
+			}
+			survivors := result.Processes[1:]
+			want := ScheduleResult{
+				AverageWait:       float64(survivors[0].Wait+survivors[1].Wait) / 2,
+				AverageTurnaround: float64(survivors[0].Turnaround+survivors[1].Turnaround) / 2,
+				Throughput:        2 / float64(max(survivors[0].Completion, survivors[1].Completion)),
+			}
+			if diff := AveragesDiff(result, want, 1e-9); diff != "" {
+				t.Errorf("averages over the survivors differ:\n%s", diff)
+			}
+			for _, p := range survivors {
+				if p.Aborted || p.Remaining != 0 {
+					t.Errorf("%s did not complete: %+v", p.ProcessID, p)
+				}
+			}
+			for _, want := range []string{"aborted", "Wasted CPU: "} {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("output is missing %q:\n%s", want, w.String())
+				}
+			}
+		})
+	}
+}
+
+func TestCPULimit(t *testing.T) {
+	t.Parallel()
+	// A is limited to 4 of its 10 ticks; B and C complete.
+	processes, err := loadProcesses(strings.NewReader(`ProcessID,Burst Duration,Arrival Time,Priority,Kill At,Suspensions,CPU Limit
+A,10,0,1,,,4
+B,3,1,2,,,
+C,2,2,3,,,
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if processes[0].CPULimit != 4 || processes[1].CPULimit != 0 {
+		t.Fatalf("loaded CPU limits = %d, %d, want 4, 0", processes[0].CPULimit, processes[1].CPULimit)
+	}
+	// a burst cut down to the limit runs the same, for schedulers that don't look at the burst.
+	truncated := slices.Clone(processes)
+	truncated[0].BurstDuration, truncated[0].CPULimit = 4, 0
+	tests := []struct {
+		name     string
+		schedule func(io.Writer, string, []Process) ScheduleResult
+		sameAs   bool
+	}{
+		{name: "fcfs", schedule: FCFSSchedule, sameAs: true},
+		{name: "sjf", schedule: SJFSchedule},
+		{name: "sjfp", schedule: SJFPrioritySchedule, sameAs: true},
+		{name: "rr", schedule: RRSchedule, sameAs: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			result := tt.schedule(&w, tt.name, processes)
+
+			var ranA, lastA int64
+			for _, slice := range result.Gantt {
+				if slice.PID == "A" {
+					ranA += slice.Stop - slice.Start
+					lastA = slice.Stop
+				}
+			}
+			a := result.Processes[0]
+			if ranA != 4 || !a.Limited || a.Aborted || a.Terminated != lastA {
+				t.Errorf("A = %+v after running %d until %d, want limited after exactly 4", a, ranA, lastA)
+			}
+			if result.LimitKills != 1 || result.LimitCPU != 4 || result.WastedCPU != 0 {
+				t.Errorf("limit kills = %d using %d, wasted %d, want 1 using 4 and none wasted",
+					result.LimitKills, result.LimitCPU, result.WastedCPU)
+			}
+
+			rest := result.Processes[1:]
+			want := ScheduleResult{
+				AverageWait:       float64(rest[0].Wait+rest[1].Wait) / 2,
+				AverageTurnaround: float64(rest[0].Turnaround+rest[1].Turnaround) / 2,
+				Throughput:        2 / float64(max(rest[0].Completion, rest[1].Completion)),
+			}
+			if diff := AveragesDiff(result, want, 1e-9); diff != "" {
+				t.Errorf("averages over the rest differ:\n%s", diff)
+			}
+			if tt.sameAs {
+				want := tt.schedule(io.Discard, tt.name, truncated)
+				if diff := cmp.Diff(want.Gantt, result.Gantt); diff != "" {
+					t.Errorf("gantt differs from A with a 4 tick burst: %s", diff)
+				}
+				if diff := cmp.Diff(want.Processes[1:], rest); diff != "" {
+					t.Errorf("the rest differ from A with a 4 tick burst: %s", diff)
+				}
+			}
+			for _, want := range []string{"limit exceeded", "CPU limit kills: 1 (used 4 CPU)"} {
+				if !strings.Contains(w.String(), want) {
+					t.Errorf("output is missing %q:\n%s", want, w.String())
+				}
+			}
+		})
+	}
+}
+
+func TestCanonicalizeGantt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		gantt   []TimeSlice
+		want    []TimeSlice
+		wantErr error
+	}{
+		{
+			name: "unsorted with a zero-length slice",
+			gantt: []TimeSlice{
+				{PID: "B", Start: 4, Stop: 6},
+				{PID: "A", Start: 0, Stop: 2},
+				{PID: "C", Start: 2, Stop: 2},
+				{PID: "A", Start: 2, Stop: 4},
+				{PID: "C", Start: 8, Stop: 9},
+			},
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 4},
+				{PID: "B", Start: 4, Stop: 6},
+				{PID: "C", Start: 8, Stop: 9},
+			},
+		},
+		{
+			name:  "empty",
+			gantt: []TimeSlice{},
+			want:  []TimeSlice{},
+		},
+		{
+			name: "overlap",
+			gantt: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 3},
+				{PID: "B", Start: 2, Stop: 4},
+			},
+			wantErr: ErrOverlappingSlices,
+		},
+		{
+			name:    "backwards",
+			gantt:   []TimeSlice{{PID: "A", Start: 3, Stop: 1}},
+			wantErr: ErrOverlappingSlices,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := CanonicalizeGantt(tt.gantt)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CanonicalizeGantt() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func Test_outputResultWith_remaining(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 8},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 6},
+		{ProcessID: "D", ArrivalTime: 3, BurstDuration: 4},
+	}
+	for _, result := range []ScheduleResult{
+		SJFSchedule(io.Discard, "sjf", processes),
+		SJFPrioritySchedule(io.Discard, "sjfp", processes),
+		RRSchedule(io.Discard, "rr", processes),
+	} {
+		var w bytes.Buffer
+		outputResultWith(&w, result, renderOptions{showRemaining: true})
+		if !strings.Contains(w.String(), "REMAINING") {
+			t.Errorf("%s: table has no remaining column:\n%s", result.Title, w.String())
+		}
+		for _, p := range result.Processes {
+			if p.Remaining != 0 {
+				t.Errorf("%s: %s has %d remaining, want 0", result.Title, p.ProcessID, p.Remaining)
+			}
+		}
+	}
+}
+
+// countdownContext reports cancellation once its Err method has been checked n times,
+// so tests can cancel deterministically in the middle of a run.
+type countdownContext struct {
+	context.Context
+	n int
+}
+
+func (c *countdownContext) Err() error {
+	if c.n <= 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+func TestScheduleCtx_cancelled(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 8},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 6},
+	}
+	type scheduleCtx func(context.Context, io.Writer, string, []Process) (ScheduleResult, error)
+	tests := []struct {
+		name     string
+		schedule scheduleCtx
+		checks   int
+		wantRan  int64
+	}{
+		{name: "fcfs", schedule: FCFSScheduleCtx, checks: 2, wantRan: 18},
+		{name: "sjf", schedule: SJFScheduleCtx, checks: 5, wantRan: 5},
+		{name: "sjfp", schedule: SJFPriorityScheduleCtx, checks: 5, wantRan: 5},
+		{name: "rr", schedule: RRScheduleCtx, checks: 2, wantRan: 8},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			ctx := &countdownContext{Context: context.Background(), n: tt.checks}
+			result, err := tt.schedule(ctx, &w, tt.name, processes)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("error = %v, want %v", err, context.Canceled)
+			}
+			var ran, remaining int64
+			for _, slice := range result.Gantt {
+				ran += slice.Stop - slice.Start
+			}
+			for _, p := range result.Processes {
+				remaining += p.Remaining
+			}
+			if ran != tt.wantRan {
+				t.Errorf("partial schedule ran %d ticks, want %d", ran, tt.wantRan)
+			}
+			if remaining != 24-ran {
+				t.Errorf("remaining = %d, want %d", remaining, 24-ran)
+			}
+			if w.Len() != 0 {
+				t.Errorf("partial schedule was rendered:\n%s", w.String())
+			}
+		})
+	}
+}
+
+func TestScheduleCtx_timeout(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+	result, err := RRScheduleCtx(ctx, io.Discard, "rr", []Process{{ProcessID: "A", BurstDuration: 5}})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if len(result.Gantt) != 0 || result.Processes[0].Remaining != 5 {
+		t.Errorf("result = %+v, want nothing scheduled", result)
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 6, Priority: 2},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 3, Priority: 1},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 8, Priority: 3, KillAt: 5},
+		{ProcessID: "D", ArrivalTime: 3, BurstDuration: 4, Priority: 2, CPULimit: 2},
+	}
+	type progress struct{ done, total int }
+	want := []progress{{1, 4}, {2, 4}, {3, 4}, {4, 4}}
+	tests := []struct {
+		name     string
+		schedule func(io.Writer, string, []Process, ...ScheduleOption) ScheduleResult
+	}{
+		{name: "fcfs", schedule: FCFSScheduleWith},
+		{name: "sjf", schedule: SJFScheduleWith},
+		{name: "sjfp", schedule: SJFPriorityScheduleWith},
+		{name: "rr", schedule: RRScheduleWith},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []progress
+			result := tt.schedule(io.Discard, tt.name, processes, WithProgress(func(done, total int) {
+				got = append(got, progress{done, total})
+			}))
+			if diff := cmp.Diff(want, got, cmp.AllowUnexported(progress{})); diff != "" {
+				t.Errorf(diff)
+			}
+			if diff := cmp.Diff(tt.schedule(io.Discard, tt.name, processes), result); diff != "" {
+				t.Errorf("progress changed the schedule: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSRTFSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		want      []TimeSlice
+	}{
+		{
+			// B arrives shorter than what's left of A and preempts it; C ties with B and waits.
+			name: "preempted on arrival",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 8},
+				{ProcessID: "B", ArrivalTime: 2, BurstDuration: 4},
+				{ProcessID: "C", ArrivalTime: 3, BurstDuration: 3},
+				{ProcessID: "D", ArrivalTime: 20, BurstDuration: 1},
+			},
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 2},
+				{PID: "B", Start: 2, Stop: 6},
+				{PID: "C", Start: 6, Stop: 9},
+				{PID: "A", Start: 9, Stop: 15},
+				{PID: "D", Start: 20, Stop: 21},
+			},
+		},
+		{
+			// B arrives with exactly what's left of A, which keeps running.
+			name: "no preemption on a tie",
+			processes: []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 5},
+				{ProcessID: "B", ArrivalTime: 2, BurstDuration: 3},
+			},
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 5},
+				{PID: "B", Start: 5, Stop: 8},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := SRTFSchedule(io.Discard, "srtf", tt.processes)
+			if diff := cmp.Diff(tt.want, result.Gantt); diff != "" {
+				t.Errorf(diff)
+			}
+			if diff := cmp.Diff(SJFSchedule(io.Discard, "srtf", tt.processes), result); diff != "" {
+				t.Errorf("SRTFSchedule differs from SJFSchedule: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSJFNonPreemptiveSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 9},
+		{ProcessID: "D", ArrivalTime: 3, BurstDuration: 4},
+		{ProcessID: "E", ArrivalTime: 30, BurstDuration: 2},
+	}
+	var w bytes.Buffer
+	result := SJFNonPreemptiveSchedule(&w, "Shortest-job-first (non-preemptive)", processes)
+	// A runs to completion although B arrives shorter; B and D tie and B queued first.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 8},
+		{PID: "B", Start: 8, Stop: 12},
+		{PID: "D", Start: 12, Stop: 16},
+		{PID: "C", Start: 16, Stop: 25},
+		{PID: "E", Start: 30, Stop: 32},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	// waits of 0, 7, 14, 9 and 0.
+	if result.AverageWait != 6 {
+		t.Errorf("AverageWait = %.2f, want 6.00", result.AverageWait)
+	}
+	if preemptive := SJFSchedule(io.Discard, "sjf", processes); preemptive.AverageWait >= result.AverageWait {
+		t.Errorf("preemptive average wait %.2f, want less than %.2f", preemptive.AverageWait, result.AverageWait)
+	}
+	if !strings.Contains(w.String(), "Shortest-job-first (non-preemptive)") {
+		t.Errorf("output is missing the title:\n%s", w.String())
+	}
+}
+
+func TestSJFBoundedWaitSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 3},
+		{ProcessID: "C", ArrivalTime: 3, BurstDuration: 3},
+		{ProcessID: "D", ArrivalTime: 5, BurstDuration: 3},
+		{ProcessID: "E", ArrivalTime: 7, BurstDuration: 3},
+	}
+	var w bytes.Buffer
+	result := SJFBoundedWaitSchedule(&w, "bounded", processes, 5)
+	// by 7 A has waited 6 ticks, so it runs to completion ahead of the shorter D and E.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 1},
+		{PID: "B", Start: 1, Stop: 4},
+		{PID: "C", Start: 4, Stop: 7},
+		{PID: "A", Start: 7, Stop: 16},
+		{PID: "D", Start: 16, Stop: 19},
+		{PID: "E", Start: 19, Stop: 22},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if !strings.Contains(w.String(), "Forced promotions: 1") {
+		t.Errorf("output is missing the promotions:\n%s", w.String())
+	}
+}
+
+func TestSRTFQuantumSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 9},
+		{ProcessID: "D", ArrivalTime: 3, BurstDuration: 4},
+		{ProcessID: "E", ArrivalTime: 30, BurstDuration: 2},
+	}
+	var w bytes.Buffer
+	result := SRTFQuantumSchedule(&w, "srtf-q3", processes, 3)
+	// B and D wait for A's quantum to end; A is re-evaluated at 14 and keeps the CPU.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 3},
+		{PID: "B", Start: 3, Stop: 7},
+		{PID: "D", Start: 7, Stop: 11},
+		{PID: "A", Start: 11, Stop: 16},
+		{PID: "C", Start: 16, Stop: 25},
+		{PID: "E", Start: 30, Stop: 32},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if !strings.Contains(w.String(), "Context switches: 5") {
+		t.Errorf("output is missing the context switches:\n%s", w.String())
+	}
+}
+
+func TestSRTFQuantumSchedule_bounds(t *testing.T) {
+	t.Parallel()
+	processes := exponentialWorkload(200, 10, 8, 6)
+	tests := []struct {
+		name    string
+		quantum int64
+		legacy  func(io.Writer, string, []Process) ScheduleResult
+	}{
+		{name: "quantum 1 is preemptive", quantum: 1, legacy: SJFSchedule},
+		{name: "quantum 0 is non-preemptive", quantum: 0, legacy: SJFNonPreemptiveSchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			want := tt.legacy(io.Discard, "sjf", processes)
+			if diff := cmp.Diff(want, SRTFQuantumSchedule(io.Discard, "sjf", processes, tt.quantum), cmpopts.IgnoreFields(ScheduleResult{}, "Notes")); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestSETFSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: "C", ArrivalTime: 6, BurstDuration: 1},
+	}
+	result := SETFSchedule(io.Discard, "setf", processes)
+	// B, with no CPU yet, preempts A, and the two then take turns; C preempts A as it arrives.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 1},
+		{PID: "B", Start: 1, Stop: 2},
+		{PID: "A", Start: 2, Stop: 3},
+		{PID: "B", Start: 3, Stop: 4},
+		{PID: "A", Start: 4, Stop: 6},
+		{PID: "C", Start: 6, Stop: 7},
+		{PID: "A", Start: 7, Stop: 8},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestPreemptivePrioritySchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 6, Priority: 3},
+		{ProcessID: "B", ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+		{ProcessID: "C", ArrivalTime: 3, BurstDuration: 2, Priority: 3},
+		{ProcessID: "D", ArrivalTime: 4, BurstDuration: 2, Priority: 1},
+	}
+	result := PreemptivePrioritySchedule(io.Discard, "priority", processes)
+	// B preempts A; D ties with B and waits for it; A then resumes ahead of C, which arrived later.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 2},
+		{PID: "B", Start: 2, Stop: 5},
+		{PID: "D", Start: 5, Stop: 7},
+		{PID: "A", Start: 7, Stop: 11},
+		{PID: "C", Start: 11, Stop: 13},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	// waits of 5, 0, 8 and 1.
+	if result.AverageWait != 3.5 {
+		t.Errorf("AverageWait = %.2f, want 3.50", result.AverageWait)
+	}
+}
+
+func TestPreemptionThresholdSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 6, Priority: 3, PreemptionThreshold: 2},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 3, Priority: 2},
+		{ProcessID: "C", ArrivalTime: 3, BurstDuration: 2, Priority: 1},
+	}
+	var w bytes.Buffer
+	result := PreemptionThresholdSchedule(&w, "threshold", processes)
+	// B isn't above A's threshold and waits; C is and preempts it, and B then runs ahead of A.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 3},
+		{PID: "C", Start: 3, Stop: 5},
+		{PID: "B", Start: 5, Stop: 8},
+		{PID: "A", Start: 8, Stop: 11},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if !strings.Contains(w.String(), "Context switches: 3") {
+		t.Errorf("output is missing the context switches:\n%s", w.String())
+	}
+	processes[0].PreemptionThreshold = 0
+	if switches := ContextSwitches(PreemptivePrioritySchedule(io.Discard, "priority", processes).Gantt); switches != 4 {
+		t.Errorf("PreemptivePrioritySchedule context switches = %d, want 4", switches)
+	}
+}
+
+func TestEDFSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 10, SoftDeadline: 30},
+		{ProcessID: "B", ArrivalTime: 2, BurstDuration: 3, SoftDeadline: 6},
+		{ProcessID: "C", ArrivalTime: 4, BurstDuration: 4, SoftDeadline: 20},
+		{ProcessID: "D", ArrivalTime: 5, BurstDuration: 2},
+		{ProcessID: "E", ArrivalTime: 6, BurstDuration: 5, SoftDeadline: 10},
+	}
+	var w bytes.Buffer
+	result := EDFSchedule(&w, "edf", processes)
+	// B preempts A and E preempts C, each with an earlier deadline; D, without one, runs last.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 2},
+		{PID: "B", Start: 2, Stop: 5},
+		{PID: "C", Start: 5, Stop: 6},
+		{PID: "E", Start: 6, Stop: 11},
+		{PID: "C", Start: 11, Stop: 14},
+		{PID: "A", Start: 14, Stop: 22},
+		{PID: "D", Start: 22, Stop: 24},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	// waits of 12, 0, 6, 17 and 0.
+	if result.AverageWait != 7 {
+		t.Errorf("AverageWait = %.2f, want 7.00", result.AverageWait)
+	}
+	// E can't meet its deadline of 10 even run at once.
+	if !strings.Contains(w.String(), "Missed deadlines: 1 (E), 1 late in total") {
+		t.Errorf("output is missing the missed deadlines:\n%s", w.String())
+	}
+}
+
+func TestWRRSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 8, Priority: 1},
+		{ProcessID: "B", ArrivalTime: 0, BurstDuration: 20, Priority: 3},
+		{ProcessID: "C", ArrivalTime: 1, BurstDuration: 2, Priority: -2},
+	}
+	result := WRRSchedule(io.Discard, "wrr", processes)
+	// B's weight of 3 gets it quanta of 12 to A's 4; C's weight counts as 1.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 4},
+		{PID: "B", Start: 4, Stop: 16},
+		{PID: "C", Start: 16, Stop: 18},
+		{PID: "A", Start: 18, Stop: 22},
+		{PID: "B", Start: 22, Stop: 30},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestRRBoostSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "B", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "C", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "X", ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: "D", ArrivalTime: 13, BurstDuration: 4},
+		{ProcessID: "E", ArrivalTime: 14, BurstDuration: 4},
+	}
+	var w bytes.Buffer
+	result := RRBoostSchedule(&w, "boost", processes, 14)
+	// D and E queue ahead of the preempted X, and by 20 X has waited 16 ticks, so it's promoted past E.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 4},
+		{PID: "B", Start: 4, Stop: 8},
+		{PID: "C", Start: 8, Stop: 12},
+		{PID: "X", Start: 12, Stop: 16},
+		{PID: "D", Start: 16, Stop: 20},
+		{PID: "X", Start: 20, Stop: 24},
+		{PID: "E", Start: 24, Stop: 28},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if !strings.Contains(w.String(), "Starvation boosts: 1") {
+		t.Errorf("output is missing the boosts:\n%s", w.String())
+	}
+}
+
+func TestLRTFSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 6},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 4},
+	}
+	result := LRTFSchedule(io.Discard, "lrtf", processes)
+	// B preempts A on arrival and keeps the CPU on the tie with C at 3; once all have one tick
+	// left, they complete back to back.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 1},
+		{PID: "B", Start: 1, Stop: 4},
+		{PID: "C", Start: 4, Stop: 6},
+		{PID: "B", Start: 6, Stop: 8},
+		{PID: "A", Start: 8, Stop: 9},
+		{PID: "C", Start: 9, Stop: 11},
+		{PID: "A", Start: 11, Stop: 12},
+		{PID: "B", Start: 12, Stop: 13},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	// waits of 9, 6 and 5.
+	if math.Abs(result.AverageWait-20.0/3) > 1e-9 {
+		t.Errorf("AverageWait = %.2f, want 6.67", result.AverageWait)
+	}
+}
+
+func TestLLFSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4, SoftDeadline: 10},
+		{ProcessID: "B", ArrivalTime: 0, BurstDuration: 7, SoftDeadline: 11},
+		{ProcessID: "C", ArrivalTime: 1, BurstDuration: 2},
+	}
+	result := LLFSchedule(io.Discard, "llf", processes)
+	// B starts with the least laxity, 4 against A's 6. Each then waits until the other's laxity is
+	// strictly less; on the ties at 4 and 6 the running process keeps the CPU. C has no deadline.
+	want := []TimeSlice{
+		{PID: "B", Start: 0, Stop: 3},
+		{PID: "A", Start: 3, Stop: 5},
+		{PID: "B", Start: 5, Stop: 7},
+		{PID: "A", Start: 7, Stop: 9},
+		{PID: "B", Start: 9, Stop: 11},
+		{PID: "C", Start: 11, Stop: 13},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if result.LateJobs != 0 {
+		t.Errorf("LateJobs = %d, want 0", result.LateJobs)
+	}
+	// EDF meets the same deadlines without switching, so it waits less.
+	if edf := EDFSchedule(io.Discard, "edf", processes); edf.LateJobs != 0 || edf.AverageWait >= result.AverageWait {
+		t.Errorf("EDF has %d late jobs and average wait %.2f, want 0 and less than %.2f", edf.LateJobs, edf.AverageWait, result.AverageWait)
+	}
+}
+
+func TestNonPreemptivePrioritySchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 6, Priority: 3},
+		{ProcessID: "B", ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+		{ProcessID: "C", ArrivalTime: 3, BurstDuration: 2, Priority: 3},
+		{ProcessID: "D", ArrivalTime: 4, BurstDuration: 2, Priority: 1},
+		{ProcessID: "E", ArrivalTime: 20, BurstDuration: 1, Priority: 5},
+	}
+	result := NonPreemptivePrioritySchedule(io.Discard, "priority", processes)
+	// A keeps the CPU although B arrives with a higher priority; B and D go first, in arrival order.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 6},
+		{PID: "B", Start: 6, Stop: 9},
+		{PID: "D", Start: 9, Stop: 11},
+		{PID: "C", Start: 11, Stop: 13},
+		{PID: "E", Start: 20, Stop: 21},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	// waits of 0, 4, 5, 8 and 0.
+	if result.AverageWait != 3.4 {
+		t.Errorf("AverageWait = %.2f, want 3.40", result.AverageWait)
+	}
+}
+
+func TestHRRNSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "L", ArrivalTime: 1, BurstDuration: 5},
+		{ProcessID: "S1", ArrivalTime: 3, BurstDuration: 2},
+		{ProcessID: "S2", ArrivalTime: 5, BurstDuration: 2},
+	}
+	result := HRRNSchedule(io.Discard, "hrrn", processes)
+	// at 4, L's ratio of 8/5 beats S1's 3/2, where SJF would run S1; at 9, S1's 8/2 beats S2's 6/2.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 4},
+		{PID: "L", Start: 4, Stop: 9},
+		{PID: "S1", Start: 9, Stop: 11},
+		{PID: "S2", Start: 11, Stop: 13},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	// waits of 0, 3, 6 and 6.
+	if result.AverageWait != 3.75 {
+		t.Errorf("AverageWait = %.2f, want 3.75", result.AverageWait)
+	}
+	if sjf := SJFNonPreemptiveSchedule(io.Discard, "sjf", processes); sjf.Gantt[1].PID != "S1" {
+		t.Errorf("non-preemptive SJF ran %s second, want S1", sjf.Gantt[1].PID)
+	}
+}
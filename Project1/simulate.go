@@ -0,0 +1,486 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// SimOptions tune Simulate.
+type SimOptions struct {
+	// Title names the schedule in its result.
+	Title string
+	// Quantum is the most ticks a picked process runs before going to the back of the ready
+	// queue. 0 runs it to completion.
+	Quantum int64
+	// QuantumOf, if set, is the quantum of each process instead of Quantum. It's given the
+	// process with the burst it has left.
+	QuantumOf func(p Process) int64
+	// PreemptedFirst puts a preempted process back ahead of any process arriving the moment its
+	// quantum ends, as the tick-by-tick SJF scheduler does. Round-robin queues those arrivals first.
+	PreemptedFirst bool
+	// KeepPlace leaves a process where it is in the ready queue while it runs and once it's
+	// preempted, for policies that rank the queue rather than rotate it.
+	KeepPlace bool
+	// Preemptive asks pick again at every decision point while a process runs: every tick, or
+	// every d ticks with WithDecisionInterval. Picking the running process again lets it carry on
+	// without being dispatched anew.
+	Preemptive bool
+	// MinRun, with Preemptive, lets a dispatched process run at least this long before pick is
+	// asked again, unless it's suspended.
+	MinRun int64
+	// AtArrivals, with Preemptive, only makes arrivals decision points, for ranks that don't
+	// change while a process runs: the ticks in between are run as one slice.
+	AtArrivals bool
+	// Hold makes the order strict, as in FCFS: pick sees suspended processes too, and one that's
+	// suspended when it's picked or while it runs keeps the CPU, idle, until it resumes.
+	Hold bool
+	// Arrived, if set, is told of each process as it joins the ready queue, in input order, for
+	// policies that keep queues of their own.
+	Arrived func(p Process, now int64)
+	// Ran, if set, is told of every slice once it has run: the process with the burst it has left
+	// after it, 0 when it completed, and when the slice started and stopped.
+	Ran func(p Process, start, stop int64)
+	// Left, if set, is told of each process as it leaves the ready queue for good: completed,
+	// killed or terminated at its CPU limit.
+	Left func(p Process, now int64)
+	// Blocked, if set, is asked after every slice that didn't finish p, given p with the burst it
+	// has left, until when p must stay off the ready queue. A time after now holds it back, and it
+	// rejoins the back of the queue then, in the order the processes were blocked.
+	Blocked func(p Process, now int64) int64
+	// Unblocked, if set, is told of each process held off the ready queue as it rejoins it, and
+	// when it was ready again: its I/O completed, its throttled window ended or the time Blocked
+	// gave came. A process ready again while another ran rejoins as the slice ends, ahead of the
+	// preempted process as arrivals are.
+	Unblocked func(p Process, at int64)
+	// Rank, if set, orders the ready queues of WithQueueSnapshots the way pick ranks them, the
+	// queue order breaking ties. Without it they're in queue order.
+	Rank func(a, b Process) int
+}
+
+// quantum is the quantum of p, with the burst it has left, or 0 to run it to completion.
+func (opts SimOptions) quantum(p Process) int64 {
+	if opts.QuantumOf != nil {
+		return max(opts.QuantumOf(p), 1)
+	}
+	return opts.Quantum
+}
+
+// Simulate runs processes through the skeleton every scheduler shares: it admits arrivals to a
+// ready queue in input order, idles while nothing is ready, and asks pick for the index of the
+// ready process to run next, for one quantum. A preempted process goes to the back of the queue,
+// behind any process that arrived while it ran (see PreemptedFirst). The ready processes pick
+// sees carry the burst they have left in BurstDuration.
+//
+// A scheduling policy is then only a pick function and options: FCFS picks 0 and runs to
+// completion, SJF picks the shortest burst at every decision point, and round-robin picks 0 every
+// quantum. Policies with queues of their own keep them up to date with the Arrived, Ran and Left
+// hooks. Kill times, suspensions, I/O bursts, CPU limits and quotas are honoured for every policy:
+// a killed process leaves at its KillAt, a suspended one keeps its place in the queue but pick
+// doesn't see it, one that reaches an IOBurst leaves the queue and rejoins its back once the I/O
+// completes, one that reaches its CPULimit is terminated, and one that has used its Quota is
+// throttled until its Period window ends, as BandwidthSchedule describes. A pick outside the ready
+// processes it was given stops the run with the partial schedule and an ErrInvalidArgs error.
+// There's one CPU, CPU 0, so a process whose Affinity doesn't allow it is rejected with
+// ErrUnsupportedOption.
+func Simulate(processes []Process, pick func(ready []Process, now int64) int, opts SimOptions) (ScheduleResult, error) {
+	return simulate(context.Background(), processes, pick, opts, newScheduleConfig())
+}
+
+// blockedEntry is a process Simulate holds off the ready queue until a time.
+type blockedEntry struct {
+	process Process
+	until   int64
+}
+
+// simulate is Simulate stopping early when ctx is done, tuned by cfg as the built-in schedulers
+// are. It writes nothing.
+func simulate(ctx context.Context, processes []Process, pick func(ready []Process, now int64) int, opts SimOptions, cfg scheduleConfig) (ScheduleResult, error) {
+	if err := checkOneCPU(processes); err != nil {
+		return ScheduleResult{Title: opts.Title}, err
+	}
+	s := cfg.start(processes)
+	var (
+		now       = s.time
+		gantt     = s.gantt
+		remaining = s.remaining
+		used      = s.used
+		done      = s.completed
+		ready     = s.queue
+		running   = s.running
+		ranFor    = s.ranFor
+		cold      = s.cold
+		arrivals  = newArrivals(processes, s.arrived)
+		blocked   []blockedEntry
+		snapshots []QueueSnapshot
+		quantum   int64
+		// window is the Period window each process with a Quota last ran in and quotaUsed how much
+		// of the quota it used there; throttled are the windows it was held back.
+		window    = make(map[string]int64)
+		quotaUsed = make(map[string]int64)
+		throttled = make(map[string][]Window)
+	)
+	quotas := make(map[string]Process)
+	for _, p := range processes {
+		if p.Quota > 0 {
+			quotas[p.ProcessID] = p
+		}
+	}
+	for _, slice := range gantt {
+		if p, ok := quotas[slice.PID]; ok {
+			window[p.ProcessID], quotaUsed[p.ProcessID] = p.chargeQuota(window[p.ProcessID], quotaUsed[p.ProcessID], slice.Start, slice.Stop)
+		}
+	}
+	// a process paused while blocked on I/O stays blocked until the I/O completes.
+	for _, p := range processes {
+		if io := p.ioWindows(gantt); len(io) > 0 && io[len(io)-1].Stop > now && !arrivals.arrived[p.ProcessID] {
+			arrivals.arrived[p.ProcessID] = true
+			blocked = append(blocked, blockedEntry{process: p, until: io[len(io)-1].Stop})
+		}
+	}
+	// withLeft is p carrying the burst it has left, as pick and the hooks see it.
+	withLeft := func(p Process) Process {
+		p.BurstDuration = remaining[p.ProcessID]
+		return p
+	}
+	// leave takes p out of the system for good.
+	leave := func(p Process) {
+		done[p.ProcessID], arrivals.arrived[p.ProcessID] = true, true
+		if p.ProcessID == running {
+			running = ""
+		}
+		if opts.Left != nil {
+			opts.Left(withLeft(p), now)
+		}
+	}
+	admit := func(t int64) {
+		arrivals.admit(t, func(p Process) {
+			ready = append(ready, p)
+			if opts.Arrived != nil {
+				opts.Arrived(withLeft(p), now)
+			}
+		})
+	}
+	// rejoin admits the arrivals by t and the blocked processes ready again by t, in the order they
+	// arrived or were ready again, arrivals first and then the order they were blocked on a tie.
+	rejoin := func(t int64) {
+		for {
+			first := -1
+			for i, entry := range blocked {
+				if entry.until <= t && (first < 0 || entry.until < blocked[first].until) {
+					first = i
+				}
+			}
+			if first < 0 {
+				admit(t)
+				return
+			}
+			entry := blocked[first]
+			admit(entry.until)
+			blocked = slices.Delete(blocked, first, first+1)
+			ready = append(ready, entry.process)
+			if opts.Unblocked != nil {
+				opts.Unblocked(withLeft(entry.process), entry.until)
+			}
+		}
+	}
+	eligible := func(p Process) bool { return opts.Hold || !p.suspendedAt(now) }
+	partial := func() ScheduleResult {
+		result := newScheduleResult(opts.Title, cfg.resultProcesses(processes, gantt, remaining), gantt)
+		for i := range result.Processes {
+			result.Processes[i].Throttled = throttled[result.Processes[i].ProcessID]
+		}
+		return result
+	}
+	var killable []Process
+	for _, p := range processes {
+		if p.KillAt > 0 {
+			killable = append(killable, p)
+		}
+	}
+	if i := slices.IndexFunc(ready, func(p Process) bool { return p.ProcessID == running }); i >= 0 {
+		quantum = opts.quantum(withLeft(ready[i]))
+	}
+
+	reported := len(done)
+	for len(done) < len(processes) {
+		reported = cfg.progress(reported, len(done), len(processes))
+		if err := ctx.Err(); err != nil {
+			return partial(), err
+		}
+		if cfg.pausesAt(now) {
+			state := SimState{Time: now, Queue: queueIDs(ready), Running: running, RanFor: ranFor, Cold: cold}
+			return cfg.paused(opts.Title, processes, gantt, remaining, state), nil
+		}
+		rejoin(now)
+		for _, p := range killable {
+			if !done[p.ProcessID] && p.killedBy(now) {
+				leave(p)
+			}
+		}
+		ready = slices.DeleteFunc(ready, func(p Process) bool { return done[p.ProcessID] })
+		blocked = slices.DeleteFunc(blocked, func(entry blockedEntry) bool { return done[entry.process.ProcessID] })
+
+		var candidates []Process
+		for _, p := range ready {
+			if eligible(p) {
+				candidates = append(candidates, withLeft(p))
+			}
+		}
+		if len(candidates) == 0 {
+			now = nextEvent(now, arrivals, ready, blocked, cfg)
+			continue
+		}
+
+		// keep running the same process between decisions, unless it was suspended or its quantum
+		// ran out.
+		next := slices.IndexFunc(ready, func(p Process) bool { return p.ProcessID == running })
+		expired := quantum > 0 && ranFor >= quantum
+		carryOn := next >= 0 && eligible(ready[next]) && !expired &&
+			(opts.Hold || opts.Preemptive && (ranFor < opts.MinRun || !cfg.decides(now)))
+		var queue []string
+		if cfg.queueSnapshots {
+			queue = rankedIDs(ready, withLeft, opts.Rank)
+		}
+		if !carryOn {
+			i := pick(slices.Clone(candidates), now)
+			if i < 0 || i >= len(candidates) {
+				return partial(), fmt.Errorf("%w: pick chose %d of %d ready processes at %d", ErrInvalidArgs, i, len(candidates), now)
+			}
+			next = slices.IndexFunc(ready, func(p Process) bool { return p.ProcessID == candidates[i].ProcessID })
+		}
+
+		p := ready[next]
+		id := p.ProcessID
+		if id != running || expired || !(opts.Preemptive || opts.Hold) {
+			if opts.Hold && p.suspendedAt(now) {
+				// the CPU idles while the process at the head is suspended.
+				now = p.resumeAt(now)
+				continue
+			}
+			snapshots = cfg.snapshot(snapshots, now, queue)
+			running, ranFor = id, 0
+			remaining[id], cold = cfg.dispatch(id, gantt, remaining[id])
+			if quantum = opts.quantum(withLeft(p)); quantum > 0 {
+				// every slice makes progress, however cold.
+				cold = min(cold, quantum-1)
+			}
+		} else if p.suspendedAt(now) {
+			// held through a suspension that started while it ran.
+			now = p.resumeAt(now)
+			continue
+		}
+
+		run := remaining[id] + cold
+		if quantum > 0 {
+			run = min(run, quantum-ranFor)
+		}
+		if opts.Preemptive && !opts.AtArrivals {
+			run = min(run, 1)
+		} else if arrival := arrivals.next(); opts.Preemptive && arrival >= 0 {
+			run = min(run, arrival-now)
+		}
+		if p.KillAt > 0 {
+			run = min(run, p.KillAt-now)
+		}
+		run = p.cpuBeforeIO(used[id], run)
+		run = p.quotaRun(now, window[id], quotaUsed[id], p.cpuLeft(used[id], p.runnableFor(now, run)))
+
+		start := now
+		gantt = appendTimeSlice(gantt, TimeSlice{PID: id, Start: start, Stop: start + run})
+		now += run
+		ranFor += run
+		used[id] += run
+		if p.Quota > 0 {
+			window[id], quotaUsed[id] = p.chargeQuota(window[id], quotaUsed[id], start, now)
+		}
+		// refilling the cache makes no progress on the burst.
+		refill := min(cold, run)
+		cold -= refill
+		remaining[id] -= run - refill
+		if opts.Ran != nil {
+			opts.Ran(withLeft(p), start, now)
+		}
+		if remaining[id] == 0 || p.killedBy(now) || p.limitedBy(used[id]) {
+			leave(p)
+			ready = slices.Delete(ready, next, next+1)
+			continue
+		}
+
+		if !opts.KeepPlace {
+			ready = slices.Delete(ready, next, next+1)
+			if opts.PreemptedFirst {
+				rejoin(now - 1)
+			} else {
+				rejoin(now)
+			}
+			ready = append(ready, p)
+		}
+		// blocked on I/O, the process is ready again once it completes.
+		until := now + p.ioAfter(used[id])
+		if p.throttledBy(now, quotaUsed[id]) {
+			end := (window[id] + 1) * p.Period
+			throttled[id] = append(throttled[id], Window{Start: now, Stop: end})
+			until = max(until, end)
+		} else if opts.Blocked != nil {
+			until = max(until, opts.Blocked(withLeft(p), now))
+		}
+		if until > now {
+			ready = slices.DeleteFunc(ready, func(q Process) bool { return q.ProcessID == id })
+			blocked = append(blocked, blockedEntry{process: p, until: until})
+			running = ""
+		}
+	}
+
+	cfg.progress(reported, len(done), len(processes))
+	result := partial()
+	result.ReadyQueueSnapshots = snapshots
+
+	return result, nil
+}
+
+// checkOneCPU rejects processes whose Affinity keeps them off CPU 0, the only CPU of Simulate.
+func checkOneCPU(processes []Process) error {
+	for _, p := range processes {
+		if !p.allowedOn(0) {
+			return fmt.Errorf("%w: process %q may not run on CPU 0, the only CPU of a single-CPU scheduler", ErrUnsupportedOption, p.ProcessID)
+		}
+	}
+	return nil
+}
+
+// nextEvent is when a Simulate with nothing to run at now next could: the next arrival, the end
+// of a block or of a queued process' suspension, or the pause.
+func nextEvent(now int64, arrivals *arrivals, ready []Process, blocked []blockedEntry, cfg scheduleConfig) int64 {
+	next := arrivals.next()
+	earliest := func(t int64) {
+		if t > now && (next < 0 || t < next) {
+			next = t
+		}
+	}
+	for _, entry := range blocked {
+		earliest(entry.until)
+	}
+	for _, p := range ready {
+		earliest(p.resumeAt(now))
+	}
+	if cfg.pauseAt != nil {
+		earliest(*cfg.pauseAt)
+	}
+	return next
+}
+
+// rankedIDs lists the ready queue ordered by rank, queue order breaking ties, or in queue order
+// without one.
+func rankedIDs(ready []Process, withLeft func(Process) Process, rank func(a, b Process) int) []string {
+	if rank == nil {
+		return queueIDs(ready)
+	}
+	ranked := make([]Process, len(ready))
+	for i, p := range ready {
+		ranked[i] = withLeft(p)
+	}
+	slices.SortStableFunc(ranked, rank)
+	return queueIDs(ranked)
+}
+
+// arrivals admits processes to a scheduler's queues as they arrive, in input order.
+type arrivals struct {
+	processes []Process
+	arrived   map[string]bool
+	// byArrival indexes processes by arrival time, input order breaking ties; those before
+	// cursor have been admitted.
+	byArrival []int
+	cursor    int
+}
+
+// newArrivals is the arrivals of processes, of which those in arrived already have.
+func newArrivals(processes []Process, arrived map[string]bool) *arrivals {
+	byArrival := make([]int, len(processes))
+	for i := range byArrival {
+		byArrival[i] = i
+	}
+	slices.SortStableFunc(byArrival, func(i, j int) int { return cmp.Compare(processes[i].ArrivalTime, processes[j].ArrivalTime) })
+	return &arrivals{processes: processes, arrived: arrived, byArrival: byArrival}
+}
+
+// admit calls join with every process arrived by t that wasn't yet, in input order.
+func (a *arrivals) admit(t int64, join func(p Process)) {
+	var due []int
+	for ; a.cursor < len(a.byArrival) && a.processes[a.byArrival[a.cursor]].ArrivalTime <= t; a.cursor++ {
+		due = append(due, a.byArrival[a.cursor])
+	}
+	slices.Sort(due)
+	for _, i := range due {
+		if p := a.processes[i]; !a.arrived[p.ProcessID] {
+			a.arrived[p.ProcessID] = true
+			join(p)
+		}
+	}
+}
+
+// next is when the earliest process yet to arrive does, or -1 if they all have.
+func (a *arrivals) next() int64 {
+	for _, i := range a.byArrival[a.cursor:] {
+		if p := a.processes[i]; !a.arrived[p.ProcessID] {
+			return p.ArrivalTime
+		}
+	}
+	return -1
+}
+
+// readyIndex is the index of the process id among the ready processes pick was given, or -1, for
+// policies that keep queues of their own.
+func readyIndex(ready []Process, id string) int {
+	return slices.IndexFunc(ready, func(p Process) bool { return p.ProcessID == id })
+}
+
+// pickFirst is the Simulate pick of FCFS and round-robin: the head of the ready queue.
+func pickFirst([]Process, int64) int { return 0 }
+
+// pickShortest is the Simulate pick of SJF: the least burst left, the earliest queued on a tie.
+func pickShortest(ready []Process, _ int64) int {
+	shortest := 0
+	for i, p := range ready {
+		if p.BurstDuration < ready[shortest].BurstDuration {
+			shortest = i
+		}
+	}
+	return shortest
+}
+
+// elapsedOf returns how much CPU a ready process Simulate passes to pick has had: the burst it has
+// in processes less the burst it has left. Policies that rank by the service received use it.
+func elapsedOf(processes []Process) func(p Process) int64 {
+	bursts := make(map[string]int64, len(processes))
+	for _, p := range processes {
+		bursts[p.ProcessID] = p.BurstDuration
+	}
+	return func(p Process) int64 { return bursts[p.ProcessID] - p.BurstDuration }
+}
+
+// pickHighestPriority is the Simulate pick of priority scheduling: the lowest Priority number, the
+// earliest queued on a tie.
+func pickHighestPriority(ready []Process, _ int64) int {
+	highest := 0
+	for i, p := range ready {
+		if p.Priority < ready[highest].Priority {
+			highest = i
+		}
+	}
+	return highest
+}
+
+// pickHighestResponseRatio is the Simulate pick of HRRN: the highest (wait + burst) / burst, the
+// earliest queued on a tie. The ratios are compared cross-multiplied to stay in integers.
+func pickHighestResponseRatio(ready []Process, now int64) int {
+	highest := 0
+	for i, p := range ready {
+		h := ready[highest]
+		if (now-p.ArrivalTime+p.BurstDuration)*h.BurstDuration > (now-h.ArrivalTime+h.BurstDuration)*p.BurstDuration {
+			highest = i
+		}
+	}
+	return highest
+}
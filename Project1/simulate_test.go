@@ -0,0 +1,174 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+	workloads := map[string][]Process{
+		"fixture": {
+			{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+			{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+			{ProcessID: "P2", ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+		},
+		"idle gaps": {
+			{ProcessID: "A", ArrivalTime: 2, BurstDuration: 3},
+			{ProcessID: "B", ArrivalTime: 9, BurstDuration: 7},
+			{ProcessID: "C", ArrivalTime: 10, BurstDuration: 1},
+		},
+		"exponential": exponentialWorkload(200, 10, 8, 5),
+	}
+	tests := []struct {
+		name   string
+		pick   func([]Process, int64) int
+		opts   SimOptions
+		legacy func(io.Writer, string, []Process) ScheduleResult
+	}{
+		{name: "fcfs", pick: pickFirst, opts: SimOptions{Title: "fcfs"}, legacy: FCFSSchedule},
+		{name: "sjf", pick: pickShortest, opts: SimOptions{Title: "sjf", Quantum: 1, PreemptedFirst: true}, legacy: SJFSchedule},
+		{name: "rr", pick: pickFirst, opts: SimOptions{Title: "rr", Quantum: defaultQuantum}, legacy: RRSchedule},
+	}
+	for _, tt := range tests {
+		for workload, processes := range workloads {
+			tt, processes := tt, processes
+			t.Run(tt.name+" "+workload, func(t *testing.T) {
+				t.Parallel()
+				want := tt.legacy(io.Discard, tt.opts.Title, processes)
+				got, err := Simulate(processes, tt.pick, tt.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(want, got); diff != "" {
+					t.Errorf(diff)
+				}
+			})
+		}
+	}
+}
+
+func TestSimulate_pickSeesRemainingBurst(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: "A", BurstDuration: 3}}
+	var seen []int64
+	_, _ = Simulate(processes, func(ready []Process, now int64) int {
+		seen = append(seen, now, ready[0].BurstDuration)
+		return 0
+	}, SimOptions{Quantum: 1})
+	// at each tick, the time and A's burst left.
+	if diff := cmp.Diff([]int64{0, 3, 1, 2, 2, 1}, seen); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestSimulate_badPick(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: "A", BurstDuration: 3}, {ProcessID: "B", ArrivalTime: 1, BurstDuration: 2}}
+	result, err := Simulate(processes, func(ready []Process, _ int64) int { return len(ready) }, SimOptions{Quantum: 1})
+	if !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("Simulate() error = %v, want %v", err, ErrInvalidArgs)
+	}
+	if len(result.Gantt) != 0 {
+		t.Errorf("Simulate() gantt = %v, want nothing run", result.Gantt)
+	}
+}
+
+func TestSimulate_pinnedOffCPU0(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: "A", BurstDuration: 3, Affinity: 0b10}}
+	if _, err := Simulate(processes, pickFirst, SimOptions{}); !errors.Is(err, ErrUnsupportedOption) {
+		t.Errorf("Simulate() error = %v, want %v", err, ErrUnsupportedOption)
+	}
+}
+
+func TestSimulate_killsSuspensionsAndLimits(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 6, Suspensions: []Window{{Start: 2, Stop: 4}}},
+		{ProcessID: "B", BurstDuration: 5, KillAt: 5},
+		{ProcessID: "C", ArrivalTime: 1, BurstDuration: 6, CPULimit: 2},
+	}
+	result, err := Simulate(processes, pickFirst, SimOptions{Quantum: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A is suspended as its first quantum ends, B is killed while it waits behind C, and C is
+	// terminated at its limit.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 2},
+		{PID: "B", Start: 2, Stop: 4},
+		{PID: "C", Start: 4, Stop: 6},
+		{PID: "A", Start: 6, Stop: 10},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if b, c := result.Processes[1], result.Processes[2]; !b.Aborted || !c.Limited {
+		t.Errorf("B = %+v, C = %+v, want B aborted and C limited", b, c)
+	}
+}
+
+func TestSimulate_ioBursts(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 5, IOBursts: []IOBurst{{After: 2, Duration: 4}}},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 3},
+	}
+	result, err := Simulate(processes, pickFirst, SimOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A leaves the CPU for its I/O at 2, B runs meanwhile and the CPU idles until A's I/O completes.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 2},
+		{PID: "B", Start: 2, Stop: 5},
+		{PID: "A", Start: 6, Stop: 9},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	a := result.Processes[0]
+	if diff := cmp.Diff([]Window{{Start: 2, Stop: 6}}, a.IO); diff != "" {
+		t.Errorf(diff)
+	}
+	if a.Blocked != 4 || a.Wait != 0 {
+		t.Errorf("A blocked %d and waited %d, want 4 and 0", a.Blocked, a.Wait)
+	}
+	wantStates := []StateInterval{
+		{Start: 0, Stop: 2, State: StateRunning},
+		{Start: 2, Stop: 6, State: StateBlocked},
+		{Start: 6, Stop: 9, State: StateRunning},
+	}
+	if diff := cmp.Diff(wantStates, StateTimeline(result)["A"]); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestSimulate_quota(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 4, Quota: 1, Period: 3},
+		{ProcessID: "B", BurstDuration: 4},
+	}
+	result, err := Simulate(processes, pickShortest, SimOptions{Preemptive: true, PreemptedFirst: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A uses its quota just as the window ending at 6 does, so it runs on into the next one.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 1},
+		{PID: "B", Start: 1, Stop: 5},
+		{PID: "A", Start: 5, Stop: 7},
+		{PID: "A", Start: 9, Stop: 10},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if diff := cmp.Diff([]Window{{Start: 1, Stop: 3}, {Start: 7, Stop: 9}}, result.Processes[0].Throttled); diff != "" {
+		t.Errorf(diff)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"io"
+	"slices"
+)
+
+// SRRSchedule is selfish round-robin. An arriving process waits in a new queue, its priority
+// growing by a every tick from 0; the accepted processes take turns round-robin for defaultQuantum,
+// their priority growing by b. A new process is accepted once its priority reaches the accepted
+// ones', or at once, with the highest priority of the new ones, when none is accepted. So with b of
+// 0 it's RRSchedule, and with b at least a it tends to FCFS; in between, the running processes are
+// favoured for a while before newcomers share the CPU. Rates below 0 count as 0.
+func SRRSchedule(w io.Writer, title string, processes []Process, a, b int64) ScheduleResult {
+	a, b = max(a, 0), max(b, 0)
+	var (
+		fresh    []string
+		accepted []string
+		level    int64 // the priority of the accepted processes
+		priority = make(map[string]int64, len(processes))
+		// current is the accepted process running its quantum, for ranFor; rotate is set when its
+		// quantum is up.
+		current string
+		ranFor  int64
+		rotate  bool
+	)
+	// accept accepts the new processes that caught up.
+	accept := func() {
+		if len(accepted) == 0 && len(fresh) > 0 {
+			highest := 0
+			for i, id := range fresh {
+				if priority[id] > priority[fresh[highest]] {
+					highest = i
+				}
+			}
+			level = priority[fresh[highest]]
+		}
+		waiting := fresh[:0]
+		for _, id := range fresh {
+			if priority[id] >= level {
+				accepted = append(accepted, id)
+			} else {
+				waiting = append(waiting, id)
+			}
+		}
+		fresh = waiting
+	}
+	pick := func(ready []Process, _ int64) int {
+		accept()
+		if rotate {
+			// processes accepted during the quantum queue ahead of the preempted one.
+			accepted = append(slices.DeleteFunc(accepted, func(id string) bool { return id == current }), current)
+			rotate, ranFor = false, 0
+		}
+		for _, id := range accepted {
+			if i := readyIndex(ready, id); i >= 0 {
+				return i
+			}
+		}
+		// every accepted process is suspended, so a new one runs meanwhile.
+		return 0
+	}
+	ran := func(p Process, start, stop int64) {
+		if p.ProcessID != current {
+			current, ranFor = p.ProcessID, 0
+		}
+		ranFor += stop - start
+		level += b * (stop - start)
+		for _, id := range fresh {
+			priority[id] += a * (stop - start)
+		}
+		rotate = p.BurstDuration > 0 && ranFor == defaultQuantum
+	}
+	left := func(p Process, _ int64) {
+		fresh = slices.DeleteFunc(fresh, func(id string) bool { return id == p.ProcessID })
+		accepted = slices.DeleteFunc(accepted, func(id string) bool { return id == p.ProcessID })
+		if p.ProcessID == current {
+			current, ranFor, rotate = "", 0, false
+		}
+	}
+
+	result, _ := Simulate(processes, pick, SimOptions{
+		Title:      title,
+		Preemptive: true,
+		Arrived:    func(p Process, _ int64) { fresh = append(fresh, p.ProcessID) },
+		Ran:        ran,
+		Left:       left,
+	})
+	outputResult(w, result)
+
+	return result
+}
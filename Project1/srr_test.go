@@ -0,0 +1,62 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSRRSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 10},
+		{ProcessID: "B", ArrivalTime: 3, BurstDuration: 4},
+	}
+	tests := []struct {
+		name string
+		a, b int64
+		want []TimeSlice
+	}{
+		{
+			// B is accepted on arrival: plain round-robin.
+			name: "b of 0",
+			a:    2,
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 4},
+				{PID: "B", Start: 4, Stop: 8},
+				{PID: "A", Start: 8, Stop: 14},
+			},
+		},
+		{
+			// B catches up with A's priority at 6, halfway through A's second quantum.
+			name: "a above b",
+			a:    2,
+			b:    1,
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 8},
+				{PID: "B", Start: 8, Stop: 12},
+				{PID: "A", Start: 12, Stop: 14},
+			},
+		},
+		{
+			// B never catches up, so it waits for A to complete.
+			name: "b as a",
+			a:    1,
+			b:    1,
+			want: []TimeSlice{
+				{PID: "A", Start: 0, Stop: 10},
+				{PID: "B", Start: 10, Stop: 14},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tt.want, SRRSchedule(io.Discard, "srr", processes, tt.a, tt.b).Gantt); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
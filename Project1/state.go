@@ -0,0 +1,233 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+)
+
+// SimState is a scheduler paused at a decision point: the clock, the gantt so far and the ready
+// queue in order. Resuming from the state a scheduler paused with gives exactly the schedule of
+// an uninterrupted run.
+type SimState struct {
+	Time  int64       `json:"time"`
+	Gantt []TimeSlice `json:"gantt"`
+	// Remaining is the burst left of every process that has run. A process missing from it has
+	// the burst left that its time in Gantt implies, which cache affinity can change.
+	Remaining map[string]int64 `json:"remaining,omitempty"`
+	// Queue lists the arrived, unfinished processes in ready queue order.
+	Queue []string `json:"queue"`
+	// Priorities are the effective priorities of queued processes whose priority has decayed
+	// (see WithPriorityDecay).
+	Priorities map[string]int64 `json:"priorities,omitempty"`
+	// Running is the process on the CPU when the state was taken, with how long it has run since
+	// it was dispatched and the cold ticks it has left (see WithCacheAffinity).
+	Running string `json:"running,omitempty"`
+	RanFor  int64  `json:"ranFor,omitempty"`
+	Cold    int64  `json:"cold,omitempty"`
+}
+
+// WithPauseAt stops the scheduler at its first decision point at or after time t. The partial
+// result isn't rendered and carries the SimState to resume from in its State field.
+func WithPauseAt(t int64) ScheduleOption {
+	return func(cfg *scheduleConfig) {
+		cfg.pauseAt = &t
+	}
+}
+
+// WithResume continues from state instead of an empty system at time 0.
+// The state should pass ValidateState for the same processes.
+func WithResume(state SimState) ScheduleOption {
+	return func(cfg *scheduleConfig) {
+		cfg.resume = &state
+	}
+}
+
+// simStart is the bookkeeping a scheduler starts from.
+type simStart struct {
+	time      int64
+	gantt     []TimeSlice
+	remaining map[string]int64
+	arrived   map[string]bool
+	completed map[string]bool
+	used      map[string]int64
+	queue     []Process
+	priority  map[string]int64
+	running   string
+	ranFor    int64
+	cold      int64
+}
+
+// start sets up a scheduler's bookkeeping for processes, from cfg.resume when given.
+func (cfg scheduleConfig) start(processes []Process) simStart {
+	s := simStart{
+		gantt:     make([]TimeSlice, 0),
+		remaining: make(map[string]int64, len(processes)),
+		arrived:   make(map[string]bool, len(processes)),
+		completed: make(map[string]bool, len(processes)),
+		used:      make(map[string]int64, len(processes)),
+		queue:     make([]Process, 0),
+	}
+	for _, p := range processes {
+		s.remaining[p.ProcessID] = p.BurstDuration
+	}
+	if cfg.resume == nil {
+		return s
+	}
+
+	state := cfg.resume
+	s.time, s.running, s.ranFor, s.cold = state.Time, state.Running, state.RanFor, state.Cold
+	s.priority = state.Priorities
+	s.gantt = append(s.gantt, state.Gantt...)
+	for _, id := range state.Queue {
+		i := slices.IndexFunc(processes, func(p Process) bool { return p.ProcessID == id })
+		s.queue = append(s.queue, processes[i])
+		s.arrived[id] = true
+	}
+	s.used = state.ran()
+	for _, p := range processes {
+		s.remaining[p.ProcessID] = state.remaining(p, s.used[p.ProcessID])
+		// a process that is done, or was terminated while out of the queue, is never queued again.
+		if s.remaining[p.ProcessID] == 0 || p.limitedBy(s.used[p.ProcessID]) || (p.killedBy(s.time) && !s.arrived[p.ProcessID]) {
+			s.arrived[p.ProcessID], s.completed[p.ProcessID] = true, true
+		}
+	}
+
+	return s
+}
+
+// ran is the CPU time of every process in the gantt.
+func (state SimState) ran() map[string]int64 {
+	ran := make(map[string]int64)
+	for _, slice := range state.Gantt {
+		ran[slice.PID] += slice.Stop - slice.Start
+	}
+	return ran
+}
+
+// remaining is the burst p has left after running for ran.
+func (state SimState) remaining(p Process, ran int64) int64 {
+	if remaining, ok := state.Remaining[p.ProcessID]; ok {
+		return remaining
+	}
+	return p.BurstDuration - ran
+}
+
+// pausesAt reports whether the scheduler should pause at time t.
+func (cfg scheduleConfig) pausesAt(t int64) bool {
+	return cfg.pauseAt != nil && t >= *cfg.pauseAt
+}
+
+// paused is the partial result of a scheduler paused with state.
+func (cfg scheduleConfig) paused(title string, processes []Process, gantt []TimeSlice, remaining map[string]int64, state SimState) ScheduleResult {
+	state.Gantt = slices.Clone(gantt)
+	state.Remaining = make(map[string]int64)
+	for _, slice := range gantt {
+		state.Remaining[slice.PID] = remaining[slice.PID]
+	}
+	result := newScheduleResult(title, cfg.resultProcesses(processes, gantt, remaining), gantt)
+	result.State = &state
+	return result
+}
+
+// queueIDs lists the processes of a ready queue in order.
+func queueIDs(queue []Process) []string {
+	ids := make([]string, len(queue))
+	for i, p := range queue {
+		ids[i] = p.ProcessID
+	}
+	return ids
+}
+
+// ValidateState rejects a state that is inconsistent with processes: unknown processes, overlapping
+// slices, progress outside a process' arrival and the state's time, more consumed than a
+// process' burst, or queued processes that haven't arrived or have already finished.
+func ValidateState(processes []Process, state SimState) error {
+	byID := make(map[string]Process, len(processes))
+	for _, p := range processes {
+		byID[p.ProcessID] = p
+	}
+	if _, err := CanonicalizeGantt(state.Gantt); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidState, err)
+	}
+
+	for _, slice := range state.Gantt {
+		p, ok := byID[slice.PID]
+		if !ok {
+			return fmt.Errorf("%w: gantt has unknown process %q", ErrInvalidState, slice.PID)
+		}
+		if slice.Stop > state.Time {
+			return fmt.Errorf("%w: %v runs past the current time %d", ErrInvalidState, slice, state.Time)
+		}
+		if slice.Start < p.ArrivalTime {
+			return fmt.Errorf("%w: %v runs before %s arrives at %d", ErrInvalidState, slice, p.ProcessID, p.ArrivalTime)
+		}
+	}
+	for id := range state.Remaining {
+		if _, ok := byID[id]; !ok {
+			return fmt.Errorf("%w: remaining burst of unknown process %q", ErrInvalidState, id)
+		}
+	}
+	ran := state.ran()
+	left := make(map[string]int64, len(processes))
+	for _, p := range processes {
+		left[p.ProcessID] = state.remaining(p, ran[p.ProcessID])
+		if left[p.ProcessID] < 0 || left[p.ProcessID] > p.BurstDuration {
+			return fmt.Errorf("%w: %s has %d left of a %d burst", ErrInvalidState, p.ProcessID, left[p.ProcessID], p.BurstDuration)
+		}
+	}
+
+	queued := make(map[string]bool, len(state.Queue))
+	for _, id := range state.Queue {
+		p, ok := byID[id]
+		switch {
+		case !ok:
+			return fmt.Errorf("%w: queue has unknown process %q", ErrInvalidState, id)
+		case queued[id]:
+			return fmt.Errorf("%w: %s is queued twice", ErrInvalidState, id)
+		case p.ArrivalTime > state.Time:
+			return fmt.Errorf("%w: %s is queued before it arrives at %d", ErrInvalidState, id, p.ArrivalTime)
+		case left[id] == 0:
+			return fmt.Errorf("%w: %s is queued but already finished", ErrInvalidState, id)
+		}
+		queued[id] = true
+	}
+	for id := range state.Priorities {
+		if !queued[id] {
+			return fmt.Errorf("%w: priority of unqueued process %q", ErrInvalidState, id)
+		}
+	}
+	if state.Running != "" && !queued[state.Running] {
+		return fmt.Errorf("%w: running process %q isn't queued", ErrInvalidState, state.Running)
+	}
+
+	return nil
+}
+
+// SaveState writes state as JSON.
+func SaveState(w io.Writer, state SimState) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(state)
+}
+
+// LoadState reads a state written by SaveState.
+func LoadState(r io.Reader) (SimState, error) {
+	var state SimState
+	if err := json.NewDecoder(r).Decode(&state); err != nil {
+		return SimState{}, fmt.Errorf("%w: decoding state", err)
+	}
+	return state, nil
+}
+
+func loadStateFile(name string) (SimState, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return SimState{}, fmt.Errorf("%w: error opening state file", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	return LoadState(f)
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithResume(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 7, Priority: 3},
+		{ProcessID: "B", ArrivalTime: 1, BurstDuration: 3, Priority: 1, Suspensions: []Window{{Start: 2, Stop: 5}}},
+		{ProcessID: "C", ArrivalTime: 2, BurstDuration: 5, Priority: 3, KillAt: 9},
+		{ProcessID: "D", ArrivalTime: 4, BurstDuration: 2, Priority: 2},
+		{ProcessID: "E", ArrivalTime: 6, BurstDuration: 4, Priority: 1, CPULimit: 3},
+		{ProcessID: "F", ArrivalTime: 30, BurstDuration: 3, Priority: 2},
+	}
+	schedulers := []struct {
+		name     string
+		schedule func(opts ...ScheduleOption) ScheduleResult
+	}{
+		{
+			name: "fcfs",
+			schedule: func(opts ...ScheduleOption) ScheduleResult {
+				return FCFSScheduleWith(io.Discard, "fcfs", processes, opts...)
+			},
+		},
+		{
+			name: "sjf",
+			schedule: func(opts ...ScheduleOption) ScheduleResult {
+				return SJFScheduleWith(io.Discard, "sjf", processes, opts...)
+			},
+		},
+		{
+			name: "sjf with cache affinity",
+			schedule: func(opts ...ScheduleOption) ScheduleResult {
+				return SJFScheduleWith(io.Discard, "sjf", processes, append(opts, WithCacheAffinity(0.3, 2))...)
+			},
+		},
+		{
+			name: "sjfp",
+			schedule: func(opts ...ScheduleOption) ScheduleResult {
+				return SJFPriorityScheduleWith(io.Discard, "sjfp", processes, append(opts, WithDecisionInterval(2))...)
+			},
+		},
+		{
+			name: "sjfp with priority decay",
+			schedule: func(opts ...ScheduleOption) ScheduleResult {
+				return SJFPriorityScheduleWith(io.Discard, "sjfp", processes, append(opts, WithPriorityDecay(1))...)
+			},
+		},
+		{
+			name: "rr",
+			schedule: func(opts ...ScheduleOption) ScheduleResult {
+				return RRQuantumScheduleWith(io.Discard, "rr", processes, 3, opts...)
+			},
+		},
+		{
+			name: "rr with cache affinity",
+			schedule: func(opts ...ScheduleOption) ScheduleResult {
+				return RRQuantumScheduleWith(io.Discard, "rr", processes, 3, append(opts, WithCacheAffinity(0.25, 1))...)
+			},
+		},
+	}
+	for _, tt := range schedulers {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			want := tt.schedule()
+			for _, split := range []int64{0, 1, 2, 5, 8, 9, 13, 20, 31, 100} {
+				paused := tt.schedule(WithPauseAt(split))
+				if paused.State == nil {
+					// there was no decision point left to pause at.
+					if diff := cmp.Diff(want, paused); diff != "" {
+						t.Errorf("split at %d: schedule without a pause differs from an uninterrupted run: %s", split, diff)
+					}
+					continue
+				}
+
+				var buf bytes.Buffer
+				if err := SaveState(&buf, *paused.State); err != nil {
+					t.Fatalf("split at %d: SaveState() error = %v", split, err)
+				}
+				state, err := LoadState(&buf)
+				if err != nil {
+					t.Fatalf("split at %d: LoadState() error = %v", split, err)
+				}
+				if err := ValidateState(processes, state); err != nil {
+					t.Fatalf("split at %d: ValidateState() error = %v", split, err)
+				}
+				if diff := cmp.Diff(want, tt.schedule(WithResume(state))); diff != "" {
+					t.Errorf("split at %d: resumed schedule differs from an uninterrupted run: %s", split, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateState(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4},
+		{ProcessID: "B", ArrivalTime: 2, BurstDuration: 3},
+		{ProcessID: "C", ArrivalTime: 8, BurstDuration: 1},
+	}
+	tests := []struct {
+		name    string
+		state   SimState
+		wantErr error
+	}{
+		{
+			name:  "in flight",
+			state: SimState{Time: 5, Gantt: []TimeSlice{{PID: "A", Start: 0, Stop: 4}, {PID: "B", Start: 4, Stop: 5}}, Queue: []string{"B"}, Running: "B"},
+		},
+		{
+			name:  "empty system",
+			state: SimState{},
+		},
+		{
+			name:    "overlapping slices",
+			state:   SimState{Time: 5, Gantt: []TimeSlice{{PID: "A", Start: 0, Stop: 3}, {PID: "B", Start: 2, Stop: 4}}},
+			wantErr: ErrOverlappingSlices,
+		},
+		{
+			name:    "unknown process",
+			state:   SimState{Time: 5, Gantt: []TimeSlice{{PID: "Z", Start: 0, Stop: 1}}},
+			wantErr: ErrInvalidState,
+		},
+		{
+			name:    "progress after current time",
+			state:   SimState{Time: 3, Gantt: []TimeSlice{{PID: "A", Start: 0, Stop: 4}}},
+			wantErr: ErrInvalidState,
+		},
+		{
+			name:    "runs before arrival",
+			state:   SimState{Time: 3, Gantt: []TimeSlice{{PID: "B", Start: 0, Stop: 1}}},
+			wantErr: ErrInvalidState,
+		},
+		{
+			name:    "consumed more than burst",
+			state:   SimState{Time: 9, Gantt: []TimeSlice{{PID: "A", Start: 0, Stop: 5}}},
+			wantErr: ErrInvalidState,
+		},
+		{
+			name:    "remaining more than burst",
+			state:   SimState{Time: 1, Gantt: []TimeSlice{{PID: "A", Start: 0, Stop: 1}}, Remaining: map[string]int64{"A": 5}},
+			wantErr: ErrInvalidState,
+		},
+		{
+			name:    "queued before arrival",
+			state:   SimState{Time: 5, Queue: []string{"A", "C"}},
+			wantErr: ErrInvalidState,
+		},
+		{
+			name:    "queued twice",
+			state:   SimState{Time: 5, Queue: []string{"A", "A"}},
+			wantErr: ErrInvalidState,
+		},
+		{
+			name:    "queued after finishing",
+			state:   SimState{Time: 5, Gantt: []TimeSlice{{PID: "A", Start: 0, Stop: 4}}, Queue: []string{"A"}},
+			wantErr: ErrInvalidState,
+		},
+		{
+			name:    "running but not queued",
+			state:   SimState{Time: 5, Queue: []string{"A"}, Running: "B"},
+			wantErr: ErrInvalidState,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateState(processes, tt.state)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateState() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && !errors.Is(err, ErrInvalidState) {
+				t.Errorf("ValidateState() error = %v, want it to wrap %v", err, ErrInvalidState)
+			}
+		})
+	}
+}
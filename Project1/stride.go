@@ -0,0 +1,47 @@
+package main
+
+import "io"
+
+// strideOne is the pass a process of weight 1 advances per tick; a process' stride is strideOne over
+// its weight.
+const strideOne int64 = 1 << 30
+
+// StrideSchedule is stride scheduling, the deterministic counterpart of LotterySchedule: every
+// process has a stride inversely proportional to its NiceWeight, and the ready process with the
+// lowest pass runs next for up to defaultQuantum, advancing its pass by its stride for every tick
+// it ran. So each process gets CPU in proportion to its weight, exactly rather than on average.
+// An arrival starts at the lowest pass of the ready processes, so it neither starves them nor is
+// starved itself. Equal passes run in queue order, a preempted process queueing behind the
+// arrivals of its quantum.
+func StrideSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	// pass is the pass of every process in the system.
+	pass := make(map[string]int64, len(processes))
+	result, _ := Simulate(processes, func(ready []Process, _ int64) int {
+		next := 0
+		for i, p := range ready {
+			if pass[p.ProcessID] < pass[ready[next].ProcessID] {
+				next = i
+			}
+		}
+		return next
+	}, SimOptions{
+		Title:   title,
+		Quantum: defaultQuantum,
+		Arrived: func(p Process, _ int64) {
+			lowest, first := int64(0), true
+			for _, other := range pass {
+				if first || other < lowest {
+					lowest, first = other, false
+				}
+			}
+			pass[p.ProcessID] = lowest
+		},
+		Ran: func(p Process, start, stop int64) {
+			pass[p.ProcessID] += strideOne / NiceWeight(p) * (stop - start)
+		},
+		Left: func(p Process, _ int64) { delete(pass, p.ProcessID) },
+	})
+	outputResult(w, result)
+
+	return result
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNiceWeight(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		priority int64
+		want     int64
+	}{
+		{priority: -30, want: 88761},
+		{priority: -20, want: 88761},
+		{priority: 0, want: 1024},
+		{priority: 1, want: 820},
+		{priority: 19, want: 15},
+		{priority: 25, want: 15},
+	}
+	for _, tt := range tests {
+		if got := NiceWeight(Process{Priority: tt.priority}); got != tt.want {
+			t.Errorf("NiceWeight(nice %d) = %d, want %d", tt.priority, got, tt.want)
+		}
+	}
+}
+
+func TestStrideSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 6},
+		{ProcessID: "B", BurstDuration: 6},
+	}
+	got := StrideSchedule(io.Discard, "stride", processes)
+	// equal weights take turns; on the tie at 8, A queued first.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 4},
+		{PID: "B", Start: 4, Stop: 8},
+		{PID: "A", Start: 8, Stop: 10},
+		{PID: "B", Start: 10, Stop: 12},
+	}
+	if diff := cmp.Diff(want, got.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestStrideSchedule_proportional(t *testing.T) {
+	t.Parallel()
+	// nice 0 against nice 5: weights 1024 and 335, about 3 to 1.
+	processes := []Process{
+		{ProcessID: "A", BurstDuration: 400},
+		{ProcessID: "B", BurstDuration: 400, Priority: 5},
+	}
+	result := StrideSchedule(io.Discard, "stride", processes)
+	var a, b int64
+	for _, slice := range result.Gantt {
+		if slice.Start >= 400 {
+			break
+		}
+		ran := min(slice.Stop, 400) - slice.Start
+		if slice.PID == "A" {
+			a += ran
+		} else {
+			b += ran
+		}
+	}
+	want := 400 * 1024 / (1024 + 335)
+	if a < int64(want)-defaultQuantum || a > int64(want)+defaultQuantum {
+		t.Errorf("A ran %d of the first 400 ticks and B %d, want about %d for A", a, b, want)
+	}
+}
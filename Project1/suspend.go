@@ -0,0 +1,105 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Window is the half-open interval [Start, Stop) of an administrative suspension, or of time a
+// process was held back.
+type Window struct {
+	Start int64 `json:"start"`
+	Stop  int64 `json:"stop"`
+}
+
+// parseWindows reads suspension windows written as "20-35;40-45". An empty string has none.
+func parseWindows(s string) ([]Window, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var windows []Window
+	for _, field := range strings.Split(s, ";") {
+		start, stop, ok := strings.Cut(strings.TrimSpace(field), "-")
+		if !ok {
+			return nil, fmt.Errorf("%w: suspension window %q is not <start>-<stop>", ErrInvalidArgs, field)
+		}
+		var (
+			w   Window
+			err error
+		)
+		if w.Start, err = strconv.ParseInt(start, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: suspension window %q: %v", ErrInvalidArgs, field, err)
+		}
+		if w.Stop, err = strconv.ParseInt(stop, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: suspension window %q: %v", ErrInvalidArgs, field, err)
+		}
+		if w.Stop <= w.Start {
+			return nil, fmt.Errorf("%w: suspension window %q ends before it starts", ErrInvalidArgs, field)
+		}
+		windows = append(windows, w)
+	}
+
+	return windows, nil
+}
+
+// suspendedAt reports whether p is ineligible to run at time t.
+func (p Process) suspendedAt(t int64) bool {
+	for _, w := range p.Suspensions {
+		if w.Start <= t && t < w.Stop {
+			return true
+		}
+	}
+	return false
+}
+
+// resumeAt is the first time at or after t when p isn't suspended.
+func (p Process) resumeAt(t int64) int64 {
+	for moved := true; moved; {
+		moved = false
+		for _, w := range p.Suspensions {
+			if w.Start <= t && t < w.Stop {
+				t, moved = w.Stop, true
+			}
+		}
+	}
+	return t
+}
+
+// runnableFor is how long p can run from t before its next suspension, at most limit.
+func (p Process) runnableFor(t, limit int64) int64 {
+	for _, w := range p.Suspensions {
+		if w.Start > t && w.Start-t < limit {
+			limit = w.Start - t
+		}
+	}
+	return limit
+}
+
+// suspendedBetween is how much of [from, to) p spent suspended. Overlapping windows count once.
+func (p Process) suspendedBetween(from, to int64) int64 {
+	return coveredBetween(p.Suspensions, from, to)
+}
+
+// coveredBetween is how much of [from, to) any of windows covers, counting overlaps once.
+func coveredBetween(windows []Window, from, to int64) int64 {
+	clipped := make([]Window, 0, len(windows))
+	for _, w := range windows {
+		if start, stop := max(w.Start, from), min(w.Stop, to); stop > start {
+			clipped = append(clipped, Window{Start: start, Stop: stop})
+		}
+	}
+	slices.SortFunc(clipped, func(a, b Window) int { return cmp.Compare(a.Start, b.Start) })
+
+	var total, reached int64
+	for _, w := range clipped {
+		start := max(w.Start, reached)
+		if w.Stop > start {
+			total += w.Stop - start
+		}
+		reached = max(reached, w.Stop)
+	}
+	return total
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseWindows(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    []Window
+		wantErr error
+	}{
+		{name: "none", s: "", want: nil},
+		{name: "one", s: "20-35", want: []Window{{Start: 20, Stop: 35}}},
+		{name: "several", s: "20-35; 40-45", want: []Window{{Start: 20, Stop: 35}, {Start: 40, Stop: 45}}},
+		{name: "missing stop", s: "20", wantErr: ErrInvalidArgs},
+		{name: "backwards", s: "35-20", wantErr: ErrInvalidArgs},
+		{name: "not a number", s: "a-b", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseWindows(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseWindows() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestSuspensions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		schedule func(io.Writer, string, []Process) ScheduleResult
+	}{
+		{name: "fcfs", schedule: FCFSSchedule},
+		{name: "sjf", schedule: SJFSchedule},
+		{name: "sjfp", schedule: SJFPrioritySchedule},
+		{name: "rr", schedule: RRSchedule},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name+" interrupts running", func(t *testing.T) {
+			t.Parallel()
+			// A is running when it gets suspended from 2 to 5.
+			processes := []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 6, Priority: 1, Suspensions: []Window{{Start: 2, Stop: 5}}},
+				{ProcessID: "B", ArrivalTime: 1, BurstDuration: 10, Priority: 2},
+			}
+			var w bytes.Buffer
+			result := tt.schedule(io.Discard, tt.name, processes)
+			for _, slice := range result.Gantt {
+				if slice.PID == "A" && slice.Start < 5 && slice.Stop > 2 {
+					t.Errorf("A ran while suspended: %v", result.Gantt)
+				}
+				// FCFS keeps its order, so only the others hand the CPU to B.
+				if tt.name != "fcfs" && slice.Start <= 2 && slice.Stop > 2 && slice.PID != "B" {
+					t.Errorf("B didn't get the CPU while A was suspended: %v", result.Gantt)
+				}
+			}
+			a := result.Processes[0]
+			if a.Remaining != 0 || a.Suspended != 3 || a.Wait != a.Turnaround-a.BurstDuration-3 {
+				t.Errorf("A = %+v, want completed with 3 suspended, not counted as wait", a)
+			}
+
+			OutputTimeline(&w, result)
+			if !strings.Contains(w.String(), "##zzz") {
+				t.Errorf("timeline has no suspension band:\n%s", w.String())
+			}
+		})
+		t.Run(tt.name+" covers the start", func(t *testing.T) {
+			t.Parallel()
+			// B would go first by length and priority, but is suspended until after A completes.
+			processes := []Process{
+				{ProcessID: "A", ArrivalTime: 0, BurstDuration: 4, Priority: 2},
+				{ProcessID: "B", ArrivalTime: 0, BurstDuration: 2, Priority: 1, Suspensions: []Window{{Start: 0, Stop: 6}}},
+			}
+			result := tt.schedule(io.Discard, tt.name, processes)
+			want := []TimeSlice{{PID: "A", Start: 0, Stop: 4}, {PID: "B", Start: 6, Stop: 8}}
+			if diff := cmp.Diff(want, result.Gantt); diff != "" {
+				t.Errorf(diff)
+			}
+			if b := result.Processes[1]; b.Suspended != 6 || b.Wait != 0 {
+				t.Errorf("B suspended = %d, wait = %d, want 6 and 0", b.Suspended, b.Wait)
+			}
+		})
+	}
+}
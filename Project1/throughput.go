@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// sparkTicks are the bar heights used by sparkline, lowest first.
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// ThroughputSeries counts completions in consecutive windows of the given width covering
+// the schedule from time 0 to the last completion. A window <= 0 defaults to a tenth of
+// that span (at least 1). It returns the counts along with the window width used.
+func ThroughputSeries(processes []ProcessResult, window int64) ([]int, int64) {
+	var makespan int64
+	for _, p := range processes {
+		makespan = max(makespan, p.Completion)
+	}
+	if makespan == 0 {
+		return nil, window
+	}
+	if window <= 0 {
+		window = max(makespan/10, 1)
+	}
+
+	series := make([]int, (makespan+window-1)/window)
+	for _, p := range processes {
+		if p.Completion <= 0 {
+			continue
+		}
+		// a completion at the end of a window belongs to it.
+		series[(p.Completion-1)/window]++
+	}
+
+	return series, window
+}
+
+// sparkline renders counts as bars scaled to the largest count.
+func sparkline(counts []int) string {
+	var peak int
+	for _, c := range counts {
+		peak = max(peak, c)
+	}
+	var sb strings.Builder
+	for _, c := range counts {
+		if peak == 0 {
+			sb.WriteRune(sparkTicks[0])
+			continue
+		}
+		sb.WriteRune(sparkTicks[c*(len(sparkTicks)-1)/peak])
+	}
+
+	return sb.String()
+}
+
+func outputThroughputSeries(w io.Writer, window int64, series []int, unit TimeUnit) {
+	if len(series) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Throughput per %s: %s %v\n", unit.formatSpan(window), sparkline(series), series)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestThroughputSeries(t *testing.T) {
+	t.Parallel()
+	// one long job ahead of four short ones: FCFS finishes nothing until the long job is done.
+	processes := []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 20},
+		{ProcessID: "P1", ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: "P2", ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: "P3", ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: "P4", ArrivalTime: 0, BurstDuration: 2},
+	}
+	tests := []struct {
+		name       string
+		result     ScheduleResult
+		window     int64
+		wantSeries []int
+		wantWindow int64
+	}{
+		{
+			name:       "fcfs clusters at the end",
+			result:     FCFSSchedule(io.Discard, "fcfs", processes),
+			window:     7,
+			wantSeries: []int{0, 0, 1, 4},
+			wantWindow: 7,
+		},
+		{
+			name:       "rr spreads completions",
+			result:     RRSchedule(io.Discard, "rr", processes),
+			window:     7,
+			wantSeries: []int{1, 3, 0, 1},
+			wantWindow: 7,
+		},
+		{
+			name:       "default window",
+			result:     RRSchedule(io.Discard, "rr", processes),
+			wantSeries: []int{0, 0, 1, 1, 1, 1, 0, 0, 0, 0, 0, 0, 0, 1},
+			wantWindow: 2,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			series, window := ThroughputSeries(tt.result.Processes, tt.window)
+			if diff := cmp.Diff(tt.wantSeries, series); diff != "" {
+				t.Errorf(diff)
+			}
+			if window != tt.wantWindow {
+				t.Errorf("window = %d, want %d", window, tt.wantWindow)
+			}
+		})
+	}
+}
+
+func Test_sparkline(t *testing.T) {
+	t.Parallel()
+	if got, want := sparkline([]int{0, 2, 4}), "▁▄█"; got != want {
+		t.Errorf("sparkline() = %q, want %q", got, want)
+	}
+	if got, want := sparkline([]int{0, 0}), "▁▁"; got != want {
+		t.Errorf("sparkline() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Timeline marks, one per time unit.
+const (
+	timelineRunning   = '#'
+	timelineWaiting   = '.'
+	timelineSuspended = 'z'
+	timelineThrottled = 't'
+	timelineBlocked   = 'i'
+	timelineAbsent    = ' '
+)
+
+// timelineMarks are the marks of each process state.
+var timelineMarks = map[ProcessState]rune{
+	StateRunning:   timelineRunning,
+	StateReady:     timelineWaiting,
+	StateSuspended: timelineSuspended,
+	StateThrottled: timelineThrottled,
+	StateBlocked:   timelineBlocked,
+}
+
+// OutputTimeline writes one row per process with a mark per time unit of the schedule: '#' while
+// running, '.' while waiting, 'z' while suspended, 'i' while blocked on I/O, 't' while throttled
+// and blank before arrival or after completion.
+func OutputTimeline(w io.Writer, result ScheduleResult) {
+	_, _ = fmt.Fprintln(w, "Timeline")
+	makespan := Makespan(result.Gantt)
+	widest := 0
+	for _, p := range result.Processes {
+		widest = max(widest, len(p.ProcessID))
+	}
+
+	states := StateTimeline(result)
+	for _, p := range result.Processes {
+		row := []rune(strings.Repeat(string(timelineAbsent), int(makespan)))
+		for _, interval := range states[p.ProcessID] {
+			for t := interval.Start; t < interval.Stop; t++ {
+				row[t] = timelineMarks[interval.State]
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%-*s |%s|\n", widest, p.ProcessID, string(row))
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// TimeUnit is the real-world time a tick stands for in rendered output. With
+// TimeUnit{Label: "ms", PerTick: 10} a 5 tick wait shows as "50ms". The simulation always
+// runs in integer ticks; the zero TimeUnit shows bare ticks.
+type TimeUnit struct {
+	Label   string
+	PerTick float64
+}
+
+// ParseTimeUnit parses the time per tick as a number followed by a label, e.g. "10ms" or
+// "0.5s". A bare label like "ms" is one of that unit per tick.
+func ParseTimeUnit(s string) (TimeUnit, error) {
+	i := strings.IndexFunc(s, unicode.IsLetter)
+	if i < 0 {
+		return TimeUnit{}, fmt.Errorf("%w: time unit %q has no label, e.g. \"10ms\"", ErrInvalidArgs, s)
+	}
+	unit := TimeUnit{Label: s[i:], PerTick: 1}
+	if i > 0 {
+		perTick, err := strconv.ParseFloat(s[:i], 64)
+		if err != nil || perTick <= 0 {
+			return TimeUnit{}, fmt.Errorf("%w: time unit %q needs a positive time per tick", ErrInvalidArgs, s)
+		}
+		unit.PerTick = perTick
+	}
+	return unit, nil
+}
+
+// Format shows a time or duration of ticks in the unit.
+func (u TimeUnit) Format(ticks int64) string {
+	if u.PerTick == 0 {
+		return strconv.FormatInt(ticks, 10)
+	}
+	return strconv.FormatFloat(float64(ticks)*u.PerTick, 'f', -1, 64) + u.Label
+}
+
+// formatSpan shows a span of ticks in the unit, or as "tick" or "N ticks" without one.
+func (u TimeUnit) formatSpan(ticks int64) string {
+	switch {
+	case u.PerTick != 0:
+		return u.Format(ticks)
+	case ticks == 1:
+		return "tick"
+	}
+	return fmt.Sprintf("%d ticks", ticks)
+}
+
+// formatAverage shows an average duration in ticks in the unit, to two decimal places.
+func (u TimeUnit) formatAverage(ticks float64) string {
+	if u.PerTick == 0 {
+		return fmt.Sprintf("%.2f", ticks)
+	}
+	return fmt.Sprintf("%.2f%s", ticks*u.PerTick, u.Label)
+}
+
+// formatRate shows a rate per tick, such as throughput, per one of the unit.
+func (u TimeUnit) formatRate(perTick float64) string {
+	if u.PerTick == 0 {
+		return fmt.Sprintf("%.2f", perTick)
+	}
+	return fmt.Sprintf("%.2f per %s", perTick/u.PerTick, u.Label)
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTimeUnit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    TimeUnit
+		wantErr error
+	}{
+		{name: "scaled", s: "10ms", want: TimeUnit{Label: "ms", PerTick: 10}},
+		{name: "fractional", s: "0.5s", want: TimeUnit{Label: "s", PerTick: 0.5}},
+		{name: "bare label", s: "us", want: TimeUnit{Label: "us", PerTick: 1}},
+		{name: "no label", s: "10", wantErr: ErrInvalidArgs},
+		{name: "zero", s: "0ms", wantErr: ErrInvalidArgs},
+		{name: "bad number", s: "1.2.3ms", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseTimeUnit(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseTimeUnit() error = %v, want %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestOutputResultWith_unit(t *testing.T) {
+	t.Parallel()
+	result := FCFSSchedule(io.Discard, "fcfs", []Process{
+		{ProcessID: "P0", ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: "P1", ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	})
+	var w bytes.Buffer
+	outputResultWith(&w, result, renderOptions{unit: TimeUnit{Label: "ms", PerTick: 2.5}})
+	for _, want := range []string{
+		"0ms    12.5ms 35ms", // gantt axis
+		"| P0 |        2 | 12.5ms | 0ms     | 0ms  | 12.5ms     | 12.5ms |",
+		"| P1 |        1 | 22.5ms | 7.5ms   | 5ms  | 27.5ms     | 35ms   |",
+		"Average wait: 2.50ms",
+		"Average turnaround: 20.00ms",
+		"Throughput: 0.06 per ms",
+		"Throughput per 2.5ms: ",
+	} {
+		if !strings.Contains(w.String(), want) {
+			t.Errorf("output is missing %q:\n%s", want, w.String())
+		}
+	}
+
+	// ticks stay the default.
+	w.Reset()
+	outputResult(&w, result)
+	if strings.Contains(w.String(), "ms") {
+		t.Errorf("output without a unit has labels:\n%s", w.String())
+	}
+	if !strings.Contains(w.String(), "Throughput per tick: ") {
+		t.Errorf("output without a unit is missing the throughput per tick:\n%s", w.String())
+	}
+}
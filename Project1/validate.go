@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	ErrNoProcesses      = errors.New("no processes")
+	ErrDuplicateID      = errors.New("duplicate process ID")
+	ErrNegativeBurst    = errors.New("non-positive burst duration")
+	ErrUnknownAlgorithm = errors.New("unknown scheduling algorithm")
+	// ErrUnsupportedOption is returned when an option is given to a scheduler that ignores it.
+	ErrUnsupportedOption = errors.New("unsupported option")
+	// ErrOverlappingSlices is returned for a gantt where two slices claim the CPU at once.
+	ErrOverlappingSlices = errors.New("overlapping time slices")
+	// ErrInvalidState is returned for a saved simulation state that doesn't fit the workload.
+	ErrInvalidState = errors.New("invalid simulation state")
+	// ErrLittlesLaw is returned for a schedule whose statistics break Little's law.
+	ErrLittlesLaw = errors.New("schedule breaks Little's law")
+	// ErrStateTimeline is returned for a process state history that doesn't tile its lifetime.
+	ErrStateTimeline = errors.New("state timeline has gaps or overlaps")
+	// ErrInvalidGanttCSV is returned for a gantt CSV row that isn't a PID, Start and a later Stop.
+	ErrInvalidGanttCSV = errors.New("invalid gantt CSV")
+)
+
+// ValidateProcesses rejects workloads the schedulers can't produce meaningful results for.
+// Every bookkeeping map is keyed by ProcessID, so IDs must be unique.
+func ValidateProcesses(processes []Process) error {
+	if len(processes) == 0 {
+		return ErrNoProcesses
+	}
+	seen := make(map[string]bool, len(processes))
+	for _, p := range processes {
+		if seen[p.ProcessID] {
+			return fmt.Errorf("%w: %q", ErrDuplicateID, p.ProcessID)
+		}
+		seen[p.ProcessID] = true
+		if p.BurstDuration <= 0 {
+			return fmt.Errorf("%w: process %q has burst %d", ErrNegativeBurst, p.ProcessID, p.BurstDuration)
+		}
+		if p.ArrivalTime < 0 {
+			return fmt.Errorf("%w: process %q arrives at %d, before the clock starts at 0", ErrInvalidArgs, p.ProcessID, p.ArrivalTime)
+		}
+		if p.CPULimit < 0 {
+			return fmt.Errorf("%w: process %q has CPU limit %d", ErrInvalidArgs, p.ProcessID, p.CPULimit)
+		}
+		if p.Quota < 0 || p.Quota > 0 && p.Period < p.Quota {
+			return fmt.Errorf("%w: process %q has quota %d per %d", ErrInvalidArgs, p.ProcessID, p.Quota, p.Period)
+		}
+		if err := p.checkIOBursts(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Duplicate process ID policies for -dedupe.
+const (
+	DedupeReject = "reject"
+	DedupeRename = "rename"
+)
+
+// Remap records a duplicate process ID that was renamed.
+type Remap struct {
+	OriginalID string
+	NewID      string
+}
+
+// RenameDuplicateIDs keeps the first process with each ID and renames later ones to
+// "<id>#2", "<id>#3" and so on, skipping names already in use. Renamed processes keep
+// their original ID in OriginalID so results can be traced back.
+func RenameDuplicateIDs(processes []Process) ([]Process, []Remap) {
+	taken := make(map[string]bool, len(processes))
+	for _, p := range processes {
+		taken[p.ProcessID] = true
+	}
+
+	var (
+		renamed = make([]Process, len(processes))
+		seen    = make(map[string]int, len(processes))
+		remaps  []Remap
+	)
+	for i, p := range processes {
+		renamed[i] = p
+		seen[p.ProcessID]++
+		if seen[p.ProcessID] == 1 {
+			continue
+		}
+		n := seen[p.ProcessID]
+		id := p.ProcessID + "#" + strconv.Itoa(n)
+		for taken[id] {
+			n++
+			id = p.ProcessID + "#" + strconv.Itoa(n)
+		}
+		seen[p.ProcessID] = n
+		taken[id] = true
+		renamed[i].ProcessID = id
+		renamed[i].OriginalID = p.ProcessID
+		remaps = append(remaps, Remap{OriginalID: p.ProcessID, NewID: id})
+	}
+
+	return renamed, remaps
+}
+
+// applyDedupePolicy renames duplicate IDs under DedupeRename; any other policy leaves
+// them for ValidateProcesses to reject.
+func applyDedupePolicy(processes []Process, policy string) ([]Process, []Remap, error) {
+	switch policy {
+	case "", DedupeReject:
+		return processes, nil, nil
+	case DedupeRename:
+		renamed, remaps := RenameDuplicateIDs(processes)
+		return renamed, remaps, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: unknown -dedupe policy %q", ErrInvalidArgs, policy)
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateProcesses(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		processes []Process
+		wantErr   error
+	}{
+		{
+			name:    "no processes",
+			wantErr: ErrNoProcesses,
+		},
+		{
+			name: "duplicate ID",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 5},
+				{ProcessID: "P0", BurstDuration: 3},
+			},
+			wantErr: ErrDuplicateID,
+		},
+		{
+			name: "negative burst",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: -1},
+			},
+			wantErr: ErrNegativeBurst,
+		},
+		{
+			name: "zero burst",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 0},
+			},
+			wantErr: ErrNegativeBurst,
+		},
+		{
+			name: "negative arrival",
+			processes: []Process{
+				{ProcessID: "P0", ArrivalTime: -5, BurstDuration: 5},
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "quota over its period",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 5, Quota: 6, Period: 5},
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "I/O burst after the burst",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 5, IOBursts: []IOBurst{{After: 5, Duration: 2}}},
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "I/O bursts out of order",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 5, IOBursts: []IOBurst{{After: 3, Duration: 2}, {After: 1, Duration: 2}}},
+			},
+			wantErr: ErrInvalidArgs,
+		},
+		{
+			name: "valid",
+			processes: []Process{
+				{ProcessID: "P0", BurstDuration: 5},
+				{ProcessID: "P1", ArrivalTime: 2, BurstDuration: 3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := ValidateProcesses(tt.processes); !errors.Is(err, tt.wantErr) {
+				t.Errorf("ValidateProcesses() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_runScheduler(t *testing.T) {
+	t.Parallel()
+	processes := []Process{{ProcessID: "P0", BurstDuration: 5}}
+	tests := []struct {
+		name      string
+		scheduler Scheduler
+		wantErr   error
+	}{
+		{name: "fcfs", scheduler: fcfs},
+		{name: "rr", scheduler: rr},
+		{name: "unset", scheduler: 0, wantErr: ErrUnknownAlgorithm},
+		{name: "out of range", scheduler: Scheduler(99), wantErr: ErrUnknownAlgorithm},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := runScheduler(io.Discard, tt.scheduler, processes, runOptions{}); !errors.Is(err, tt.wantErr) {
+				t.Errorf("runScheduler() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_applyDedupePolicy(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "P3", ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: "P3", ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: "P3#2", ArrivalTime: 2, BurstDuration: 1},
+		{ProcessID: "P3", ArrivalTime: 3, BurstDuration: 1},
+	}
+	tests := []struct {
+		name       string
+		policy     string
+		wantIDs    []string
+		wantRemaps []Remap
+		wantErr    error
+	}{
+		{
+			name:    "reject",
+			policy:  DedupeReject,
+			wantIDs: []string{"P3", "P3", "P3#2", "P3"},
+			wantErr: ErrDuplicateID,
+		},
+		{
+			name:    "rename",
+			policy:  DedupeRename,
+			wantIDs: []string{"P3", "P3#3", "P3#2", "P3#4"},
+			wantRemaps: []Remap{
+				{OriginalID: "P3", NewID: "P3#3"},
+				{OriginalID: "P3", NewID: "P3#4"},
+			},
+		},
+		{
+			name:    "unknown policy",
+			policy:  "merge",
+			wantErr: ErrInvalidArgs,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, remaps, err := applyDedupePolicy(processes, tt.policy)
+			if err == nil {
+				err = ValidateProcesses(got)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			var ids []string
+			for _, p := range got {
+				ids = append(ids, p.ProcessID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, ids); diff != "" {
+				t.Errorf(diff)
+			}
+			if diff := cmp.Diff(tt.wantRemaps, remaps); diff != "" {
+				t.Errorf(diff)
+			}
+			if err != nil {
+				return
+			}
+
+			var w bytes.Buffer
+			outputRemaps(&w, remaps)
+			result := FCFSSchedule(&w, "fcfs", got)
+			want := `Renamed duplicate process IDs
+  P3 -> P3#3
+  P3 -> P3#4
+
+`
+			if diff := cmp.Diff(want, w.String()[:len(want)]); diff != "" {
+				t.Errorf(diff)
+			}
+			wantGantt := []TimeSlice{
+				{PID: "P3", Start: 0, Stop: 2},
+				{PID: "P3#3", Start: 2, Stop: 4},
+				{PID: "P3#2", Start: 4, Stop: 5},
+				{PID: "P3#4", Start: 5, Stop: 6},
+			}
+			if diff := cmp.Diff(wantGantt, result.Gantt); diff != "" {
+				t.Errorf(diff)
+			}
+			if result.Processes[1].OriginalID != "P3" {
+				t.Errorf("OriginalID = %q, want P3", result.Processes[1].OriginalID)
+			}
+		})
+	}
+}
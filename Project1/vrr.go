@@ -0,0 +1,112 @@
+package main
+
+import (
+	"io"
+	"slices"
+)
+
+// vrrEntry is a process waiting in the auxiliary queue of VRRSchedule with the quantum it has left.
+type vrrEntry struct {
+	process Process
+	quantum int64
+}
+
+// VRRSchedule is virtual round-robin. A process that blocks on one of its IOBursts leaves the CPU
+// with the rest of its quantum, and once the I/O completes it waits in an auxiliary queue that
+// takes precedence over the ready queue, to run out that leftover quantum. After that it goes to
+// the back of the ready queue as usual, so I/O-bound processes get their fair share of the CPU. A
+// process that blocks just as its quantum ends has none left over and rejoins the ready queue. The
+// auxiliary queue doesn't preempt the running process. Suspensions and throttling are handled as
+// in RRSchedule, which it is without I/O bursts.
+func VRRSchedule(w io.Writer, title string, processes []Process) ScheduleResult {
+	var (
+		// ready and auxiliary are the queues of every runnable process but current.
+		ready     []Process
+		auxiliary []vrrEntry
+		current   *vrrEntry
+		ranFor    int64
+		// requeue is set when current used up its quantum or was suspended, at stopped.
+		requeue bool
+		stopped int64
+		// ran is the CPU time of every process, and leftover the quantum of those blocked on I/O.
+		ran      = make(map[string]int64, len(processes))
+		leftover = make(map[string]int64)
+	)
+	// flush puts a process whose quantum ended at the back of the ready queue, behind the processes
+	// that arrived or completed their I/O by then.
+	flush := func() {
+		if requeue {
+			ready, current, requeue = append(ready, current.process), nil, false
+		}
+	}
+	pick := func(runnable []Process, _ int64) int {
+		flush()
+		if current != nil && readyIndex(runnable, current.process.ProcessID) < 0 {
+			// throttled, it rejoins the ready queue once unblocked.
+			current = nil
+		}
+		if current == nil {
+			isRunnable := func(p Process) bool { return readyIndex(runnable, p.ProcessID) >= 0 }
+			if i := slices.IndexFunc(auxiliary, func(entry vrrEntry) bool { return isRunnable(entry.process) }); i >= 0 {
+				current = &vrrEntry{process: auxiliary[i].process, quantum: auxiliary[i].quantum}
+				auxiliary = slices.Delete(auxiliary, i, i+1)
+			} else {
+				i := slices.IndexFunc(ready, isRunnable)
+				current = &vrrEntry{process: ready[i], quantum: defaultQuantum}
+				ready = slices.Delete(ready, i, i+1)
+			}
+			ranFor = 0
+		}
+		return readyIndex(runnable, current.process.ProcessID)
+	}
+
+	result, _ := Simulate(processes, pick, SimOptions{
+		Title:      title,
+		Preemptive: true,
+		KeepPlace:  true,
+		Arrived:    func(p Process, _ int64) { ready = append(ready, p) },
+		Ran: func(p Process, start, stop int64) {
+			ranFor += stop - start
+			ran[p.ProcessID] += stop - start
+			stopped = stop
+			switch {
+			case p.BurstDuration == 0:
+			case p.ioAfter(ran[p.ProcessID]) > 0:
+				// blocked on I/O with the rest of its quantum.
+				leftover[p.ProcessID] = current.quantum - ranFor
+				current = nil
+			case ranFor == current.quantum || p.suspendedAt(stop):
+				requeue = true
+			}
+		},
+		Unblocked: func(p Process, at int64) {
+			if at > stopped {
+				flush()
+			}
+			isP := func(q Process) bool { return q.ProcessID == p.ProcessID }
+			ready = slices.DeleteFunc(ready, isP)
+			if current != nil && isP(current.process) {
+				// throttled while it ran.
+				current = nil
+			}
+			if quantum := leftover[p.ProcessID]; quantum > 0 {
+				auxiliary = append(auxiliary, vrrEntry{process: p, quantum: quantum})
+			} else {
+				ready = append(ready, p)
+			}
+			delete(leftover, p.ProcessID)
+		},
+		Left: func(p Process, _ int64) {
+			isP := func(q Process) bool { return q.ProcessID == p.ProcessID }
+			ready = slices.DeleteFunc(ready, isP)
+			auxiliary = slices.DeleteFunc(auxiliary, func(entry vrrEntry) bool { return isP(entry.process) })
+			delete(leftover, p.ProcessID)
+			if current != nil && isP(current.process) {
+				current, requeue = nil, false
+			}
+		},
+	})
+	outputResult(w, result)
+
+	return result
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVRRSchedule(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: "A", ArrivalTime: 0, BurstDuration: 6, IOBursts: []IOBurst{{After: 2, Duration: 3}}},
+		{ProcessID: "B", ArrivalTime: 0, BurstDuration: 8},
+		{ProcessID: "C", ArrivalTime: 1, BurstDuration: 3},
+	}
+	result := VRRSchedule(io.Discard, "vrr", processes)
+	// A blocks on I/O at 2 with 2 of its quantum left. It completes at 5 but B keeps the CPU to the
+	// end of its quantum; A then runs out its leftover quantum ahead of C and goes to the back.
+	want := []TimeSlice{
+		{PID: "A", Start: 0, Stop: 2},
+		{PID: "B", Start: 2, Stop: 6},
+		{PID: "A", Start: 6, Stop: 8},
+		{PID: "C", Start: 8, Stop: 11},
+		{PID: "B", Start: 11, Stop: 15},
+		{PID: "A", Start: 15, Stop: 17},
+	}
+	if diff := cmp.Diff(want, result.Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+	if diff := cmp.Diff([]Window{{Start: 2, Stop: 5}}, result.Processes[0].IO); diff != "" {
+		t.Errorf(diff)
+	}
+	// RRSchedule sends A back behind C instead.
+	want = []TimeSlice{
+		{PID: "A", Start: 0, Stop: 2},
+		{PID: "B", Start: 2, Stop: 6},
+		{PID: "C", Start: 6, Stop: 9},
+		{PID: "A", Start: 9, Stop: 13},
+		{PID: "B", Start: 13, Stop: 17},
+	}
+	if diff := cmp.Diff(want, RRSchedule(io.Discard, "rr", processes).Gantt); diff != "" {
+		t.Errorf(diff)
+	}
+}